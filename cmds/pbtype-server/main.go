@@ -13,25 +13,124 @@ import (
 	"github.com/tierklinik-dobersberg/apis/pkg/discovery/consuldiscover"
 	"github.com/tierklinik-dobersberg/apis/pkg/discovery/wellknown"
 	"github.com/tierklinik-dobersberg/apis/pkg/server"
+	"github.com/tierklinik-dobersberg/pbtype-server/gen/go/tkd/pbtype/v1/pbtypev1connect"
+	"github.com/tierklinik-dobersberg/pbtype-server/internal/config"
+	"github.com/tierklinik-dobersberg/pbtype-server/internal/notify"
 	"github.com/tierklinik-dobersberg/pbtype-server/internal/registry"
 	"github.com/tierklinik-dobersberg/pbtype-server/internal/service"
+	"github.com/tierklinik-dobersberg/pbtype-server/internal/webhook"
 )
 
 func main() {
 	var (
 		listenAddress string
 		sources       []string
+		dependencies  []string
 		interval      time.Duration
+		cacheDir      string
+		configFile    string
+		webhookSecret string
+		history       int
+		archive       int
+		refuse        bool
+		conflicts     string
+		notifyURLs    []string
+		notifySecret  string
+		adminToken    string
+		uploadToken   string
+		uploadDir     string
 	)
 
 	root := &cobra.Command{
-		Use:  "pbtype-server [url...]",
-		Args: cobra.MinimumNArgs(1),
+		Use: "pbtype-server [url...]",
 		Run: func(cmd *cobra.Command, args []string) {
 			ctx, cancel := context.WithCancel(context.Background())
 			defer cancel()
 
-			reg := registry.New(interval, sources)
+			var cfg config.Config
+
+			if configFile != "" {
+				loaded, err := config.Load(configFile)
+				if err != nil {
+					slog.Error("failed to load configuration", "error", err)
+					os.Exit(-1)
+				}
+
+				cfg = *loaded
+			}
+
+			for _, url := range append(sources, args...) {
+				cfg.Sources = append(cfg.Sources, config.Source{
+					URL: url,
+				})
+			}
+
+			for _, url := range dependencies {
+				cfg.Sources = append(cfg.Sources, config.Source{
+					URL:        url,
+					ImportOnly: true,
+				})
+			}
+
+			if cfg.RefreshInterval == 0 || cmd.Flags().Changed("refresh-interval") {
+				cfg.RefreshInterval = config.Duration(interval)
+			}
+
+			if cacheDir != "" {
+				cfg.CacheDir = cacheDir
+			}
+
+			if webhookSecret != "" {
+				cfg.WebhookSecret = webhookSecret
+			}
+
+			if adminToken != "" {
+				cfg.AdminToken = adminToken
+			}
+
+			if uploadToken != "" {
+				cfg.UploadToken = uploadToken
+			}
+
+			if uploadDir != "" {
+				cfg.UploadDir = uploadDir
+			}
+
+			if cmd.Flags().Changed("snapshot-history") {
+				cfg.SnapshotHistory = history
+			}
+
+			if cmd.Flags().Changed("snapshot-archive") {
+				cfg.SnapshotArchive = archive
+			}
+
+			if refuse {
+				cfg.RefuseBreakingChanges = true
+			}
+
+			if conflicts != "" {
+				cfg.ConflictPolicy = conflicts
+			}
+
+			for _, url := range notifyURLs {
+				cfg.Notifications = append(cfg.Notifications, config.Notification{
+					URL:    url,
+					Secret: notifySecret,
+				})
+			}
+
+			if err := cfg.Validate(); err != nil {
+				slog.Error("invalid configuration", "error", err)
+				os.Exit(-1)
+			}
+
+			reg := registry.New(cfg)
+
+			// start the notifier before polling so no changelog entry
+			// is missed.
+			if len(cfg.Notifications) > 0 {
+				notify.New(reg, cfg.Notifications).Start(ctx)
+			}
 
 			if err := reg.StartPolling(ctx); err != nil {
 				slog.Error("failed to start polling sources", "error", err)
@@ -45,6 +144,37 @@ func main() {
 			path, handler := typeserverv1connect.NewTypeResolverServiceHandler(srv)
 			serveMux.Handle(path, handler)
 
+			path, handler = pbtypev1connect.NewDescriptorServiceHandler(service.NewDescriptorServer(reg))
+			serveMux.Handle(path, handler)
+
+			path, handler = pbtypev1connect.NewAdminServiceHandler(service.NewAdminServer(reg, cfg.AdminToken, cfg.UploadToken))
+			serveMux.Handle(path, handler)
+
+			reflection := service.NewReflectionServer(reg)
+
+			path, handler = reflection.Handler()
+			serveMux.Handle(path, handler)
+
+			path, handler = reflection.HandlerV1Alpha()
+			serveMux.Handle(path, handler)
+
+			health := service.NewHealthServer(reg)
+
+			path, handler = health.Handler()
+			serveMux.Handle(path, handler)
+
+			changelog := service.NewChangelogFeed(reg)
+
+			serveMux.HandleFunc("GET /changelog.atom", changelog.Atom)
+			serveMux.HandleFunc("GET /changelog.json", changelog.JSON)
+
+			serveMux.HandleFunc("/healthz", health.Liveness)
+			serveMux.HandleFunc("/readyz", health.Readiness)
+
+			if cfg.WebhookSecret != "" {
+				serveMux.Handle("/webhook", webhook.New(reg, cfg.WebhookSecret))
+			}
+
 			// Register at service catalog
 			catalog, err := consuldiscover.NewFromEnv()
 			if err != nil {
@@ -75,8 +205,21 @@ func main() {
 	flags := root.Flags()
 	{
 		flags.StringVar(&listenAddress, "listen", ":8081", "The address to listen")
+		flags.StringVar(&configFile, "config", "", "Path to a YAML or JSON configuration file for proto sources")
 		flags.StringSliceVar(&sources, "source", nil, "A list of proto sources")
-		flags.DurationVar(&interval, "refresh-interval", time.Minute*10, "The refresh interval for proto sources")
+		flags.StringSliceVar(&dependencies, "dependency", nil, "A list of proto sources that are only used to satisfy imports")
+		flags.DurationVar(&interval, "refresh-interval", config.DefaultRefreshInterval, "The default refresh interval for proto sources")
+		flags.StringVar(&cacheDir, "cache-dir", "", "A directory to persist compiled proto sources for faster startup")
+		flags.IntVar(&history, "snapshot-history", config.DefaultSnapshotHistory, "The number of snapshots kept in memory")
+		flags.IntVar(&archive, "snapshot-archive", 0, "The number of snapshots kept in the cache directory")
+		flags.BoolVar(&refuse, "refuse-breaking-changes", false, "Do not activate new versions of sources that contain wire-breaking changes")
+		flags.StringVar(&conflicts, "conflict-policy", "", "How files and symbols provided by more than one source are handled: fail, first or priority. Defaults to first")
+		flags.StringSliceVar(&notifyURLs, "notify", nil, "A list of URLs that receive a POST request for each changelog entry")
+		flags.StringVar(&notifySecret, "notify-secret", os.Getenv("NOTIFY_SECRET"), "The secret used to sign notifications sent to --notify URLs")
+		flags.StringVar(&adminToken, "admin-token", os.Getenv("ADMIN_TOKEN"), "The bearer token required to call the admin service. The admin service, except for uploads, is disabled if empty")
+		flags.StringVar(&uploadToken, "upload-token", os.Getenv("UPLOAD_TOKEN"), "The bearer token required to upload sources. Uploads are disabled if empty")
+		flags.StringVar(&uploadDir, "upload-dir", "", "The directory uploaded sources are stored in. Defaults to the uploads directory in --cache-dir")
+		flags.StringVar(&webhookSecret, "webhook-secret", os.Getenv("WEBHOOK_SECRET"), "The shared secret to verify push webhooks. Webhooks are disabled if empty")
 	}
 
 	if err := root.Execute(); err != nil {
@@ -0,0 +1,168 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/bufbuild/connect-go"
+	"github.com/bufbuild/protocompile"
+	"github.com/spf13/cobra"
+	"github.com/tierklinik-dobersberg/apis/pkg/cli"
+	pbtypev1 "github.com/tierklinik-dobersberg/pbtype-server/gen/go/tkd/pbtype/v1"
+	"github.com/tierklinik-dobersberg/pbtype-server/gen/go/tkd/pbtype/v1/pbtypev1connect"
+	"github.com/tierklinik-dobersberg/pbtype-server/internal/registry"
+	"github.com/tierklinik-dobersberg/pbtype-server/pkg/resolver"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+func getDiffCommand(server *string) *cobra.Command {
+	return &cobra.Command{
+		Use:   "diff FROM TO",
+		Short: "Compare two snapshots or a snapshot and a local directory of .proto files",
+		Long: `Compare two snapshots or a snapshot and a local directory of .proto files.
+
+Snapshots are identified by their ID or a unique prefix of their hash. If TO
+is a directory, all .proto files below it are compiled and compared against
+FROM. Imports that are not part of the directory are resolved using the
+type server.`,
+		Args: cobra.ExactArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			req := &pbtypev1.DiffRequest{
+				FromSnapshot: args[0],
+			}
+
+			if stat, err := os.Stat(args[1]); err == nil && stat.IsDir() {
+				set, err := compileDirectory(cmd.Context(), *server, args[1])
+				if err != nil {
+					log.Fatal(err.Error())
+				}
+
+				blob, err := proto.Marshal(set)
+				if err != nil {
+					log.Fatal(err.Error())
+				}
+
+				req.To = &pbtypev1.DiffRequest_FileDescriptorSet{
+					FileDescriptorSet: blob,
+				}
+			} else {
+				req.To = &pbtypev1.DiffRequest_ToSnapshot{
+					ToSnapshot: args[1],
+				}
+			}
+
+			client := pbtypev1connect.NewDescriptorServiceClient(cli.NewInsecureHttp2Client(), *server)
+
+			res, err := client.Diff(cmd.Context(), connect.NewRequest(req))
+			if err != nil {
+				log.Fatal(err.Error())
+			}
+
+			printDiff(res.Msg)
+		},
+	}
+}
+
+// compileDirectory compiles all .proto files below dir and returns them as
+// a FileDescriptorSet. Imports that cannot be found in dir are fetched from
+// the type server.
+func compileDirectory(ctx context.Context, server string, dir string) (*descriptorpb.FileDescriptorSet, error) {
+	var files []string
+
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if d.IsDir() || filepath.Ext(path) != ".proto" {
+			return nil
+		}
+
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+
+		files = append(files, filepath.ToSlash(rel))
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no .proto files found in %s", dir)
+	}
+
+	remote := resolver.New(server)
+
+	compiler := protocompile.Compiler{
+		Resolver: protocompile.WithStandardImports(protocompile.CompositeResolver{
+			&protocompile.SourceResolver{
+				ImportPaths: []string{dir},
+			},
+			protocompile.ResolverFunc(func(path string) (protocompile.SearchResult, error) {
+				fd, err := remote.FindFileByPath(path)
+				if err != nil {
+					return protocompile.SearchResult{}, err
+				}
+
+				return protocompile.SearchResult{Desc: fd}, nil
+			}),
+		}),
+	}
+
+	compiled, err := compiler.Compile(ctx, files...)
+	if err != nil {
+		return nil, err
+	}
+
+	set := &descriptorpb.FileDescriptorSet{}
+	for _, file := range compiled {
+		set.File = append(set.File, protodesc.ToFileDescriptorProto(file))
+	}
+
+	return set, nil
+}
+
+func printDiff(res *pbtypev1.DiffResponse) {
+	if res.ToSnapshotHash != "" {
+		fmt.Printf("comparing snapshot %d (%s) with snapshot %d (%s)\n\n", res.FromSnapshotId, res.FromSnapshotHash, res.ToSnapshotId, res.ToSnapshotHash)
+	} else {
+		fmt.Printf("comparing snapshot %d (%s) with local files\n\n", res.FromSnapshotId, res.FromSnapshotHash)
+	}
+
+	if len(res.Entries) == 0 {
+		fmt.Println("no differences")
+
+		return
+	}
+
+	for _, entry := range res.Entries {
+		var prefix string
+		switch entry.Change {
+		case pbtypev1.ElementChangeType_ELEMENT_CHANGE_TYPE_ADDED:
+			prefix = "+"
+		case pbtypev1.ElementChangeType_ELEMENT_CHANGE_TYPE_REMOVED:
+			prefix = "-"
+		default:
+			prefix = "~"
+		}
+
+		element := strings.ToLower(strings.ReplaceAll(strings.TrimPrefix(entry.Element.String(), "ELEMENT_TYPE_"), "_", " "))
+
+		fmt.Printf("%s %s %s (%s)\n", prefix, element, entry.Name, entry.FileName)
+
+		for _, prop := range entry.Properties {
+			fmt.Printf("    %s: %s -> %s\n", prop.Name, registry.FormatValue(prop.OldValue), registry.FormatValue(prop.NewValue))
+		}
+	}
+}
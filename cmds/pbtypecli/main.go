@@ -104,7 +104,11 @@ func main() {
 		},
 	}
 
-	cmd.Flags().StringVarP(&server, "server", "s", "http://localhost:8081", "The address of the type server")
+	cmd.PersistentFlags().StringVarP(&server, "server", "s", "http://localhost:8081", "The address of the type server")
+
+	cmd.AddCommand(
+		getDiffCommand(&server),
+	)
 
 	if err := cmd.Execute(); err != nil {
 		log.Fatal(err.Error())
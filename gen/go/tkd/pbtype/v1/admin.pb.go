@@ -0,0 +1,1537 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.35.1
+// 	protoc        (unknown)
+// source: tkd/pbtype/v1/admin.proto
+
+package pbtypev1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type BreakingChangeKind int32
+
+const (
+	BreakingChangeKind_BREAKING_CHANGE_KIND_UNSPECIFIED          BreakingChangeKind = 0
+	BreakingChangeKind_BREAKING_CHANGE_KIND_FIELD_REMOVED        BreakingChangeKind = 1
+	BreakingChangeKind_BREAKING_CHANGE_KIND_FIELD_NUMBER_CHANGED BreakingChangeKind = 2
+	BreakingChangeKind_BREAKING_CHANGE_KIND_FIELD_TYPE_CHANGED   BreakingChangeKind = 3
+	BreakingChangeKind_BREAKING_CHANGE_KIND_FIELD_RENAMED        BreakingChangeKind = 4
+	BreakingChangeKind_BREAKING_CHANGE_KIND_MESSAGE_REMOVED      BreakingChangeKind = 5
+	BreakingChangeKind_BREAKING_CHANGE_KIND_MESSAGE_RENAMED      BreakingChangeKind = 6
+	BreakingChangeKind_BREAKING_CHANGE_KIND_ENUM_VALUE_REMOVED   BreakingChangeKind = 7
+)
+
+// Enum value maps for BreakingChangeKind.
+var (
+	BreakingChangeKind_name = map[int32]string{
+		0: "BREAKING_CHANGE_KIND_UNSPECIFIED",
+		1: "BREAKING_CHANGE_KIND_FIELD_REMOVED",
+		2: "BREAKING_CHANGE_KIND_FIELD_NUMBER_CHANGED",
+		3: "BREAKING_CHANGE_KIND_FIELD_TYPE_CHANGED",
+		4: "BREAKING_CHANGE_KIND_FIELD_RENAMED",
+		5: "BREAKING_CHANGE_KIND_MESSAGE_REMOVED",
+		6: "BREAKING_CHANGE_KIND_MESSAGE_RENAMED",
+		7: "BREAKING_CHANGE_KIND_ENUM_VALUE_REMOVED",
+	}
+	BreakingChangeKind_value = map[string]int32{
+		"BREAKING_CHANGE_KIND_UNSPECIFIED":          0,
+		"BREAKING_CHANGE_KIND_FIELD_REMOVED":        1,
+		"BREAKING_CHANGE_KIND_FIELD_NUMBER_CHANGED": 2,
+		"BREAKING_CHANGE_KIND_FIELD_TYPE_CHANGED":   3,
+		"BREAKING_CHANGE_KIND_FIELD_RENAMED":        4,
+		"BREAKING_CHANGE_KIND_MESSAGE_REMOVED":      5,
+		"BREAKING_CHANGE_KIND_MESSAGE_RENAMED":      6,
+		"BREAKING_CHANGE_KIND_ENUM_VALUE_REMOVED":   7,
+	}
+)
+
+func (x BreakingChangeKind) Enum() *BreakingChangeKind {
+	p := new(BreakingChangeKind)
+	*p = x
+	return p
+}
+
+func (x BreakingChangeKind) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (BreakingChangeKind) Descriptor() protoreflect.EnumDescriptor {
+	return file_tkd_pbtype_v1_admin_proto_enumTypes[0].Descriptor()
+}
+
+func (BreakingChangeKind) Type() protoreflect.EnumType {
+	return &file_tkd_pbtype_v1_admin_proto_enumTypes[0]
+}
+
+func (x BreakingChangeKind) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use BreakingChangeKind.Descriptor instead.
+func (BreakingChangeKind) EnumDescriptor() ([]byte, []int) {
+	return file_tkd_pbtype_v1_admin_proto_rawDescGZIP(), []int{0}
+}
+
+// ConflictKind describes what two sources conflict on.
+type ConflictKind int32
+
+const (
+	ConflictKind_CONFLICT_KIND_UNSPECIFIED ConflictKind = 0
+	// Two sources provide a file with the same path.
+	ConflictKind_CONFLICT_KIND_FILE ConflictKind = 1
+	// Two sources define the same fully qualified name in different files.
+	ConflictKind_CONFLICT_KIND_SYMBOL ConflictKind = 2
+)
+
+// Enum value maps for ConflictKind.
+var (
+	ConflictKind_name = map[int32]string{
+		0: "CONFLICT_KIND_UNSPECIFIED",
+		1: "CONFLICT_KIND_FILE",
+		2: "CONFLICT_KIND_SYMBOL",
+	}
+	ConflictKind_value = map[string]int32{
+		"CONFLICT_KIND_UNSPECIFIED": 0,
+		"CONFLICT_KIND_FILE":        1,
+		"CONFLICT_KIND_SYMBOL":      2,
+	}
+)
+
+func (x ConflictKind) Enum() *ConflictKind {
+	p := new(ConflictKind)
+	*p = x
+	return p
+}
+
+func (x ConflictKind) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ConflictKind) Descriptor() protoreflect.EnumDescriptor {
+	return file_tkd_pbtype_v1_admin_proto_enumTypes[1].Descriptor()
+}
+
+func (ConflictKind) Type() protoreflect.EnumType {
+	return &file_tkd_pbtype_v1_admin_proto_enumTypes[1]
+}
+
+func (x ConflictKind) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ConflictKind.Descriptor instead.
+func (ConflictKind) EnumDescriptor() ([]byte, []int) {
+	return file_tkd_pbtype_v1_admin_proto_rawDescGZIP(), []int{1}
+}
+
+// SourceStatus describes a configured protobuf source and the outcome of
+// its last refresh.
+type SourceStatus struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The unique name of the source.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// The URL the source is downloaded from, without credentials.
+	Url string `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`
+	// Whether or not the source is only used to satisfy imports.
+	ImportOnly bool `protobuf:"varint,3,opt,name=import_only,json=importOnly,proto3" json:"import_only,omitempty"`
+	// The time the source has been fetched the last time.
+	LastFetch *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=last_fetch,json=lastFetch,proto3" json:"last_fetch,omitempty"`
+	// The time the source has been compiled successfully the last time.
+	LastSuccess *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=last_success,json=lastSuccess,proto3" json:"last_success,omitempty"`
+	// The error of the last refresh, if any.
+	LastError string `protobuf:"bytes,6,opt,name=last_error,json=lastError,proto3" json:"last_error,omitempty"`
+	// The number of files in the currently served version of the source.
+	FileCount int32 `protobuf:"varint,7,opt,name=file_count,json=fileCount,proto3" json:"file_count,omitempty"`
+	// A hash over all file descriptors of the currently served version of
+	// the source.
+	ContentHash string `protobuf:"bytes,8,opt,name=content_hash,json=contentHash,proto3" json:"content_hash,omitempty"`
+	// The breaking changes detected the last time a new version of the
+	// source has been compiled.
+	BreakingChanges []*BreakingChange `protobuf:"bytes,9,rep,name=breaking_changes,json=breakingChanges,proto3" json:"breaking_changes,omitempty"`
+	// The time the breaking changes have been detected.
+	BreakingChangesDetected *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=breaking_changes_detected,json=breakingChangesDetected,proto3" json:"breaking_changes_detected,omitempty"`
+	// Whether or not the source has been uploaded using UploadSource
+	// instead of being configured.
+	Uploaded bool `protobuf:"varint,11,opt,name=uploaded,proto3" json:"uploaded,omitempty"`
+	// The git commit of the currently served version of the source. Only
+	// set for git sources.
+	Revision string `protobuf:"bytes,12,opt,name=revision,proto3" json:"revision,omitempty"`
+}
+
+func (x *SourceStatus) Reset() {
+	*x = SourceStatus{}
+	mi := &file_tkd_pbtype_v1_admin_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SourceStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SourceStatus) ProtoMessage() {}
+
+func (x *SourceStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_tkd_pbtype_v1_admin_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SourceStatus.ProtoReflect.Descriptor instead.
+func (*SourceStatus) Descriptor() ([]byte, []int) {
+	return file_tkd_pbtype_v1_admin_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *SourceStatus) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *SourceStatus) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *SourceStatus) GetImportOnly() bool {
+	if x != nil {
+		return x.ImportOnly
+	}
+	return false
+}
+
+func (x *SourceStatus) GetLastFetch() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastFetch
+	}
+	return nil
+}
+
+func (x *SourceStatus) GetLastSuccess() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastSuccess
+	}
+	return nil
+}
+
+func (x *SourceStatus) GetLastError() string {
+	if x != nil {
+		return x.LastError
+	}
+	return ""
+}
+
+func (x *SourceStatus) GetFileCount() int32 {
+	if x != nil {
+		return x.FileCount
+	}
+	return 0
+}
+
+func (x *SourceStatus) GetContentHash() string {
+	if x != nil {
+		return x.ContentHash
+	}
+	return ""
+}
+
+func (x *SourceStatus) GetBreakingChanges() []*BreakingChange {
+	if x != nil {
+		return x.BreakingChanges
+	}
+	return nil
+}
+
+func (x *SourceStatus) GetBreakingChangesDetected() *timestamppb.Timestamp {
+	if x != nil {
+		return x.BreakingChangesDetected
+	}
+	return nil
+}
+
+func (x *SourceStatus) GetUploaded() bool {
+	if x != nil {
+		return x.Uploaded
+	}
+	return false
+}
+
+func (x *SourceStatus) GetRevision() string {
+	if x != nil {
+		return x.Revision
+	}
+	return ""
+}
+
+// BreakingChange describes an incompatible change between two versions of
+// a source.
+type BreakingChange struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The kind of change.
+	Kind BreakingChangeKind `protobuf:"varint,1,opt,name=kind,proto3,enum=tkd.pbtype.v1.BreakingChangeKind" json:"kind,omitempty"`
+	// The fully-qualified name of the removed or changed element.
+	Element string `protobuf:"bytes,2,opt,name=element,proto3" json:"element,omitempty"`
+	// The file that declared the element.
+	FileName string `protobuf:"bytes,3,opt,name=file_name,json=fileName,proto3" json:"file_name,omitempty"`
+	// Whether or not data encoded using the previous version can no longer
+	// be decoded correctly.
+	WireBreaking bool `protobuf:"varint,4,opt,name=wire_breaking,json=wireBreaking,proto3" json:"wire_breaking,omitempty"`
+	// A human readable description of the change.
+	Description string `protobuf:"bytes,5,opt,name=description,proto3" json:"description,omitempty"`
+}
+
+func (x *BreakingChange) Reset() {
+	*x = BreakingChange{}
+	mi := &file_tkd_pbtype_v1_admin_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BreakingChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BreakingChange) ProtoMessage() {}
+
+func (x *BreakingChange) ProtoReflect() protoreflect.Message {
+	mi := &file_tkd_pbtype_v1_admin_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BreakingChange.ProtoReflect.Descriptor instead.
+func (*BreakingChange) Descriptor() ([]byte, []int) {
+	return file_tkd_pbtype_v1_admin_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *BreakingChange) GetKind() BreakingChangeKind {
+	if x != nil {
+		return x.Kind
+	}
+	return BreakingChangeKind_BREAKING_CHANGE_KIND_UNSPECIFIED
+}
+
+func (x *BreakingChange) GetElement() string {
+	if x != nil {
+		return x.Element
+	}
+	return ""
+}
+
+func (x *BreakingChange) GetFileName() string {
+	if x != nil {
+		return x.FileName
+	}
+	return ""
+}
+
+func (x *BreakingChange) GetWireBreaking() bool {
+	if x != nil {
+		return x.WireBreaking
+	}
+	return false
+}
+
+func (x *BreakingChange) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+type ListSourcesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ListSourcesRequest) Reset() {
+	*x = ListSourcesRequest{}
+	mi := &file_tkd_pbtype_v1_admin_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSourcesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSourcesRequest) ProtoMessage() {}
+
+func (x *ListSourcesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tkd_pbtype_v1_admin_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSourcesRequest.ProtoReflect.Descriptor instead.
+func (*ListSourcesRequest) Descriptor() ([]byte, []int) {
+	return file_tkd_pbtype_v1_admin_proto_rawDescGZIP(), []int{2}
+}
+
+type ListSourcesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Sources []*SourceStatus `protobuf:"bytes,1,rep,name=sources,proto3" json:"sources,omitempty"`
+}
+
+func (x *ListSourcesResponse) Reset() {
+	*x = ListSourcesResponse{}
+	mi := &file_tkd_pbtype_v1_admin_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSourcesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSourcesResponse) ProtoMessage() {}
+
+func (x *ListSourcesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tkd_pbtype_v1_admin_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSourcesResponse.ProtoReflect.Descriptor instead.
+func (*ListSourcesResponse) Descriptor() ([]byte, []int) {
+	return file_tkd_pbtype_v1_admin_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ListSourcesResponse) GetSources() []*SourceStatus {
+	if x != nil {
+		return x.Sources
+	}
+	return nil
+}
+
+type RefreshSourcesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The names of the sources to refresh. If empty, all sources are
+	// refreshed.
+	Names []string `protobuf:"bytes,1,rep,name=names,proto3" json:"names,omitempty"`
+}
+
+func (x *RefreshSourcesRequest) Reset() {
+	*x = RefreshSourcesRequest{}
+	mi := &file_tkd_pbtype_v1_admin_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RefreshSourcesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RefreshSourcesRequest) ProtoMessage() {}
+
+func (x *RefreshSourcesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tkd_pbtype_v1_admin_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RefreshSourcesRequest.ProtoReflect.Descriptor instead.
+func (*RefreshSourcesRequest) Descriptor() ([]byte, []int) {
+	return file_tkd_pbtype_v1_admin_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *RefreshSourcesRequest) GetNames() []string {
+	if x != nil {
+		return x.Names
+	}
+	return nil
+}
+
+type RefreshSourcesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The status of all refreshed sources.
+	Sources []*SourceStatus `protobuf:"bytes,1,rep,name=sources,proto3" json:"sources,omitempty"`
+}
+
+func (x *RefreshSourcesResponse) Reset() {
+	*x = RefreshSourcesResponse{}
+	mi := &file_tkd_pbtype_v1_admin_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RefreshSourcesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RefreshSourcesResponse) ProtoMessage() {}
+
+func (x *RefreshSourcesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tkd_pbtype_v1_admin_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RefreshSourcesResponse.ProtoReflect.Descriptor instead.
+func (*RefreshSourcesResponse) Descriptor() ([]byte, []int) {
+	return file_tkd_pbtype_v1_admin_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *RefreshSourcesResponse) GetSources() []*SourceStatus {
+	if x != nil {
+		return x.Sources
+	}
+	return nil
+}
+
+// SourceRevision identifies the version of a source that went into a
+// snapshot.
+type SourceRevision struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The name of the source.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// The URL of the source, without credentials.
+	Url string `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`
+	// A hash over all file descriptors of the source.
+	ContentHash string `protobuf:"bytes,3,opt,name=content_hash,json=contentHash,proto3" json:"content_hash,omitempty"`
+	// The git commit of the source. Only set for git sources.
+	Revision string `protobuf:"bytes,4,opt,name=revision,proto3" json:"revision,omitempty"`
+}
+
+func (x *SourceRevision) Reset() {
+	*x = SourceRevision{}
+	mi := &file_tkd_pbtype_v1_admin_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SourceRevision) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SourceRevision) ProtoMessage() {}
+
+func (x *SourceRevision) ProtoReflect() protoreflect.Message {
+	mi := &file_tkd_pbtype_v1_admin_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SourceRevision.ProtoReflect.Descriptor instead.
+func (*SourceRevision) Descriptor() ([]byte, []int) {
+	return file_tkd_pbtype_v1_admin_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *SourceRevision) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *SourceRevision) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *SourceRevision) GetContentHash() string {
+	if x != nil {
+		return x.ContentHash
+	}
+	return ""
+}
+
+func (x *SourceRevision) GetRevision() string {
+	if x != nil {
+		return x.Revision
+	}
+	return ""
+}
+
+// SnapshotInfo describes a snapshot of the served files.
+type SnapshotInfo struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The sequence number of the snapshot.
+	Id uint64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// A hash over the names and hashes of all files in the snapshot.
+	Hash string `protobuf:"bytes,2,opt,name=hash,proto3" json:"hash,omitempty"`
+	// The time the snapshot has been activated.
+	Created *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=created,proto3" json:"created,omitempty"`
+	// The sources that went into the snapshot.
+	Sources []*SourceRevision `protobuf:"bytes,4,rep,name=sources,proto3" json:"sources,omitempty"`
+	// Whether or not the snapshot is kept in memory. Other snapshots are
+	// loaded from the archive on demand.
+	InMemory bool `protobuf:"varint,5,opt,name=in_memory,json=inMemory,proto3" json:"in_memory,omitempty"`
+}
+
+func (x *SnapshotInfo) Reset() {
+	*x = SnapshotInfo{}
+	mi := &file_tkd_pbtype_v1_admin_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SnapshotInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SnapshotInfo) ProtoMessage() {}
+
+func (x *SnapshotInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_tkd_pbtype_v1_admin_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SnapshotInfo.ProtoReflect.Descriptor instead.
+func (*SnapshotInfo) Descriptor() ([]byte, []int) {
+	return file_tkd_pbtype_v1_admin_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *SnapshotInfo) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *SnapshotInfo) GetHash() string {
+	if x != nil {
+		return x.Hash
+	}
+	return ""
+}
+
+func (x *SnapshotInfo) GetCreated() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Created
+	}
+	return nil
+}
+
+func (x *SnapshotInfo) GetSources() []*SourceRevision {
+	if x != nil {
+		return x.Sources
+	}
+	return nil
+}
+
+func (x *SnapshotInfo) GetInMemory() bool {
+	if x != nil {
+		return x.InMemory
+	}
+	return false
+}
+
+type ListSnapshotsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ListSnapshotsRequest) Reset() {
+	*x = ListSnapshotsRequest{}
+	mi := &file_tkd_pbtype_v1_admin_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSnapshotsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSnapshotsRequest) ProtoMessage() {}
+
+func (x *ListSnapshotsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tkd_pbtype_v1_admin_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSnapshotsRequest.ProtoReflect.Descriptor instead.
+func (*ListSnapshotsRequest) Descriptor() ([]byte, []int) {
+	return file_tkd_pbtype_v1_admin_proto_rawDescGZIP(), []int{8}
+}
+
+type ListSnapshotsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// All retained snapshots, oldest first.
+	Snapshots []*SnapshotInfo `protobuf:"bytes,1,rep,name=snapshots,proto3" json:"snapshots,omitempty"`
+}
+
+func (x *ListSnapshotsResponse) Reset() {
+	*x = ListSnapshotsResponse{}
+	mi := &file_tkd_pbtype_v1_admin_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSnapshotsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSnapshotsResponse) ProtoMessage() {}
+
+func (x *ListSnapshotsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tkd_pbtype_v1_admin_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSnapshotsResponse.ProtoReflect.Descriptor instead.
+func (*ListSnapshotsResponse) Descriptor() ([]byte, []int) {
+	return file_tkd_pbtype_v1_admin_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ListSnapshotsResponse) GetSnapshots() []*SnapshotInfo {
+	if x != nil {
+		return x.Snapshots
+	}
+	return nil
+}
+
+// ProtoFile is a single .proto source file.
+type ProtoFile struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The import path of the file, like "foo/v1/foo.proto".
+	Path string `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	// The content of the file.
+	Content string `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`
+}
+
+func (x *ProtoFile) Reset() {
+	*x = ProtoFile{}
+	mi := &file_tkd_pbtype_v1_admin_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProtoFile) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProtoFile) ProtoMessage() {}
+
+func (x *ProtoFile) ProtoReflect() protoreflect.Message {
+	mi := &file_tkd_pbtype_v1_admin_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProtoFile.ProtoReflect.Descriptor instead.
+func (*ProtoFile) Descriptor() ([]byte, []int) {
+	return file_tkd_pbtype_v1_admin_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ProtoFile) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *ProtoFile) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+// ProtoBundle is a set of .proto source files.
+type ProtoBundle struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Files []*ProtoFile `protobuf:"bytes,1,rep,name=files,proto3" json:"files,omitempty"`
+}
+
+func (x *ProtoBundle) Reset() {
+	*x = ProtoBundle{}
+	mi := &file_tkd_pbtype_v1_admin_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProtoBundle) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProtoBundle) ProtoMessage() {}
+
+func (x *ProtoBundle) ProtoReflect() protoreflect.Message {
+	mi := &file_tkd_pbtype_v1_admin_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProtoBundle.ProtoReflect.Descriptor instead.
+func (*ProtoBundle) Descriptor() ([]byte, []int) {
+	return file_tkd_pbtype_v1_admin_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ProtoBundle) GetFiles() []*ProtoFile {
+	if x != nil {
+		return x.Files
+	}
+	return nil
+}
+
+type UploadSourceRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The name of the source. Uploading a source with the same name again
+	// replaces it. The name must not be used by a configured source.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Whether or not the source is only used to satisfy imports.
+	ImportOnly bool `protobuf:"varint,2,opt,name=import_only,json=importOnly,proto3" json:"import_only,omitempty"`
+	// Types that are assignable to Content:
+	//	*UploadSourceRequest_FileDescriptorSet
+	//	*UploadSourceRequest_ProtoBundle
+	Content isUploadSourceRequest_Content `protobuf_oneof:"content"`
+}
+
+func (x *UploadSourceRequest) Reset() {
+	*x = UploadSourceRequest{}
+	mi := &file_tkd_pbtype_v1_admin_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadSourceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadSourceRequest) ProtoMessage() {}
+
+func (x *UploadSourceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tkd_pbtype_v1_admin_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadSourceRequest.ProtoReflect.Descriptor instead.
+func (*UploadSourceRequest) Descriptor() ([]byte, []int) {
+	return file_tkd_pbtype_v1_admin_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *UploadSourceRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *UploadSourceRequest) GetImportOnly() bool {
+	if x != nil {
+		return x.ImportOnly
+	}
+	return false
+}
+
+func (m *UploadSourceRequest) GetContent() isUploadSourceRequest_Content {
+	if m != nil {
+		return m.Content
+	}
+	return nil
+}
+
+func (x *UploadSourceRequest) GetFileDescriptorSet() []byte {
+	if x, ok := x.GetContent().(*UploadSourceRequest_FileDescriptorSet); ok {
+		return x.FileDescriptorSet
+	}
+	return nil
+}
+
+func (x *UploadSourceRequest) GetProtoBundle() *ProtoBundle {
+	if x, ok := x.GetContent().(*UploadSourceRequest_ProtoBundle); ok {
+		return x.ProtoBundle
+	}
+	return nil
+}
+
+type isUploadSourceRequest_Content interface {
+	isUploadSourceRequest_Content()
+}
+
+type UploadSourceRequest_FileDescriptorSet struct {
+	// A serialized google.protobuf.FileDescriptorSet.
+	FileDescriptorSet []byte `protobuf:"bytes,3,opt,name=file_descriptor_set,json=fileDescriptorSet,proto3,oneof"`
+}
+
+type UploadSourceRequest_ProtoBundle struct {
+	// The .proto source files of the source.
+	ProtoBundle *ProtoBundle `protobuf:"bytes,4,opt,name=proto_bundle,json=protoBundle,proto3,oneof"`
+}
+
+func (*UploadSourceRequest_FileDescriptorSet) isUploadSourceRequest_Content() {}
+
+func (*UploadSourceRequest_ProtoBundle) isUploadSourceRequest_Content() {}
+
+type UploadSourceResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The status of the uploaded source.
+	Source *SourceStatus `protobuf:"bytes,1,opt,name=source,proto3" json:"source,omitempty"`
+}
+
+func (x *UploadSourceResponse) Reset() {
+	*x = UploadSourceResponse{}
+	mi := &file_tkd_pbtype_v1_admin_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadSourceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadSourceResponse) ProtoMessage() {}
+
+func (x *UploadSourceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tkd_pbtype_v1_admin_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadSourceResponse.ProtoReflect.Descriptor instead.
+func (*UploadSourceResponse) Descriptor() ([]byte, []int) {
+	return file_tkd_pbtype_v1_admin_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *UploadSourceResponse) GetSource() *SourceStatus {
+	if x != nil {
+		return x.Source
+	}
+	return nil
+}
+
+// Conflict describes a file path or symbol that is provided by more than
+// one source.
+type Conflict struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// What the sources conflict on.
+	Kind ConflictKind `protobuf:"varint,1,opt,name=kind,proto3,enum=tkd.pbtype.v1.ConflictKind" json:"kind,omitempty"`
+	// The path of the file or the fully qualified name of the symbol.
+	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// The source and file that are served.
+	Source string `protobuf:"bytes,3,opt,name=source,proto3" json:"source,omitempty"`
+	File   string `protobuf:"bytes,4,opt,name=file,proto3" json:"file,omitempty"`
+	// The source and file that are not served.
+	OtherSource string `protobuf:"bytes,5,opt,name=other_source,json=otherSource,proto3" json:"other_source,omitempty"`
+	OtherFile   string `protobuf:"bytes,6,opt,name=other_file,json=otherFile,proto3" json:"other_file,omitempty"`
+	// Whether or not a new version of other_source has been refused
+	// because of the conflict. Otherwise, other_file has been dropped from
+	// the served files.
+	Refused bool `protobuf:"varint,7,opt,name=refused,proto3" json:"refused,omitempty"`
+}
+
+func (x *Conflict) Reset() {
+	*x = Conflict{}
+	mi := &file_tkd_pbtype_v1_admin_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Conflict) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Conflict) ProtoMessage() {}
+
+func (x *Conflict) ProtoReflect() protoreflect.Message {
+	mi := &file_tkd_pbtype_v1_admin_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Conflict.ProtoReflect.Descriptor instead.
+func (*Conflict) Descriptor() ([]byte, []int) {
+	return file_tkd_pbtype_v1_admin_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *Conflict) GetKind() ConflictKind {
+	if x != nil {
+		return x.Kind
+	}
+	return ConflictKind_CONFLICT_KIND_UNSPECIFIED
+}
+
+func (x *Conflict) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Conflict) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *Conflict) GetFile() string {
+	if x != nil {
+		return x.File
+	}
+	return ""
+}
+
+func (x *Conflict) GetOtherSource() string {
+	if x != nil {
+		return x.OtherSource
+	}
+	return ""
+}
+
+func (x *Conflict) GetOtherFile() string {
+	if x != nil {
+		return x.OtherFile
+	}
+	return ""
+}
+
+func (x *Conflict) GetRefused() bool {
+	if x != nil {
+		return x.Refused
+	}
+	return false
+}
+
+type ListConflictsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ListConflictsRequest) Reset() {
+	*x = ListConflictsRequest{}
+	mi := &file_tkd_pbtype_v1_admin_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListConflictsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListConflictsRequest) ProtoMessage() {}
+
+func (x *ListConflictsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tkd_pbtype_v1_admin_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListConflictsRequest.ProtoReflect.Descriptor instead.
+func (*ListConflictsRequest) Descriptor() ([]byte, []int) {
+	return file_tkd_pbtype_v1_admin_proto_rawDescGZIP(), []int{15}
+}
+
+type ListConflictsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The configured conflict policy, one of "fail", "first" or
+	// "priority".
+	Policy string `protobuf:"bytes,1,opt,name=policy,proto3" json:"policy,omitempty"`
+	// All conflicts detected when the current snapshot has been activated,
+	// followed by the conflicts that caused new versions of sources to be
+	// refused.
+	Conflicts []*Conflict `protobuf:"bytes,2,rep,name=conflicts,proto3" json:"conflicts,omitempty"`
+}
+
+func (x *ListConflictsResponse) Reset() {
+	*x = ListConflictsResponse{}
+	mi := &file_tkd_pbtype_v1_admin_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListConflictsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListConflictsResponse) ProtoMessage() {}
+
+func (x *ListConflictsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tkd_pbtype_v1_admin_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListConflictsResponse.ProtoReflect.Descriptor instead.
+func (*ListConflictsResponse) Descriptor() ([]byte, []int) {
+	return file_tkd_pbtype_v1_admin_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ListConflictsResponse) GetPolicy() string {
+	if x != nil {
+		return x.Policy
+	}
+	return ""
+}
+
+func (x *ListConflictsResponse) GetConflicts() []*Conflict {
+	if x != nil {
+		return x.Conflicts
+	}
+	return nil
+}
+
+var File_tkd_pbtype_v1_admin_proto protoreflect.FileDescriptor
+
+var file_tkd_pbtype_v1_admin_proto_rawDesc = []byte{
+	0x0a, 0x19, 0x74, 0x6b, 0x64, 0x2f, 0x70, 0x62, 0x74, 0x79, 0x70, 0x65, 0x2f, 0x76, 0x31, 0x2f,
+	0x61, 0x64, 0x6d, 0x69, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0d, 0x74, 0x6b, 0x64,
+	0x2e, 0x70, 0x62, 0x74, 0x79, 0x70, 0x65, 0x2e, 0x76, 0x31, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65,
+	0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x8a, 0x04, 0x0a, 0x0c,
+	0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x12, 0x0a, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x12, 0x10, 0x0a, 0x03, 0x75, 0x72, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75,
+	0x72, 0x6c, 0x12, 0x1f, 0x0a, 0x0b, 0x69, 0x6d, 0x70, 0x6f, 0x72, 0x74, 0x5f, 0x6f, 0x6e, 0x6c,
+	0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x69, 0x6d, 0x70, 0x6f, 0x72, 0x74, 0x4f,
+	0x6e, 0x6c, 0x79, 0x12, 0x39, 0x0a, 0x0a, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x66, 0x65, 0x74, 0x63,
+	0x68, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74,
+	0x61, 0x6d, 0x70, 0x52, 0x09, 0x6c, 0x61, 0x73, 0x74, 0x46, 0x65, 0x74, 0x63, 0x68, 0x12, 0x3d,
+	0x0a, 0x0c, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
+	0x52, 0x0b, 0x6c, 0x61, 0x73, 0x74, 0x53, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x12, 0x1d, 0x0a,
+	0x0a, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x09, 0x6c, 0x61, 0x73, 0x74, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x1d, 0x0a, 0x0a,
+	0x66, 0x69, 0x6c, 0x65, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x09, 0x66, 0x69, 0x6c, 0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x21, 0x0a, 0x0c, 0x63,
+	0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x08, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0b, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x48, 0x61, 0x73, 0x68, 0x12, 0x48,
+	0x0a, 0x10, 0x62, 0x72, 0x65, 0x61, 0x6b, 0x69, 0x6e, 0x67, 0x5f, 0x63, 0x68, 0x61, 0x6e, 0x67,
+	0x65, 0x73, 0x18, 0x09, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x74, 0x6b, 0x64, 0x2e, 0x70,
+	0x62, 0x74, 0x79, 0x70, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x72, 0x65, 0x61, 0x6b, 0x69, 0x6e,
+	0x67, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x0f, 0x62, 0x72, 0x65, 0x61, 0x6b, 0x69, 0x6e,
+	0x67, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x12, 0x56, 0x0a, 0x19, 0x62, 0x72, 0x65, 0x61,
+	0x6b, 0x69, 0x6e, 0x67, 0x5f, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x5f, 0x64, 0x65, 0x74,
+	0x65, 0x63, 0x74, 0x65, 0x64, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69,
+	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x17, 0x62, 0x72, 0x65, 0x61, 0x6b, 0x69, 0x6e,
+	0x67, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x44, 0x65, 0x74, 0x65, 0x63, 0x74, 0x65, 0x64,
+	0x12, 0x1a, 0x0a, 0x08, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x65, 0x64, 0x18, 0x0b, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x08, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x65, 0x64, 0x12, 0x1a, 0x0a, 0x08,
+	0x72, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
+	0x72, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0xc5, 0x01, 0x0a, 0x0e, 0x42, 0x72, 0x65,
+	0x61, 0x6b, 0x69, 0x6e, 0x67, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x35, 0x0a, 0x04, 0x6b,
+	0x69, 0x6e, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x21, 0x2e, 0x74, 0x6b, 0x64, 0x2e,
+	0x70, 0x62, 0x74, 0x79, 0x70, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x72, 0x65, 0x61, 0x6b, 0x69,
+	0x6e, 0x67, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x4b, 0x69, 0x6e, 0x64, 0x52, 0x04, 0x6b, 0x69,
+	0x6e, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x65, 0x6c, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x07, 0x65, 0x6c, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x1b, 0x0a, 0x09,
+	0x66, 0x69, 0x6c, 0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x08, 0x66, 0x69, 0x6c, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x77, 0x69, 0x72,
+	0x65, 0x5f, 0x62, 0x72, 0x65, 0x61, 0x6b, 0x69, 0x6e, 0x67, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x0c, 0x77, 0x69, 0x72, 0x65, 0x42, 0x72, 0x65, 0x61, 0x6b, 0x69, 0x6e, 0x67, 0x12, 0x20,
+	0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e,
+	0x22, 0x14, 0x0a, 0x12, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x4c, 0x0a, 0x13, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x6f,
+	0x75, 0x72, 0x63, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x35, 0x0a,
+	0x07, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1b,
+	0x2e, 0x74, 0x6b, 0x64, 0x2e, 0x70, 0x62, 0x74, 0x79, 0x70, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x53,
+	0x6f, 0x75, 0x72, 0x63, 0x65, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x07, 0x73, 0x6f, 0x75,
+	0x72, 0x63, 0x65, 0x73, 0x22, 0x2d, 0x0a, 0x15, 0x52, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68, 0x53,
+	0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a,
+	0x05, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x6e, 0x61,
+	0x6d, 0x65, 0x73, 0x22, 0x4f, 0x0a, 0x16, 0x52, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68, 0x53, 0x6f,
+	0x75, 0x72, 0x63, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x35, 0x0a,
+	0x07, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1b,
+	0x2e, 0x74, 0x6b, 0x64, 0x2e, 0x70, 0x62, 0x74, 0x79, 0x70, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x53,
+	0x6f, 0x75, 0x72, 0x63, 0x65, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x07, 0x73, 0x6f, 0x75,
+	0x72, 0x63, 0x65, 0x73, 0x22, 0x75, 0x0a, 0x0e, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x65,
+	0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x72,
+	0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x72, 0x6c, 0x12, 0x21, 0x0a, 0x0c,
+	0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0b, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x48, 0x61, 0x73, 0x68, 0x12,
+	0x1a, 0x0a, 0x08, 0x72, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x08, 0x72, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0xbe, 0x01, 0x0a, 0x0c,
+	0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x0e, 0x0a, 0x02,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04,
+	0x68, 0x61, 0x73, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x68, 0x61, 0x73, 0x68,
+	0x12, 0x34, 0x0a, 0x07, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x07, 0x63,
+	0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x12, 0x37, 0x0a, 0x07, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65,
+	0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x74, 0x6b, 0x64, 0x2e, 0x70, 0x62,
+	0x74, 0x79, 0x70, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x65,
+	0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x07, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x12,
+	0x1b, 0x0a, 0x09, 0x69, 0x6e, 0x5f, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x08, 0x69, 0x6e, 0x4d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x22, 0x16, 0x0a, 0x14,
+	0x4c, 0x69, 0x73, 0x74, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x22, 0x52, 0x0a, 0x15, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x6e, 0x61, 0x70,
+	0x73, 0x68, 0x6f, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x39, 0x0a,
+	0x09, 0x73, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x1b, 0x2e, 0x74, 0x6b, 0x64, 0x2e, 0x70, 0x62, 0x74, 0x79, 0x70, 0x65, 0x2e, 0x76, 0x31,
+	0x2e, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x09, 0x73,
+	0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x73, 0x22, 0x39, 0x0a, 0x09, 0x50, 0x72, 0x6f, 0x74,
+	0x6f, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x61, 0x74, 0x68, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6e,
+	0x74, 0x65, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x6f, 0x6e, 0x74,
+	0x65, 0x6e, 0x74, 0x22, 0x3d, 0x0a, 0x0b, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x42, 0x75, 0x6e, 0x64,
+	0x6c, 0x65, 0x12, 0x2e, 0x0a, 0x05, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x18, 0x2e, 0x74, 0x6b, 0x64, 0x2e, 0x70, 0x62, 0x74, 0x79, 0x70, 0x65, 0x2e, 0x76,
+	0x31, 0x2e, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x05, 0x66, 0x69, 0x6c,
+	0x65, 0x73, 0x22, 0xc8, 0x01, 0x0a, 0x13, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x53, 0x6f, 0x75,
+	0x72, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61,
+	0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1f,
+	0x0a, 0x0b, 0x69, 0x6d, 0x70, 0x6f, 0x72, 0x74, 0x5f, 0x6f, 0x6e, 0x6c, 0x79, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x0a, 0x69, 0x6d, 0x70, 0x6f, 0x72, 0x74, 0x4f, 0x6e, 0x6c, 0x79, 0x12,
+	0x30, 0x0a, 0x13, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74,
+	0x6f, 0x72, 0x5f, 0x73, 0x65, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x48, 0x00, 0x52, 0x11,
+	0x66, 0x69, 0x6c, 0x65, 0x44, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x6f, 0x72, 0x53, 0x65,
+	0x74, 0x12, 0x3f, 0x0a, 0x0c, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x5f, 0x62, 0x75, 0x6e, 0x64, 0x6c,
+	0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x74, 0x6b, 0x64, 0x2e, 0x70, 0x62,
+	0x74, 0x79, 0x70, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x42, 0x75, 0x6e,
+	0x64, 0x6c, 0x65, 0x48, 0x00, 0x52, 0x0b, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x42, 0x75, 0x6e, 0x64,
+	0x6c, 0x65, 0x42, 0x09, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x22, 0x4b, 0x0a,
+	0x14, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x33, 0x0a, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x74, 0x6b, 0x64, 0x2e, 0x70, 0x62, 0x74, 0x79,
+	0x70, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x53, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x52, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x22, 0xd7, 0x01, 0x0a, 0x08, 0x43,
+	0x6f, 0x6e, 0x66, 0x6c, 0x69, 0x63, 0x74, 0x12, 0x2f, 0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1b, 0x2e, 0x74, 0x6b, 0x64, 0x2e, 0x70, 0x62, 0x74, 0x79,
+	0x70, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6e, 0x66, 0x6c, 0x69, 0x63, 0x74, 0x4b, 0x69,
+	0x6e, 0x64, 0x52, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06,
+	0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x6f,
+	0x75, 0x72, 0x63, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x66, 0x69, 0x6c, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x6f, 0x74, 0x68, 0x65,
+	0x72, 0x5f, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b,
+	0x6f, 0x74, 0x68, 0x65, 0x72, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x6f,
+	0x74, 0x68, 0x65, 0x72, 0x5f, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x09, 0x6f, 0x74, 0x68, 0x65, 0x72, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x72, 0x65,
+	0x66, 0x75, 0x73, 0x65, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x72, 0x65, 0x66,
+	0x75, 0x73, 0x65, 0x64, 0x22, 0x16, 0x0a, 0x14, 0x4c, 0x69, 0x73, 0x74, 0x43, 0x6f, 0x6e, 0x66,
+	0x6c, 0x69, 0x63, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x66, 0x0a, 0x15,
+	0x4c, 0x69, 0x73, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x6c, 0x69, 0x63, 0x74, 0x73, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x12, 0x35, 0x0a,
+	0x09, 0x63, 0x6f, 0x6e, 0x66, 0x6c, 0x69, 0x63, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x17, 0x2e, 0x74, 0x6b, 0x64, 0x2e, 0x70, 0x62, 0x74, 0x79, 0x70, 0x65, 0x2e, 0x76, 0x31,
+	0x2e, 0x43, 0x6f, 0x6e, 0x66, 0x6c, 0x69, 0x63, 0x74, 0x52, 0x09, 0x63, 0x6f, 0x6e, 0x66, 0x6c,
+	0x69, 0x63, 0x74, 0x73, 0x2a, 0xe7, 0x02, 0x0a, 0x12, 0x42, 0x72, 0x65, 0x61, 0x6b, 0x69, 0x6e,
+	0x67, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x4b, 0x69, 0x6e, 0x64, 0x12, 0x24, 0x0a, 0x20, 0x42,
+	0x52, 0x45, 0x41, 0x4b, 0x49, 0x4e, 0x47, 0x5f, 0x43, 0x48, 0x41, 0x4e, 0x47, 0x45, 0x5f, 0x4b,
+	0x49, 0x4e, 0x44, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10,
+	0x00, 0x12, 0x26, 0x0a, 0x22, 0x42, 0x52, 0x45, 0x41, 0x4b, 0x49, 0x4e, 0x47, 0x5f, 0x43, 0x48,
+	0x41, 0x4e, 0x47, 0x45, 0x5f, 0x4b, 0x49, 0x4e, 0x44, 0x5f, 0x46, 0x49, 0x45, 0x4c, 0x44, 0x5f,
+	0x52, 0x45, 0x4d, 0x4f, 0x56, 0x45, 0x44, 0x10, 0x01, 0x12, 0x2d, 0x0a, 0x29, 0x42, 0x52, 0x45,
+	0x41, 0x4b, 0x49, 0x4e, 0x47, 0x5f, 0x43, 0x48, 0x41, 0x4e, 0x47, 0x45, 0x5f, 0x4b, 0x49, 0x4e,
+	0x44, 0x5f, 0x46, 0x49, 0x45, 0x4c, 0x44, 0x5f, 0x4e, 0x55, 0x4d, 0x42, 0x45, 0x52, 0x5f, 0x43,
+	0x48, 0x41, 0x4e, 0x47, 0x45, 0x44, 0x10, 0x02, 0x12, 0x2b, 0x0a, 0x27, 0x42, 0x52, 0x45, 0x41,
+	0x4b, 0x49, 0x4e, 0x47, 0x5f, 0x43, 0x48, 0x41, 0x4e, 0x47, 0x45, 0x5f, 0x4b, 0x49, 0x4e, 0x44,
+	0x5f, 0x46, 0x49, 0x45, 0x4c, 0x44, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x43, 0x48, 0x41, 0x4e,
+	0x47, 0x45, 0x44, 0x10, 0x03, 0x12, 0x26, 0x0a, 0x22, 0x42, 0x52, 0x45, 0x41, 0x4b, 0x49, 0x4e,
+	0x47, 0x5f, 0x43, 0x48, 0x41, 0x4e, 0x47, 0x45, 0x5f, 0x4b, 0x49, 0x4e, 0x44, 0x5f, 0x46, 0x49,
+	0x45, 0x4c, 0x44, 0x5f, 0x52, 0x45, 0x4e, 0x41, 0x4d, 0x45, 0x44, 0x10, 0x04, 0x12, 0x28, 0x0a,
+	0x24, 0x42, 0x52, 0x45, 0x41, 0x4b, 0x49, 0x4e, 0x47, 0x5f, 0x43, 0x48, 0x41, 0x4e, 0x47, 0x45,
+	0x5f, 0x4b, 0x49, 0x4e, 0x44, 0x5f, 0x4d, 0x45, 0x53, 0x53, 0x41, 0x47, 0x45, 0x5f, 0x52, 0x45,
+	0x4d, 0x4f, 0x56, 0x45, 0x44, 0x10, 0x05, 0x12, 0x28, 0x0a, 0x24, 0x42, 0x52, 0x45, 0x41, 0x4b,
+	0x49, 0x4e, 0x47, 0x5f, 0x43, 0x48, 0x41, 0x4e, 0x47, 0x45, 0x5f, 0x4b, 0x49, 0x4e, 0x44, 0x5f,
+	0x4d, 0x45, 0x53, 0x53, 0x41, 0x47, 0x45, 0x5f, 0x52, 0x45, 0x4e, 0x41, 0x4d, 0x45, 0x44, 0x10,
+	0x06, 0x12, 0x2b, 0x0a, 0x27, 0x42, 0x52, 0x45, 0x41, 0x4b, 0x49, 0x4e, 0x47, 0x5f, 0x43, 0x48,
+	0x41, 0x4e, 0x47, 0x45, 0x5f, 0x4b, 0x49, 0x4e, 0x44, 0x5f, 0x45, 0x4e, 0x55, 0x4d, 0x5f, 0x56,
+	0x41, 0x4c, 0x55, 0x45, 0x5f, 0x52, 0x45, 0x4d, 0x4f, 0x56, 0x45, 0x44, 0x10, 0x07, 0x2a, 0x5f,
+	0x0a, 0x0c, 0x43, 0x6f, 0x6e, 0x66, 0x6c, 0x69, 0x63, 0x74, 0x4b, 0x69, 0x6e, 0x64, 0x12, 0x1d,
+	0x0a, 0x19, 0x43, 0x4f, 0x4e, 0x46, 0x4c, 0x49, 0x43, 0x54, 0x5f, 0x4b, 0x49, 0x4e, 0x44, 0x5f,
+	0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x16, 0x0a,
+	0x12, 0x43, 0x4f, 0x4e, 0x46, 0x4c, 0x49, 0x43, 0x54, 0x5f, 0x4b, 0x49, 0x4e, 0x44, 0x5f, 0x46,
+	0x49, 0x4c, 0x45, 0x10, 0x01, 0x12, 0x18, 0x0a, 0x14, 0x43, 0x4f, 0x4e, 0x46, 0x4c, 0x49, 0x43,
+	0x54, 0x5f, 0x4b, 0x49, 0x4e, 0x44, 0x5f, 0x53, 0x59, 0x4d, 0x42, 0x4f, 0x4c, 0x10, 0x02, 0x32,
+	0xd4, 0x03, 0x0a, 0x0c, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
+	0x12, 0x54, 0x0a, 0x0b, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x12,
+	0x21, 0x2e, 0x74, 0x6b, 0x64, 0x2e, 0x70, 0x62, 0x74, 0x79, 0x70, 0x65, 0x2e, 0x76, 0x31, 0x2e,
+	0x4c, 0x69, 0x73, 0x74, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x22, 0x2e, 0x74, 0x6b, 0x64, 0x2e, 0x70, 0x62, 0x74, 0x79, 0x70, 0x65, 0x2e,
+	0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x5d, 0x0a, 0x0e, 0x52, 0x65, 0x66, 0x72, 0x65, 0x73,
+	0x68, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x12, 0x24, 0x2e, 0x74, 0x6b, 0x64, 0x2e, 0x70,
+	0x62, 0x74, 0x79, 0x70, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68,
+	0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x25,
+	0x2e, 0x74, 0x6b, 0x64, 0x2e, 0x70, 0x62, 0x74, 0x79, 0x70, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x52,
+	0x65, 0x66, 0x72, 0x65, 0x73, 0x68, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x5a, 0x0a, 0x0d, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x6e, 0x61,
+	0x70, 0x73, 0x68, 0x6f, 0x74, 0x73, 0x12, 0x23, 0x2e, 0x74, 0x6b, 0x64, 0x2e, 0x70, 0x62, 0x74,
+	0x79, 0x70, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x6e, 0x61, 0x70, 0x73,
+	0x68, 0x6f, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x24, 0x2e, 0x74, 0x6b,
+	0x64, 0x2e, 0x70, 0x62, 0x74, 0x79, 0x70, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74,
+	0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x57, 0x0a, 0x0c, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x53, 0x6f, 0x75, 0x72, 0x63,
+	0x65, 0x12, 0x22, 0x2e, 0x74, 0x6b, 0x64, 0x2e, 0x70, 0x62, 0x74, 0x79, 0x70, 0x65, 0x2e, 0x76,
+	0x31, 0x2e, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x23, 0x2e, 0x74, 0x6b, 0x64, 0x2e, 0x70, 0x62, 0x74, 0x79,
+	0x70, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x53, 0x6f, 0x75, 0x72,
+	0x63, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x5a, 0x0a, 0x0d, 0x4c, 0x69,
+	0x73, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x6c, 0x69, 0x63, 0x74, 0x73, 0x12, 0x23, 0x2e, 0x74, 0x6b,
+	0x64, 0x2e, 0x70, 0x62, 0x74, 0x79, 0x70, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74,
+	0x43, 0x6f, 0x6e, 0x66, 0x6c, 0x69, 0x63, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x24, 0x2e, 0x74, 0x6b, 0x64, 0x2e, 0x70, 0x62, 0x74, 0x79, 0x70, 0x65, 0x2e, 0x76, 0x31,
+	0x2e, 0x4c, 0x69, 0x73, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x6c, 0x69, 0x63, 0x74, 0x73, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x4e, 0x5a, 0x4c, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62,
+	0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x74, 0x69, 0x65, 0x72, 0x6b, 0x6c, 0x69, 0x6e, 0x69, 0x6b, 0x2d,
+	0x64, 0x6f, 0x62, 0x65, 0x72, 0x73, 0x62, 0x65, 0x72, 0x67, 0x2f, 0x70, 0x62, 0x74, 0x79, 0x70,
+	0x65, 0x2d, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2f, 0x67, 0x65, 0x6e, 0x2f, 0x67, 0x6f, 0x2f,
+	0x74, 0x6b, 0x64, 0x2f, 0x70, 0x62, 0x74, 0x79, 0x70, 0x65, 0x2f, 0x76, 0x31, 0x3b, 0x70, 0x62,
+	0x74, 0x79, 0x70, 0x65, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_tkd_pbtype_v1_admin_proto_rawDescOnce sync.Once
+	file_tkd_pbtype_v1_admin_proto_rawDescData = file_tkd_pbtype_v1_admin_proto_rawDesc
+)
+
+func file_tkd_pbtype_v1_admin_proto_rawDescGZIP() []byte {
+	file_tkd_pbtype_v1_admin_proto_rawDescOnce.Do(func() {
+		file_tkd_pbtype_v1_admin_proto_rawDescData = protoimpl.X.CompressGZIP(file_tkd_pbtype_v1_admin_proto_rawDescData)
+	})
+	return file_tkd_pbtype_v1_admin_proto_rawDescData
+}
+
+var file_tkd_pbtype_v1_admin_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_tkd_pbtype_v1_admin_proto_msgTypes = make([]protoimpl.MessageInfo, 17)
+var file_tkd_pbtype_v1_admin_proto_goTypes = []any{
+	(BreakingChangeKind)(0),        // 0: tkd.pbtype.v1.BreakingChangeKind
+	(ConflictKind)(0),              // 1: tkd.pbtype.v1.ConflictKind
+	(*SourceStatus)(nil),           // 2: tkd.pbtype.v1.SourceStatus
+	(*BreakingChange)(nil),         // 3: tkd.pbtype.v1.BreakingChange
+	(*ListSourcesRequest)(nil),     // 4: tkd.pbtype.v1.ListSourcesRequest
+	(*ListSourcesResponse)(nil),    // 5: tkd.pbtype.v1.ListSourcesResponse
+	(*RefreshSourcesRequest)(nil),  // 6: tkd.pbtype.v1.RefreshSourcesRequest
+	(*RefreshSourcesResponse)(nil), // 7: tkd.pbtype.v1.RefreshSourcesResponse
+	(*SourceRevision)(nil),         // 8: tkd.pbtype.v1.SourceRevision
+	(*SnapshotInfo)(nil),           // 9: tkd.pbtype.v1.SnapshotInfo
+	(*ListSnapshotsRequest)(nil),   // 10: tkd.pbtype.v1.ListSnapshotsRequest
+	(*ListSnapshotsResponse)(nil),  // 11: tkd.pbtype.v1.ListSnapshotsResponse
+	(*ProtoFile)(nil),              // 12: tkd.pbtype.v1.ProtoFile
+	(*ProtoBundle)(nil),            // 13: tkd.pbtype.v1.ProtoBundle
+	(*UploadSourceRequest)(nil),    // 14: tkd.pbtype.v1.UploadSourceRequest
+	(*UploadSourceResponse)(nil),   // 15: tkd.pbtype.v1.UploadSourceResponse
+	(*Conflict)(nil),               // 16: tkd.pbtype.v1.Conflict
+	(*ListConflictsRequest)(nil),   // 17: tkd.pbtype.v1.ListConflictsRequest
+	(*ListConflictsResponse)(nil),  // 18: tkd.pbtype.v1.ListConflictsResponse
+	(*timestamppb.Timestamp)(nil),  // 19: google.protobuf.Timestamp
+}
+var file_tkd_pbtype_v1_admin_proto_depIdxs = []int32{
+	19, // 0: tkd.pbtype.v1.SourceStatus.last_fetch:type_name -> google.protobuf.Timestamp
+	19, // 1: tkd.pbtype.v1.SourceStatus.last_success:type_name -> google.protobuf.Timestamp
+	3,  // 2: tkd.pbtype.v1.SourceStatus.breaking_changes:type_name -> tkd.pbtype.v1.BreakingChange
+	19, // 3: tkd.pbtype.v1.SourceStatus.breaking_changes_detected:type_name -> google.protobuf.Timestamp
+	0,  // 4: tkd.pbtype.v1.BreakingChange.kind:type_name -> tkd.pbtype.v1.BreakingChangeKind
+	2,  // 5: tkd.pbtype.v1.ListSourcesResponse.sources:type_name -> tkd.pbtype.v1.SourceStatus
+	2,  // 6: tkd.pbtype.v1.RefreshSourcesResponse.sources:type_name -> tkd.pbtype.v1.SourceStatus
+	19, // 7: tkd.pbtype.v1.SnapshotInfo.created:type_name -> google.protobuf.Timestamp
+	8,  // 8: tkd.pbtype.v1.SnapshotInfo.sources:type_name -> tkd.pbtype.v1.SourceRevision
+	9,  // 9: tkd.pbtype.v1.ListSnapshotsResponse.snapshots:type_name -> tkd.pbtype.v1.SnapshotInfo
+	12, // 10: tkd.pbtype.v1.ProtoBundle.files:type_name -> tkd.pbtype.v1.ProtoFile
+	13, // 11: tkd.pbtype.v1.UploadSourceRequest.proto_bundle:type_name -> tkd.pbtype.v1.ProtoBundle
+	2,  // 12: tkd.pbtype.v1.UploadSourceResponse.source:type_name -> tkd.pbtype.v1.SourceStatus
+	1,  // 13: tkd.pbtype.v1.Conflict.kind:type_name -> tkd.pbtype.v1.ConflictKind
+	16, // 14: tkd.pbtype.v1.ListConflictsResponse.conflicts:type_name -> tkd.pbtype.v1.Conflict
+	4,  // 15: tkd.pbtype.v1.AdminService.ListSources:input_type -> tkd.pbtype.v1.ListSourcesRequest
+	6,  // 16: tkd.pbtype.v1.AdminService.RefreshSources:input_type -> tkd.pbtype.v1.RefreshSourcesRequest
+	10, // 17: tkd.pbtype.v1.AdminService.ListSnapshots:input_type -> tkd.pbtype.v1.ListSnapshotsRequest
+	14, // 18: tkd.pbtype.v1.AdminService.UploadSource:input_type -> tkd.pbtype.v1.UploadSourceRequest
+	17, // 19: tkd.pbtype.v1.AdminService.ListConflicts:input_type -> tkd.pbtype.v1.ListConflictsRequest
+	5,  // 20: tkd.pbtype.v1.AdminService.ListSources:output_type -> tkd.pbtype.v1.ListSourcesResponse
+	7,  // 21: tkd.pbtype.v1.AdminService.RefreshSources:output_type -> tkd.pbtype.v1.RefreshSourcesResponse
+	11, // 22: tkd.pbtype.v1.AdminService.ListSnapshots:output_type -> tkd.pbtype.v1.ListSnapshotsResponse
+	15, // 23: tkd.pbtype.v1.AdminService.UploadSource:output_type -> tkd.pbtype.v1.UploadSourceResponse
+	18, // 24: tkd.pbtype.v1.AdminService.ListConflicts:output_type -> tkd.pbtype.v1.ListConflictsResponse
+	20, // [20:25] is the sub-list for method output_type
+	15, // [15:20] is the sub-list for method input_type
+	15, // [15:15] is the sub-list for extension type_name
+	15, // [15:15] is the sub-list for extension extendee
+	0,  // [0:15] is the sub-list for field type_name
+}
+
+func init() { file_tkd_pbtype_v1_admin_proto_init() }
+func file_tkd_pbtype_v1_admin_proto_init() {
+	if File_tkd_pbtype_v1_admin_proto != nil {
+		return
+	}
+	file_tkd_pbtype_v1_admin_proto_msgTypes[12].OneofWrappers = []any{
+		(*UploadSourceRequest_FileDescriptorSet)(nil),
+		(*UploadSourceRequest_ProtoBundle)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_tkd_pbtype_v1_admin_proto_rawDesc,
+			NumEnums:      2,
+			NumMessages:   17,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_tkd_pbtype_v1_admin_proto_goTypes,
+		DependencyIndexes: file_tkd_pbtype_v1_admin_proto_depIdxs,
+		EnumInfos:         file_tkd_pbtype_v1_admin_proto_enumTypes,
+		MessageInfos:      file_tkd_pbtype_v1_admin_proto_msgTypes,
+	}.Build()
+	File_tkd_pbtype_v1_admin_proto = out.File
+	file_tkd_pbtype_v1_admin_proto_rawDesc = nil
+	file_tkd_pbtype_v1_admin_proto_goTypes = nil
+	file_tkd_pbtype_v1_admin_proto_depIdxs = nil
+}
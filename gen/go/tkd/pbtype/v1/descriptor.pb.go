@@ -0,0 +1,829 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.35.1
+// 	protoc        (unknown)
+// source: tkd/pbtype/v1/descriptor.proto
+
+package pbtypev1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ResolveRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Kind:
+	//	*ResolveRequest_FileByFilename
+	//	*ResolveRequest_FileContainingSymbol
+	//	*ResolveRequest_FileContainingUrl
+	Kind isResolveRequest_Kind `protobuf_oneof:"kind"`
+	// Whether or not all transitive dependencies of the resolved file
+	// should be included in the response.
+	IncludeDependencies bool `protobuf:"varint,4,opt,name=include_dependencies,json=includeDependencies,proto3" json:"include_dependencies,omitempty"`
+	// A list of file names the client already knows. Those files, and
+	// their dependencies, are not included in the response.
+	KnownFiles []string `protobuf:"bytes,5,rep,name=known_files,json=knownFiles,proto3" json:"known_files,omitempty"`
+	// Pins the request to a snapshot, identified by its ID or a unique
+	// prefix of its hash. If empty, the current snapshot is used.
+	Snapshot string `protobuf:"bytes,6,opt,name=snapshot,proto3" json:"snapshot,omitempty"`
+}
+
+func (x *ResolveRequest) Reset() {
+	*x = ResolveRequest{}
+	mi := &file_tkd_pbtype_v1_descriptor_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResolveRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResolveRequest) ProtoMessage() {}
+
+func (x *ResolveRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tkd_pbtype_v1_descriptor_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResolveRequest.ProtoReflect.Descriptor instead.
+func (*ResolveRequest) Descriptor() ([]byte, []int) {
+	return file_tkd_pbtype_v1_descriptor_proto_rawDescGZIP(), []int{0}
+}
+
+func (m *ResolveRequest) GetKind() isResolveRequest_Kind {
+	if m != nil {
+		return m.Kind
+	}
+	return nil
+}
+
+func (x *ResolveRequest) GetFileByFilename() string {
+	if x, ok := x.GetKind().(*ResolveRequest_FileByFilename); ok {
+		return x.FileByFilename
+	}
+	return ""
+}
+
+func (x *ResolveRequest) GetFileContainingSymbol() string {
+	if x, ok := x.GetKind().(*ResolveRequest_FileContainingSymbol); ok {
+		return x.FileContainingSymbol
+	}
+	return ""
+}
+
+func (x *ResolveRequest) GetFileContainingUrl() string {
+	if x, ok := x.GetKind().(*ResolveRequest_FileContainingUrl); ok {
+		return x.FileContainingUrl
+	}
+	return ""
+}
+
+func (x *ResolveRequest) GetIncludeDependencies() bool {
+	if x != nil {
+		return x.IncludeDependencies
+	}
+	return false
+}
+
+func (x *ResolveRequest) GetKnownFiles() []string {
+	if x != nil {
+		return x.KnownFiles
+	}
+	return nil
+}
+
+func (x *ResolveRequest) GetSnapshot() string {
+	if x != nil {
+		return x.Snapshot
+	}
+	return ""
+}
+
+type isResolveRequest_Kind interface {
+	isResolveRequest_Kind()
+}
+
+type ResolveRequest_FileByFilename struct {
+	// Searches for the file descriptor proto by file name.
+	// For example:
+	//  - tkd/typeserver/v1/typeserver.proto
+	//  - buf/validate/validate.proto
+	FileByFilename string `protobuf:"bytes,1,opt,name=file_by_filename,json=fileByFilename,proto3,oneof"`
+}
+
+type ResolveRequest_FileContainingSymbol struct {
+	// Searches for the file descriptor that contains the specified
+	// fully-qualified symbol name.
+	FileContainingSymbol string `protobuf:"bytes,2,opt,name=file_containing_symbol,json=fileContainingSymbol,proto3,oneof"`
+}
+
+type ResolveRequest_FileContainingUrl struct {
+	// Similar to file_containing_symbol but searches the type_url for
+	// a message. See google.protobuf.Any for more information.
+	FileContainingUrl string `protobuf:"bytes,3,opt,name=file_containing_url,json=fileContainingUrl,proto3,oneof"`
+}
+
+func (*ResolveRequest_FileByFilename) isResolveRequest_Kind() {}
+
+func (*ResolveRequest_FileContainingSymbol) isResolveRequest_Kind() {}
+
+func (*ResolveRequest_FileContainingUrl) isResolveRequest_Kind() {}
+
+type ResolveResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Serialized google.protobuf.FileDescriptorProto messages ordered by
+	// dependency so each file follows all of its dependencies. The
+	// resolved file is always included as the last entry.
+	FileDescriptorProtos [][]byte `protobuf:"bytes,1,rep,name=file_descriptor_protos,json=fileDescriptorProtos,proto3" json:"file_descriptor_protos,omitempty"`
+	// The ID of the snapshot the request has been resolved from.
+	SnapshotId uint64 `protobuf:"varint,2,opt,name=snapshot_id,json=snapshotId,proto3" json:"snapshot_id,omitempty"`
+	// The hash of the snapshot the request has been resolved from.
+	SnapshotHash string `protobuf:"bytes,3,opt,name=snapshot_hash,json=snapshotHash,proto3" json:"snapshot_hash,omitempty"`
+	// The provenance of all returned files that have been provided by a
+	// source, in the order of file_descriptor_protos.
+	Provenance []*FileProvenance `protobuf:"bytes,4,rep,name=provenance,proto3" json:"provenance,omitempty"`
+}
+
+func (x *ResolveResponse) Reset() {
+	*x = ResolveResponse{}
+	mi := &file_tkd_pbtype_v1_descriptor_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResolveResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResolveResponse) ProtoMessage() {}
+
+func (x *ResolveResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tkd_pbtype_v1_descriptor_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResolveResponse.ProtoReflect.Descriptor instead.
+func (*ResolveResponse) Descriptor() ([]byte, []int) {
+	return file_tkd_pbtype_v1_descriptor_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ResolveResponse) GetFileDescriptorProtos() [][]byte {
+	if x != nil {
+		return x.FileDescriptorProtos
+	}
+	return nil
+}
+
+func (x *ResolveResponse) GetSnapshotId() uint64 {
+	if x != nil {
+		return x.SnapshotId
+	}
+	return 0
+}
+
+func (x *ResolveResponse) GetSnapshotHash() string {
+	if x != nil {
+		return x.SnapshotHash
+	}
+	return ""
+}
+
+func (x *ResolveResponse) GetProvenance() []*FileProvenance {
+	if x != nil {
+		return x.Provenance
+	}
+	return nil
+}
+
+// ResolveItem identifies a file to resolve in a BatchResolveRequest.
+type ResolveItem struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Kind:
+	//	*ResolveItem_FileByFilename
+	//	*ResolveItem_FileContainingSymbol
+	//	*ResolveItem_FileContainingUrl
+	Kind isResolveItem_Kind `protobuf_oneof:"kind"`
+}
+
+func (x *ResolveItem) Reset() {
+	*x = ResolveItem{}
+	mi := &file_tkd_pbtype_v1_descriptor_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResolveItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResolveItem) ProtoMessage() {}
+
+func (x *ResolveItem) ProtoReflect() protoreflect.Message {
+	mi := &file_tkd_pbtype_v1_descriptor_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResolveItem.ProtoReflect.Descriptor instead.
+func (*ResolveItem) Descriptor() ([]byte, []int) {
+	return file_tkd_pbtype_v1_descriptor_proto_rawDescGZIP(), []int{2}
+}
+
+func (m *ResolveItem) GetKind() isResolveItem_Kind {
+	if m != nil {
+		return m.Kind
+	}
+	return nil
+}
+
+func (x *ResolveItem) GetFileByFilename() string {
+	if x, ok := x.GetKind().(*ResolveItem_FileByFilename); ok {
+		return x.FileByFilename
+	}
+	return ""
+}
+
+func (x *ResolveItem) GetFileContainingSymbol() string {
+	if x, ok := x.GetKind().(*ResolveItem_FileContainingSymbol); ok {
+		return x.FileContainingSymbol
+	}
+	return ""
+}
+
+func (x *ResolveItem) GetFileContainingUrl() string {
+	if x, ok := x.GetKind().(*ResolveItem_FileContainingUrl); ok {
+		return x.FileContainingUrl
+	}
+	return ""
+}
+
+type isResolveItem_Kind interface {
+	isResolveItem_Kind()
+}
+
+type ResolveItem_FileByFilename struct {
+	// Searches for the file descriptor proto by file name.
+	FileByFilename string `protobuf:"bytes,1,opt,name=file_by_filename,json=fileByFilename,proto3,oneof"`
+}
+
+type ResolveItem_FileContainingSymbol struct {
+	// Searches for the file descriptor that contains the specified
+	// fully-qualified symbol name.
+	FileContainingSymbol string `protobuf:"bytes,2,opt,name=file_containing_symbol,json=fileContainingSymbol,proto3,oneof"`
+}
+
+type ResolveItem_FileContainingUrl struct {
+	// Searches for the file descriptor that contains the message
+	// referenced by the type URL.
+	FileContainingUrl string `protobuf:"bytes,3,opt,name=file_containing_url,json=fileContainingUrl,proto3,oneof"`
+}
+
+func (*ResolveItem_FileByFilename) isResolveItem_Kind() {}
+
+func (*ResolveItem_FileContainingSymbol) isResolveItem_Kind() {}
+
+func (*ResolveItem_FileContainingUrl) isResolveItem_Kind() {}
+
+type BatchResolveRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The items to resolve. At most 500 items may be requested at once.
+	Items []*ResolveItem `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	// Whether or not all transitive dependencies of the resolved files
+	// should be included in the response.
+	IncludeDependencies bool `protobuf:"varint,2,opt,name=include_dependencies,json=includeDependencies,proto3" json:"include_dependencies,omitempty"`
+	// A list of file names the client already knows. Those files, and
+	// their dependencies, are not included in the response.
+	KnownFiles []string `protobuf:"bytes,3,rep,name=known_files,json=knownFiles,proto3" json:"known_files,omitempty"`
+	// Pins the request to a snapshot, identified by its ID or a unique
+	// prefix of its hash. If empty, the current snapshot is used.
+	Snapshot string `protobuf:"bytes,4,opt,name=snapshot,proto3" json:"snapshot,omitempty"`
+}
+
+func (x *BatchResolveRequest) Reset() {
+	*x = BatchResolveRequest{}
+	mi := &file_tkd_pbtype_v1_descriptor_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchResolveRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchResolveRequest) ProtoMessage() {}
+
+func (x *BatchResolveRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tkd_pbtype_v1_descriptor_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchResolveRequest.ProtoReflect.Descriptor instead.
+func (*BatchResolveRequest) Descriptor() ([]byte, []int) {
+	return file_tkd_pbtype_v1_descriptor_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *BatchResolveRequest) GetItems() []*ResolveItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *BatchResolveRequest) GetIncludeDependencies() bool {
+	if x != nil {
+		return x.IncludeDependencies
+	}
+	return false
+}
+
+func (x *BatchResolveRequest) GetKnownFiles() []string {
+	if x != nil {
+		return x.KnownFiles
+	}
+	return nil
+}
+
+func (x *BatchResolveRequest) GetSnapshot() string {
+	if x != nil {
+		return x.Snapshot
+	}
+	return ""
+}
+
+// ResolveItemStatus describes the result of a single item of a
+// BatchResolveRequest.
+type ResolveItemStatus struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The requested item.
+	Item *ResolveItem `protobuf:"bytes,1,opt,name=item,proto3" json:"item,omitempty"`
+	// The name of the file that contains the item. Empty if the item
+	// could not be resolved.
+	FileName string `protobuf:"bytes,2,opt,name=file_name,json=fileName,proto3" json:"file_name,omitempty"`
+	// The reason why the item could not be resolved.
+	Error string `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	// The gRPC status code of error, like 5 for NOT_FOUND. Zero if the
+	// item has been resolved.
+	Code int32 `protobuf:"varint,4,opt,name=code,proto3" json:"code,omitempty"`
+	// Similar symbols or files if the item could not be found. Only set
+	// for the first items of a request that could not be found.
+	NotFound *NotFoundDetail `protobuf:"bytes,5,opt,name=not_found,json=notFound,proto3" json:"not_found,omitempty"`
+}
+
+func (x *ResolveItemStatus) Reset() {
+	*x = ResolveItemStatus{}
+	mi := &file_tkd_pbtype_v1_descriptor_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResolveItemStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResolveItemStatus) ProtoMessage() {}
+
+func (x *ResolveItemStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_tkd_pbtype_v1_descriptor_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResolveItemStatus.ProtoReflect.Descriptor instead.
+func (*ResolveItemStatus) Descriptor() ([]byte, []int) {
+	return file_tkd_pbtype_v1_descriptor_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ResolveItemStatus) GetItem() *ResolveItem {
+	if x != nil {
+		return x.Item
+	}
+	return nil
+}
+
+func (x *ResolveItemStatus) GetFileName() string {
+	if x != nil {
+		return x.FileName
+	}
+	return ""
+}
+
+func (x *ResolveItemStatus) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *ResolveItemStatus) GetCode() int32 {
+	if x != nil {
+		return x.Code
+	}
+	return 0
+}
+
+func (x *ResolveItemStatus) GetNotFound() *NotFoundDetail {
+	if x != nil {
+		return x.NotFound
+	}
+	return nil
+}
+
+type BatchResolveResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Serialized google.protobuf.FileDescriptorProto messages of all
+	// resolved files, deduplicated and ordered by dependency so each file
+	// follows all of its dependencies.
+	FileDescriptorProtos [][]byte `protobuf:"bytes,1,rep,name=file_descriptor_protos,json=fileDescriptorProtos,proto3" json:"file_descriptor_protos,omitempty"`
+	// The status of each requested item, in request order.
+	Items []*ResolveItemStatus `protobuf:"bytes,2,rep,name=items,proto3" json:"items,omitempty"`
+	// The ID of the snapshot the request has been resolved from.
+	SnapshotId uint64 `protobuf:"varint,3,opt,name=snapshot_id,json=snapshotId,proto3" json:"snapshot_id,omitempty"`
+	// The hash of the snapshot the request has been resolved from.
+	SnapshotHash string `protobuf:"bytes,4,opt,name=snapshot_hash,json=snapshotHash,proto3" json:"snapshot_hash,omitempty"`
+	// The provenance of all returned files that have been provided by a
+	// source, in the order of file_descriptor_protos.
+	Provenance []*FileProvenance `protobuf:"bytes,5,rep,name=provenance,proto3" json:"provenance,omitempty"`
+}
+
+func (x *BatchResolveResponse) Reset() {
+	*x = BatchResolveResponse{}
+	mi := &file_tkd_pbtype_v1_descriptor_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchResolveResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchResolveResponse) ProtoMessage() {}
+
+func (x *BatchResolveResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tkd_pbtype_v1_descriptor_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchResolveResponse.ProtoReflect.Descriptor instead.
+func (*BatchResolveResponse) Descriptor() ([]byte, []int) {
+	return file_tkd_pbtype_v1_descriptor_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *BatchResolveResponse) GetFileDescriptorProtos() [][]byte {
+	if x != nil {
+		return x.FileDescriptorProtos
+	}
+	return nil
+}
+
+func (x *BatchResolveResponse) GetItems() []*ResolveItemStatus {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *BatchResolveResponse) GetSnapshotId() uint64 {
+	if x != nil {
+		return x.SnapshotId
+	}
+	return 0
+}
+
+func (x *BatchResolveResponse) GetSnapshotHash() string {
+	if x != nil {
+		return x.SnapshotHash
+	}
+	return ""
+}
+
+func (x *BatchResolveResponse) GetProvenance() []*FileProvenance {
+	if x != nil {
+		return x.Provenance
+	}
+	return nil
+}
+
+var File_tkd_pbtype_v1_descriptor_proto protoreflect.FileDescriptor
+
+var file_tkd_pbtype_v1_descriptor_proto_rawDesc = []byte{
+	0x0a, 0x1e, 0x74, 0x6b, 0x64, 0x2f, 0x70, 0x62, 0x74, 0x79, 0x70, 0x65, 0x2f, 0x76, 0x31, 0x2f,
+	0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x6f, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x12, 0x0d, 0x74, 0x6b, 0x64, 0x2e, 0x70, 0x62, 0x74, 0x79, 0x70, 0x65, 0x2e, 0x76, 0x31, 0x1a,
+	0x1d, 0x74, 0x6b, 0x64, 0x2f, 0x70, 0x62, 0x74, 0x79, 0x70, 0x65, 0x2f, 0x76, 0x31, 0x2f, 0x63,
+	0x68, 0x61, 0x6e, 0x67, 0x65, 0x6c, 0x6f, 0x67, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x18,
+	0x74, 0x6b, 0x64, 0x2f, 0x70, 0x62, 0x74, 0x79, 0x70, 0x65, 0x2f, 0x76, 0x31, 0x2f, 0x64, 0x69,
+	0x66, 0x66, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1a, 0x74, 0x6b, 0x64, 0x2f, 0x70, 0x62,
+	0x74, 0x79, 0x70, 0x65, 0x2f, 0x76, 0x31, 0x2f, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1e, 0x74, 0x6b, 0x64, 0x2f, 0x70, 0x62, 0x74, 0x79, 0x70, 0x65,
+	0x2f, 0x76, 0x31, 0x2f, 0x70, 0x72, 0x6f, 0x76, 0x65, 0x6e, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1a, 0x74, 0x6b, 0x64, 0x2f, 0x70, 0x62, 0x74, 0x79, 0x70, 0x65,
+	0x2f, 0x76, 0x31, 0x2f, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x1a, 0x19, 0x74, 0x6b, 0x64, 0x2f, 0x70, 0x62, 0x74, 0x79, 0x70, 0x65, 0x2f, 0x76, 0x31, 0x2f,
+	0x77, 0x61, 0x74, 0x63, 0x68, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x9e, 0x02, 0x0a, 0x0e,
+	0x52, 0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2a,
+	0x0a, 0x10, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x62, 0x79, 0x5f, 0x66, 0x69, 0x6c, 0x65, 0x6e, 0x61,
+	0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x0e, 0x66, 0x69, 0x6c, 0x65,
+	0x42, 0x79, 0x46, 0x69, 0x6c, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x36, 0x0a, 0x16, 0x66, 0x69,
+	0x6c, 0x65, 0x5f, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x69, 0x6e, 0x67, 0x5f, 0x73, 0x79,
+	0x6d, 0x62, 0x6f, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x14, 0x66, 0x69,
+	0x6c, 0x65, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x69, 0x6e, 0x67, 0x53, 0x79, 0x6d, 0x62,
+	0x6f, 0x6c, 0x12, 0x30, 0x0a, 0x13, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x63, 0x6f, 0x6e, 0x74, 0x61,
+	0x69, 0x6e, 0x69, 0x6e, 0x67, 0x5f, 0x75, 0x72, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x48,
+	0x00, 0x52, 0x11, 0x66, 0x69, 0x6c, 0x65, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x69, 0x6e,
+	0x67, 0x55, 0x72, 0x6c, 0x12, 0x31, 0x0a, 0x14, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x5f,
+	0x64, 0x65, 0x70, 0x65, 0x6e, 0x64, 0x65, 0x6e, 0x63, 0x69, 0x65, 0x73, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x13, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x44, 0x65, 0x70, 0x65, 0x6e,
+	0x64, 0x65, 0x6e, 0x63, 0x69, 0x65, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x6b, 0x6e, 0x6f, 0x77, 0x6e,
+	0x5f, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0a, 0x6b, 0x6e,
+	0x6f, 0x77, 0x6e, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x6e, 0x61, 0x70,
+	0x73, 0x68, 0x6f, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x73, 0x6e, 0x61, 0x70,
+	0x73, 0x68, 0x6f, 0x74, 0x42, 0x06, 0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x22, 0xcc, 0x01, 0x0a,
+	0x0f, 0x52, 0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x34, 0x0a, 0x16, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70,
+	0x74, 0x6f, 0x72, 0x5f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0c,
+	0x52, 0x14, 0x66, 0x69, 0x6c, 0x65, 0x44, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x6f, 0x72,
+	0x50, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x6e, 0x61, 0x70, 0x73, 0x68,
+	0x6f, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0a, 0x73, 0x6e, 0x61,
+	0x70, 0x73, 0x68, 0x6f, 0x74, 0x49, 0x64, 0x12, 0x23, 0x0a, 0x0d, 0x73, 0x6e, 0x61, 0x70, 0x73,
+	0x68, 0x6f, 0x74, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c,
+	0x73, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x48, 0x61, 0x73, 0x68, 0x12, 0x3d, 0x0a, 0x0a,
+	0x70, 0x72, 0x6f, 0x76, 0x65, 0x6e, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x1d, 0x2e, 0x74, 0x6b, 0x64, 0x2e, 0x70, 0x62, 0x74, 0x79, 0x70, 0x65, 0x2e, 0x76, 0x31,
+	0x2e, 0x46, 0x69, 0x6c, 0x65, 0x50, 0x72, 0x6f, 0x76, 0x65, 0x6e, 0x61, 0x6e, 0x63, 0x65, 0x52,
+	0x0a, 0x70, 0x72, 0x6f, 0x76, 0x65, 0x6e, 0x61, 0x6e, 0x63, 0x65, 0x22, 0xab, 0x01, 0x0a, 0x0b,
+	0x52, 0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x49, 0x74, 0x65, 0x6d, 0x12, 0x2a, 0x0a, 0x10, 0x66,
+	0x69, 0x6c, 0x65, 0x5f, 0x62, 0x79, 0x5f, 0x66, 0x69, 0x6c, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x0e, 0x66, 0x69, 0x6c, 0x65, 0x42, 0x79, 0x46,
+	0x69, 0x6c, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x36, 0x0a, 0x16, 0x66, 0x69, 0x6c, 0x65, 0x5f,
+	0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x69, 0x6e, 0x67, 0x5f, 0x73, 0x79, 0x6d, 0x62, 0x6f,
+	0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x14, 0x66, 0x69, 0x6c, 0x65, 0x43,
+	0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x69, 0x6e, 0x67, 0x53, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x12,
+	0x30, 0x0a, 0x13, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x69,
+	0x6e, 0x67, 0x5f, 0x75, 0x72, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x11,
+	0x66, 0x69, 0x6c, 0x65, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x69, 0x6e, 0x67, 0x55, 0x72,
+	0x6c, 0x42, 0x06, 0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x22, 0xb7, 0x01, 0x0a, 0x13, 0x42, 0x61,
+	0x74, 0x63, 0x68, 0x52, 0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x30, 0x0a, 0x05, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x1a, 0x2e, 0x74, 0x6b, 0x64, 0x2e, 0x70, 0x62, 0x74, 0x79, 0x70, 0x65, 0x2e, 0x76, 0x31,
+	0x2e, 0x52, 0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x49, 0x74, 0x65, 0x6d, 0x52, 0x05, 0x69, 0x74,
+	0x65, 0x6d, 0x73, 0x12, 0x31, 0x0a, 0x14, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x5f, 0x64,
+	0x65, 0x70, 0x65, 0x6e, 0x64, 0x65, 0x6e, 0x63, 0x69, 0x65, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x13, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x44, 0x65, 0x70, 0x65, 0x6e, 0x64,
+	0x65, 0x6e, 0x63, 0x69, 0x65, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x6b, 0x6e, 0x6f, 0x77, 0x6e, 0x5f,
+	0x66, 0x69, 0x6c, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0a, 0x6b, 0x6e, 0x6f,
+	0x77, 0x6e, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x6e, 0x61, 0x70, 0x73,
+	0x68, 0x6f, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x73, 0x6e, 0x61, 0x70, 0x73,
+	0x68, 0x6f, 0x74, 0x22, 0xc6, 0x01, 0x0a, 0x11, 0x52, 0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x49,
+	0x74, 0x65, 0x6d, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x2e, 0x0a, 0x04, 0x69, 0x74, 0x65,
+	0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x74, 0x6b, 0x64, 0x2e, 0x70, 0x62,
+	0x74, 0x79, 0x70, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x49,
+	0x74, 0x65, 0x6d, 0x52, 0x04, 0x69, 0x74, 0x65, 0x6d, 0x12, 0x1b, 0x0a, 0x09, 0x66, 0x69, 0x6c,
+	0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x66, 0x69,
+	0x6c, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x12, 0x0a, 0x04,
+	0x63, 0x6f, 0x64, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x63, 0x6f, 0x64, 0x65,
+	0x12, 0x3a, 0x0a, 0x09, 0x6e, 0x6f, 0x74, 0x5f, 0x66, 0x6f, 0x75, 0x6e, 0x64, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x74, 0x6b, 0x64, 0x2e, 0x70, 0x62, 0x74, 0x79, 0x70, 0x65,
+	0x2e, 0x76, 0x31, 0x2e, 0x4e, 0x6f, 0x74, 0x46, 0x6f, 0x75, 0x6e, 0x64, 0x44, 0x65, 0x74, 0x61,
+	0x69, 0x6c, 0x52, 0x08, 0x6e, 0x6f, 0x74, 0x46, 0x6f, 0x75, 0x6e, 0x64, 0x22, 0x89, 0x02, 0x0a,
+	0x14, 0x42, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x34, 0x0a, 0x16, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x64, 0x65,
+	0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x6f, 0x72, 0x5f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x14, 0x66, 0x69, 0x6c, 0x65, 0x44, 0x65, 0x73, 0x63, 0x72,
+	0x69, 0x70, 0x74, 0x6f, 0x72, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x12, 0x36, 0x0a, 0x05, 0x69,
+	0x74, 0x65, 0x6d, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x74, 0x6b, 0x64,
+	0x2e, 0x70, 0x62, 0x74, 0x79, 0x70, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x6c,
+	0x76, 0x65, 0x49, 0x74, 0x65, 0x6d, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x05, 0x69, 0x74,
+	0x65, 0x6d, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x5f,
+	0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0a, 0x73, 0x6e, 0x61, 0x70, 0x73, 0x68,
+	0x6f, 0x74, 0x49, 0x64, 0x12, 0x23, 0x0a, 0x0d, 0x73, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74,
+	0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x73, 0x6e, 0x61,
+	0x70, 0x73, 0x68, 0x6f, 0x74, 0x48, 0x61, 0x73, 0x68, 0x12, 0x3d, 0x0a, 0x0a, 0x70, 0x72, 0x6f,
+	0x76, 0x65, 0x6e, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1d, 0x2e,
+	0x74, 0x6b, 0x64, 0x2e, 0x70, 0x62, 0x74, 0x79, 0x70, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x46, 0x69,
+	0x6c, 0x65, 0x50, 0x72, 0x6f, 0x76, 0x65, 0x6e, 0x61, 0x6e, 0x63, 0x65, 0x52, 0x0a, 0x70, 0x72,
+	0x6f, 0x76, 0x65, 0x6e, 0x61, 0x6e, 0x63, 0x65, 0x32, 0xa1, 0x05, 0x0a, 0x11, 0x44, 0x65, 0x73,
+	0x63, 0x72, 0x69, 0x70, 0x74, 0x6f, 0x72, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x48,
+	0x0a, 0x07, 0x52, 0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x12, 0x1d, 0x2e, 0x74, 0x6b, 0x64, 0x2e,
+	0x70, 0x62, 0x74, 0x79, 0x70, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x6c, 0x76,
+	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x74, 0x6b, 0x64, 0x2e, 0x70,
+	0x62, 0x74, 0x79, 0x70, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x57, 0x0a, 0x0c, 0x42, 0x61, 0x74, 0x63,
+	0x68, 0x52, 0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x12, 0x22, 0x2e, 0x74, 0x6b, 0x64, 0x2e, 0x70,
+	0x62, 0x74, 0x79, 0x70, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65,
+	0x73, 0x6f, 0x6c, 0x76, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x23, 0x2e, 0x74,
+	0x6b, 0x64, 0x2e, 0x70, 0x62, 0x74, 0x79, 0x70, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x61, 0x74,
+	0x63, 0x68, 0x52, 0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x57, 0x0a, 0x0c, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65,
+	0x73, 0x12, 0x22, 0x2e, 0x74, 0x6b, 0x64, 0x2e, 0x70, 0x62, 0x74, 0x79, 0x70, 0x65, 0x2e, 0x76,
+	0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x23, 0x2e, 0x74, 0x6b, 0x64, 0x2e, 0x70, 0x62, 0x74, 0x79,
+	0x70, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67,
+	0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x54, 0x0a, 0x0b, 0x4c, 0x69,
+	0x73, 0x74, 0x53, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x73, 0x12, 0x21, 0x2e, 0x74, 0x6b, 0x64, 0x2e,
+	0x70, 0x62, 0x74, 0x79, 0x70, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x79,
+	0x6d, 0x62, 0x6f, 0x6c, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x22, 0x2e, 0x74,
+	0x6b, 0x64, 0x2e, 0x70, 0x62, 0x74, 0x79, 0x70, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73,
+	0x74, 0x53, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x44, 0x0a, 0x05, 0x57, 0x61, 0x74, 0x63, 0x68, 0x12, 0x1b, 0x2e, 0x74, 0x6b, 0x64, 0x2e,
+	0x70, 0x62, 0x74, 0x79, 0x70, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x57, 0x61, 0x74, 0x63, 0x68, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x74, 0x6b, 0x64, 0x2e, 0x70, 0x62, 0x74,
+	0x79, 0x70, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x57, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12, 0x3f, 0x0a, 0x04, 0x44, 0x69, 0x66, 0x66, 0x12, 0x1a,
+	0x2e, 0x74, 0x6b, 0x64, 0x2e, 0x70, 0x62, 0x74, 0x79, 0x70, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x44,
+	0x69, 0x66, 0x66, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x74, 0x6b, 0x64,
+	0x2e, 0x70, 0x62, 0x74, 0x79, 0x70, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x69, 0x66, 0x66, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x57, 0x0a, 0x0c, 0x47, 0x65, 0x74, 0x43, 0x68,
+	0x61, 0x6e, 0x67, 0x65, 0x6c, 0x6f, 0x67, 0x12, 0x22, 0x2e, 0x74, 0x6b, 0x64, 0x2e, 0x70, 0x62,
+	0x74, 0x79, 0x70, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x43, 0x68, 0x61, 0x6e, 0x67,
+	0x65, 0x6c, 0x6f, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x23, 0x2e, 0x74, 0x6b,
+	0x64, 0x2e, 0x70, 0x62, 0x74, 0x79, 0x70, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x43,
+	0x68, 0x61, 0x6e, 0x67, 0x65, 0x6c, 0x6f, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x5a, 0x0a, 0x0d, 0x47, 0x65, 0x74, 0x50, 0x72, 0x6f, 0x76, 0x65, 0x6e, 0x61, 0x6e, 0x63,
+	0x65, 0x12, 0x23, 0x2e, 0x74, 0x6b, 0x64, 0x2e, 0x70, 0x62, 0x74, 0x79, 0x70, 0x65, 0x2e, 0x76,
+	0x31, 0x2e, 0x47, 0x65, 0x74, 0x50, 0x72, 0x6f, 0x76, 0x65, 0x6e, 0x61, 0x6e, 0x63, 0x65, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x24, 0x2e, 0x74, 0x6b, 0x64, 0x2e, 0x70, 0x62, 0x74,
+	0x79, 0x70, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x50, 0x72, 0x6f, 0x76, 0x65, 0x6e,
+	0x61, 0x6e, 0x63, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x4e, 0x5a, 0x4c,
+	0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x74, 0x69, 0x65, 0x72, 0x6b,
+	0x6c, 0x69, 0x6e, 0x69, 0x6b, 0x2d, 0x64, 0x6f, 0x62, 0x65, 0x72, 0x73, 0x62, 0x65, 0x72, 0x67,
+	0x2f, 0x70, 0x62, 0x74, 0x79, 0x70, 0x65, 0x2d, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2f, 0x67,
+	0x65, 0x6e, 0x2f, 0x67, 0x6f, 0x2f, 0x74, 0x6b, 0x64, 0x2f, 0x70, 0x62, 0x74, 0x79, 0x70, 0x65,
+	0x2f, 0x76, 0x31, 0x3b, 0x70, 0x62, 0x74, 0x79, 0x70, 0x65, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_tkd_pbtype_v1_descriptor_proto_rawDescOnce sync.Once
+	file_tkd_pbtype_v1_descriptor_proto_rawDescData = file_tkd_pbtype_v1_descriptor_proto_rawDesc
+)
+
+func file_tkd_pbtype_v1_descriptor_proto_rawDescGZIP() []byte {
+	file_tkd_pbtype_v1_descriptor_proto_rawDescOnce.Do(func() {
+		file_tkd_pbtype_v1_descriptor_proto_rawDescData = protoimpl.X.CompressGZIP(file_tkd_pbtype_v1_descriptor_proto_rawDescData)
+	})
+	return file_tkd_pbtype_v1_descriptor_proto_rawDescData
+}
+
+var file_tkd_pbtype_v1_descriptor_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_tkd_pbtype_v1_descriptor_proto_goTypes = []any{
+	(*ResolveRequest)(nil),        // 0: tkd.pbtype.v1.ResolveRequest
+	(*ResolveResponse)(nil),       // 1: tkd.pbtype.v1.ResolveResponse
+	(*ResolveItem)(nil),           // 2: tkd.pbtype.v1.ResolveItem
+	(*BatchResolveRequest)(nil),   // 3: tkd.pbtype.v1.BatchResolveRequest
+	(*ResolveItemStatus)(nil),     // 4: tkd.pbtype.v1.ResolveItemStatus
+	(*BatchResolveResponse)(nil),  // 5: tkd.pbtype.v1.BatchResolveResponse
+	(*FileProvenance)(nil),        // 6: tkd.pbtype.v1.FileProvenance
+	(*NotFoundDetail)(nil),        // 7: tkd.pbtype.v1.NotFoundDetail
+	(*ListPackagesRequest)(nil),   // 8: tkd.pbtype.v1.ListPackagesRequest
+	(*ListSymbolsRequest)(nil),    // 9: tkd.pbtype.v1.ListSymbolsRequest
+	(*WatchRequest)(nil),          // 10: tkd.pbtype.v1.WatchRequest
+	(*DiffRequest)(nil),           // 11: tkd.pbtype.v1.DiffRequest
+	(*GetChangelogRequest)(nil),   // 12: tkd.pbtype.v1.GetChangelogRequest
+	(*GetProvenanceRequest)(nil),  // 13: tkd.pbtype.v1.GetProvenanceRequest
+	(*ListPackagesResponse)(nil),  // 14: tkd.pbtype.v1.ListPackagesResponse
+	(*ListSymbolsResponse)(nil),   // 15: tkd.pbtype.v1.ListSymbolsResponse
+	(*WatchResponse)(nil),         // 16: tkd.pbtype.v1.WatchResponse
+	(*DiffResponse)(nil),          // 17: tkd.pbtype.v1.DiffResponse
+	(*GetChangelogResponse)(nil),  // 18: tkd.pbtype.v1.GetChangelogResponse
+	(*GetProvenanceResponse)(nil), // 19: tkd.pbtype.v1.GetProvenanceResponse
+}
+var file_tkd_pbtype_v1_descriptor_proto_depIdxs = []int32{
+	6,  // 0: tkd.pbtype.v1.ResolveResponse.provenance:type_name -> tkd.pbtype.v1.FileProvenance
+	2,  // 1: tkd.pbtype.v1.BatchResolveRequest.items:type_name -> tkd.pbtype.v1.ResolveItem
+	2,  // 2: tkd.pbtype.v1.ResolveItemStatus.item:type_name -> tkd.pbtype.v1.ResolveItem
+	7,  // 3: tkd.pbtype.v1.ResolveItemStatus.not_found:type_name -> tkd.pbtype.v1.NotFoundDetail
+	4,  // 4: tkd.pbtype.v1.BatchResolveResponse.items:type_name -> tkd.pbtype.v1.ResolveItemStatus
+	6,  // 5: tkd.pbtype.v1.BatchResolveResponse.provenance:type_name -> tkd.pbtype.v1.FileProvenance
+	0,  // 6: tkd.pbtype.v1.DescriptorService.Resolve:input_type -> tkd.pbtype.v1.ResolveRequest
+	3,  // 7: tkd.pbtype.v1.DescriptorService.BatchResolve:input_type -> tkd.pbtype.v1.BatchResolveRequest
+	8,  // 8: tkd.pbtype.v1.DescriptorService.ListPackages:input_type -> tkd.pbtype.v1.ListPackagesRequest
+	9,  // 9: tkd.pbtype.v1.DescriptorService.ListSymbols:input_type -> tkd.pbtype.v1.ListSymbolsRequest
+	10, // 10: tkd.pbtype.v1.DescriptorService.Watch:input_type -> tkd.pbtype.v1.WatchRequest
+	11, // 11: tkd.pbtype.v1.DescriptorService.Diff:input_type -> tkd.pbtype.v1.DiffRequest
+	12, // 12: tkd.pbtype.v1.DescriptorService.GetChangelog:input_type -> tkd.pbtype.v1.GetChangelogRequest
+	13, // 13: tkd.pbtype.v1.DescriptorService.GetProvenance:input_type -> tkd.pbtype.v1.GetProvenanceRequest
+	1,  // 14: tkd.pbtype.v1.DescriptorService.Resolve:output_type -> tkd.pbtype.v1.ResolveResponse
+	5,  // 15: tkd.pbtype.v1.DescriptorService.BatchResolve:output_type -> tkd.pbtype.v1.BatchResolveResponse
+	14, // 16: tkd.pbtype.v1.DescriptorService.ListPackages:output_type -> tkd.pbtype.v1.ListPackagesResponse
+	15, // 17: tkd.pbtype.v1.DescriptorService.ListSymbols:output_type -> tkd.pbtype.v1.ListSymbolsResponse
+	16, // 18: tkd.pbtype.v1.DescriptorService.Watch:output_type -> tkd.pbtype.v1.WatchResponse
+	17, // 19: tkd.pbtype.v1.DescriptorService.Diff:output_type -> tkd.pbtype.v1.DiffResponse
+	18, // 20: tkd.pbtype.v1.DescriptorService.GetChangelog:output_type -> tkd.pbtype.v1.GetChangelogResponse
+	19, // 21: tkd.pbtype.v1.DescriptorService.GetProvenance:output_type -> tkd.pbtype.v1.GetProvenanceResponse
+	14, // [14:22] is the sub-list for method output_type
+	6,  // [6:14] is the sub-list for method input_type
+	6,  // [6:6] is the sub-list for extension type_name
+	6,  // [6:6] is the sub-list for extension extendee
+	0,  // [0:6] is the sub-list for field type_name
+}
+
+func init() { file_tkd_pbtype_v1_descriptor_proto_init() }
+func file_tkd_pbtype_v1_descriptor_proto_init() {
+	if File_tkd_pbtype_v1_descriptor_proto != nil {
+		return
+	}
+	file_tkd_pbtype_v1_changelog_proto_init()
+	file_tkd_pbtype_v1_diff_proto_init()
+	file_tkd_pbtype_v1_errors_proto_init()
+	file_tkd_pbtype_v1_provenance_proto_init()
+	file_tkd_pbtype_v1_search_proto_init()
+	file_tkd_pbtype_v1_watch_proto_init()
+	file_tkd_pbtype_v1_descriptor_proto_msgTypes[0].OneofWrappers = []any{
+		(*ResolveRequest_FileByFilename)(nil),
+		(*ResolveRequest_FileContainingSymbol)(nil),
+		(*ResolveRequest_FileContainingUrl)(nil),
+	}
+	file_tkd_pbtype_v1_descriptor_proto_msgTypes[2].OneofWrappers = []any{
+		(*ResolveItem_FileByFilename)(nil),
+		(*ResolveItem_FileContainingSymbol)(nil),
+		(*ResolveItem_FileContainingUrl)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_tkd_pbtype_v1_descriptor_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   6,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_tkd_pbtype_v1_descriptor_proto_goTypes,
+		DependencyIndexes: file_tkd_pbtype_v1_descriptor_proto_depIdxs,
+		MessageInfos:      file_tkd_pbtype_v1_descriptor_proto_msgTypes,
+	}.Build()
+	File_tkd_pbtype_v1_descriptor_proto = out.File
+	file_tkd_pbtype_v1_descriptor_proto_rawDesc = nil
+	file_tkd_pbtype_v1_descriptor_proto_goTypes = nil
+	file_tkd_pbtype_v1_descriptor_proto_depIdxs = nil
+}
@@ -0,0 +1,613 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.35.1
+// 	protoc        (unknown)
+// source: tkd/pbtype/v1/diff.proto
+
+package pbtypev1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ElementChangeType int32
+
+const (
+	ElementChangeType_ELEMENT_CHANGE_TYPE_UNSPECIFIED ElementChangeType = 0
+	ElementChangeType_ELEMENT_CHANGE_TYPE_ADDED       ElementChangeType = 1
+	ElementChangeType_ELEMENT_CHANGE_TYPE_REMOVED     ElementChangeType = 2
+	ElementChangeType_ELEMENT_CHANGE_TYPE_CHANGED     ElementChangeType = 3
+)
+
+// Enum value maps for ElementChangeType.
+var (
+	ElementChangeType_name = map[int32]string{
+		0: "ELEMENT_CHANGE_TYPE_UNSPECIFIED",
+		1: "ELEMENT_CHANGE_TYPE_ADDED",
+		2: "ELEMENT_CHANGE_TYPE_REMOVED",
+		3: "ELEMENT_CHANGE_TYPE_CHANGED",
+	}
+	ElementChangeType_value = map[string]int32{
+		"ELEMENT_CHANGE_TYPE_UNSPECIFIED": 0,
+		"ELEMENT_CHANGE_TYPE_ADDED":       1,
+		"ELEMENT_CHANGE_TYPE_REMOVED":     2,
+		"ELEMENT_CHANGE_TYPE_CHANGED":     3,
+	}
+)
+
+func (x ElementChangeType) Enum() *ElementChangeType {
+	p := new(ElementChangeType)
+	*p = x
+	return p
+}
+
+func (x ElementChangeType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ElementChangeType) Descriptor() protoreflect.EnumDescriptor {
+	return file_tkd_pbtype_v1_diff_proto_enumTypes[0].Descriptor()
+}
+
+func (ElementChangeType) Type() protoreflect.EnumType {
+	return &file_tkd_pbtype_v1_diff_proto_enumTypes[0]
+}
+
+func (x ElementChangeType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ElementChangeType.Descriptor instead.
+func (ElementChangeType) EnumDescriptor() ([]byte, []int) {
+	return file_tkd_pbtype_v1_diff_proto_rawDescGZIP(), []int{0}
+}
+
+type ElementType int32
+
+const (
+	ElementType_ELEMENT_TYPE_UNSPECIFIED ElementType = 0
+	ElementType_ELEMENT_TYPE_FILE        ElementType = 1
+	ElementType_ELEMENT_TYPE_MESSAGE     ElementType = 2
+	ElementType_ELEMENT_TYPE_FIELD       ElementType = 3
+	ElementType_ELEMENT_TYPE_ENUM        ElementType = 4
+	ElementType_ELEMENT_TYPE_ENUM_VALUE  ElementType = 5
+	ElementType_ELEMENT_TYPE_SERVICE     ElementType = 6
+	ElementType_ELEMENT_TYPE_METHOD      ElementType = 7
+	ElementType_ELEMENT_TYPE_EXTENSION   ElementType = 8
+)
+
+// Enum value maps for ElementType.
+var (
+	ElementType_name = map[int32]string{
+		0: "ELEMENT_TYPE_UNSPECIFIED",
+		1: "ELEMENT_TYPE_FILE",
+		2: "ELEMENT_TYPE_MESSAGE",
+		3: "ELEMENT_TYPE_FIELD",
+		4: "ELEMENT_TYPE_ENUM",
+		5: "ELEMENT_TYPE_ENUM_VALUE",
+		6: "ELEMENT_TYPE_SERVICE",
+		7: "ELEMENT_TYPE_METHOD",
+		8: "ELEMENT_TYPE_EXTENSION",
+	}
+	ElementType_value = map[string]int32{
+		"ELEMENT_TYPE_UNSPECIFIED": 0,
+		"ELEMENT_TYPE_FILE":        1,
+		"ELEMENT_TYPE_MESSAGE":     2,
+		"ELEMENT_TYPE_FIELD":       3,
+		"ELEMENT_TYPE_ENUM":        4,
+		"ELEMENT_TYPE_ENUM_VALUE":  5,
+		"ELEMENT_TYPE_SERVICE":     6,
+		"ELEMENT_TYPE_METHOD":      7,
+		"ELEMENT_TYPE_EXTENSION":   8,
+	}
+)
+
+func (x ElementType) Enum() *ElementType {
+	p := new(ElementType)
+	*p = x
+	return p
+}
+
+func (x ElementType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ElementType) Descriptor() protoreflect.EnumDescriptor {
+	return file_tkd_pbtype_v1_diff_proto_enumTypes[1].Descriptor()
+}
+
+func (ElementType) Type() protoreflect.EnumType {
+	return &file_tkd_pbtype_v1_diff_proto_enumTypes[1]
+}
+
+func (x ElementType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ElementType.Descriptor instead.
+func (ElementType) EnumDescriptor() ([]byte, []int) {
+	return file_tkd_pbtype_v1_diff_proto_rawDescGZIP(), []int{1}
+}
+
+// PropertyChange describes a changed property of an element, like the
+// type of a field or the options of a message.
+type PropertyChange struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name     string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	OldValue string `protobuf:"bytes,2,opt,name=old_value,json=oldValue,proto3" json:"old_value,omitempty"`
+	NewValue string `protobuf:"bytes,3,opt,name=new_value,json=newValue,proto3" json:"new_value,omitempty"`
+}
+
+func (x *PropertyChange) Reset() {
+	*x = PropertyChange{}
+	mi := &file_tkd_pbtype_v1_diff_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PropertyChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PropertyChange) ProtoMessage() {}
+
+func (x *PropertyChange) ProtoReflect() protoreflect.Message {
+	mi := &file_tkd_pbtype_v1_diff_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PropertyChange.ProtoReflect.Descriptor instead.
+func (*PropertyChange) Descriptor() ([]byte, []int) {
+	return file_tkd_pbtype_v1_diff_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *PropertyChange) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *PropertyChange) GetOldValue() string {
+	if x != nil {
+		return x.OldValue
+	}
+	return ""
+}
+
+func (x *PropertyChange) GetNewValue() string {
+	if x != nil {
+		return x.NewValue
+	}
+	return ""
+}
+
+// DiffEntry describes a single element that differs.
+type DiffEntry struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Change  ElementChangeType `protobuf:"varint,1,opt,name=change,proto3,enum=tkd.pbtype.v1.ElementChangeType" json:"change,omitempty"`
+	Element ElementType       `protobuf:"varint,2,opt,name=element,proto3,enum=tkd.pbtype.v1.ElementType" json:"element,omitempty"`
+	// The fully-qualified name of the element or the name of a file.
+	Name string `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	// The name of the file that declares the element.
+	FileName string `protobuf:"bytes,4,opt,name=file_name,json=fileName,proto3" json:"file_name,omitempty"`
+	// The properties that differ if the element has been changed.
+	Properties []*PropertyChange `protobuf:"bytes,5,rep,name=properties,proto3" json:"properties,omitempty"`
+	// The package of the file that declares the element.
+	Package string `protobuf:"bytes,6,opt,name=package,proto3" json:"package,omitempty"`
+}
+
+func (x *DiffEntry) Reset() {
+	*x = DiffEntry{}
+	mi := &file_tkd_pbtype_v1_diff_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DiffEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DiffEntry) ProtoMessage() {}
+
+func (x *DiffEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_tkd_pbtype_v1_diff_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DiffEntry.ProtoReflect.Descriptor instead.
+func (*DiffEntry) Descriptor() ([]byte, []int) {
+	return file_tkd_pbtype_v1_diff_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *DiffEntry) GetChange() ElementChangeType {
+	if x != nil {
+		return x.Change
+	}
+	return ElementChangeType_ELEMENT_CHANGE_TYPE_UNSPECIFIED
+}
+
+func (x *DiffEntry) GetElement() ElementType {
+	if x != nil {
+		return x.Element
+	}
+	return ElementType_ELEMENT_TYPE_UNSPECIFIED
+}
+
+func (x *DiffEntry) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *DiffEntry) GetFileName() string {
+	if x != nil {
+		return x.FileName
+	}
+	return ""
+}
+
+func (x *DiffEntry) GetProperties() []*PropertyChange {
+	if x != nil {
+		return x.Properties
+	}
+	return nil
+}
+
+func (x *DiffEntry) GetPackage() string {
+	if x != nil {
+		return x.Package
+	}
+	return ""
+}
+
+type DiffRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The snapshot to compare, identified by its ID or a unique prefix of
+	// its hash. If empty, the current snapshot is used.
+	FromSnapshot string `protobuf:"bytes,1,opt,name=from_snapshot,json=fromSnapshot,proto3" json:"from_snapshot,omitempty"`
+	// Types that are assignable to To:
+	//	*DiffRequest_ToSnapshot
+	//	*DiffRequest_FileDescriptorSet
+	To isDiffRequest_To `protobuf_oneof:"to"`
+}
+
+func (x *DiffRequest) Reset() {
+	*x = DiffRequest{}
+	mi := &file_tkd_pbtype_v1_diff_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DiffRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DiffRequest) ProtoMessage() {}
+
+func (x *DiffRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tkd_pbtype_v1_diff_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DiffRequest.ProtoReflect.Descriptor instead.
+func (*DiffRequest) Descriptor() ([]byte, []int) {
+	return file_tkd_pbtype_v1_diff_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *DiffRequest) GetFromSnapshot() string {
+	if x != nil {
+		return x.FromSnapshot
+	}
+	return ""
+}
+
+func (m *DiffRequest) GetTo() isDiffRequest_To {
+	if m != nil {
+		return m.To
+	}
+	return nil
+}
+
+func (x *DiffRequest) GetToSnapshot() string {
+	if x, ok := x.GetTo().(*DiffRequest_ToSnapshot); ok {
+		return x.ToSnapshot
+	}
+	return ""
+}
+
+func (x *DiffRequest) GetFileDescriptorSet() []byte {
+	if x, ok := x.GetTo().(*DiffRequest_FileDescriptorSet); ok {
+		return x.FileDescriptorSet
+	}
+	return nil
+}
+
+type isDiffRequest_To interface {
+	isDiffRequest_To()
+}
+
+type DiffRequest_ToSnapshot struct {
+	// The snapshot to compare against.
+	ToSnapshot string `protobuf:"bytes,2,opt,name=to_snapshot,json=toSnapshot,proto3,oneof"`
+}
+
+type DiffRequest_FileDescriptorSet struct {
+	// A serialized google.protobuf.FileDescriptorSet to compare
+	// against. Imports are resolved using from_snapshot and only files
+	// contained in the set are compared.
+	FileDescriptorSet []byte `protobuf:"bytes,3,opt,name=file_descriptor_set,json=fileDescriptorSet,proto3,oneof"`
+}
+
+func (*DiffRequest_ToSnapshot) isDiffRequest_To() {}
+
+func (*DiffRequest_FileDescriptorSet) isDiffRequest_To() {}
+
+type DiffResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	FromSnapshotId   uint64 `protobuf:"varint,1,opt,name=from_snapshot_id,json=fromSnapshotId,proto3" json:"from_snapshot_id,omitempty"`
+	FromSnapshotHash string `protobuf:"bytes,2,opt,name=from_snapshot_hash,json=fromSnapshotHash,proto3" json:"from_snapshot_hash,omitempty"`
+	// Not set if the request contained a file descriptor set.
+	ToSnapshotId   uint64 `protobuf:"varint,3,opt,name=to_snapshot_id,json=toSnapshotId,proto3" json:"to_snapshot_id,omitempty"`
+	ToSnapshotHash string `protobuf:"bytes,4,opt,name=to_snapshot_hash,json=toSnapshotHash,proto3" json:"to_snapshot_hash,omitempty"`
+	// All elements that differ, ordered by name.
+	Entries []*DiffEntry `protobuf:"bytes,5,rep,name=entries,proto3" json:"entries,omitempty"`
+}
+
+func (x *DiffResponse) Reset() {
+	*x = DiffResponse{}
+	mi := &file_tkd_pbtype_v1_diff_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DiffResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DiffResponse) ProtoMessage() {}
+
+func (x *DiffResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tkd_pbtype_v1_diff_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DiffResponse.ProtoReflect.Descriptor instead.
+func (*DiffResponse) Descriptor() ([]byte, []int) {
+	return file_tkd_pbtype_v1_diff_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *DiffResponse) GetFromSnapshotId() uint64 {
+	if x != nil {
+		return x.FromSnapshotId
+	}
+	return 0
+}
+
+func (x *DiffResponse) GetFromSnapshotHash() string {
+	if x != nil {
+		return x.FromSnapshotHash
+	}
+	return ""
+}
+
+func (x *DiffResponse) GetToSnapshotId() uint64 {
+	if x != nil {
+		return x.ToSnapshotId
+	}
+	return 0
+}
+
+func (x *DiffResponse) GetToSnapshotHash() string {
+	if x != nil {
+		return x.ToSnapshotHash
+	}
+	return ""
+}
+
+func (x *DiffResponse) GetEntries() []*DiffEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+var File_tkd_pbtype_v1_diff_proto protoreflect.FileDescriptor
+
+var file_tkd_pbtype_v1_diff_proto_rawDesc = []byte{
+	0x0a, 0x18, 0x74, 0x6b, 0x64, 0x2f, 0x70, 0x62, 0x74, 0x79, 0x70, 0x65, 0x2f, 0x76, 0x31, 0x2f,
+	0x64, 0x69, 0x66, 0x66, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0d, 0x74, 0x6b, 0x64, 0x2e,
+	0x70, 0x62, 0x74, 0x79, 0x70, 0x65, 0x2e, 0x76, 0x31, 0x22, 0x5e, 0x0a, 0x0e, 0x50, 0x72, 0x6f,
+	0x70, 0x65, 0x72, 0x74, 0x79, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12,
+	0x1b, 0x0a, 0x09, 0x6f, 0x6c, 0x64, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x08, 0x6f, 0x6c, 0x64, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x1b, 0x0a, 0x09,
+	0x6e, 0x65, 0x77, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x08, 0x6e, 0x65, 0x77, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x85, 0x02, 0x0a, 0x09, 0x44, 0x69,
+	0x66, 0x66, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x38, 0x0a, 0x06, 0x63, 0x68, 0x61, 0x6e, 0x67,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x20, 0x2e, 0x74, 0x6b, 0x64, 0x2e, 0x70, 0x62,
+	0x74, 0x79, 0x70, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x6c, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x43,
+	0x68, 0x61, 0x6e, 0x67, 0x65, 0x54, 0x79, 0x70, 0x65, 0x52, 0x06, 0x63, 0x68, 0x61, 0x6e, 0x67,
+	0x65, 0x12, 0x34, 0x0a, 0x07, 0x65, 0x6c, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0e, 0x32, 0x1a, 0x2e, 0x74, 0x6b, 0x64, 0x2e, 0x70, 0x62, 0x74, 0x79, 0x70, 0x65, 0x2e,
+	0x76, 0x31, 0x2e, 0x45, 0x6c, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x52, 0x07,
+	0x65, 0x6c, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x66,
+	0x69, 0x6c, 0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
+	0x66, 0x69, 0x6c, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x3d, 0x0a, 0x0a, 0x70, 0x72, 0x6f, 0x70,
+	0x65, 0x72, 0x74, 0x69, 0x65, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x74,
+	0x6b, 0x64, 0x2e, 0x70, 0x62, 0x74, 0x79, 0x70, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x72, 0x6f,
+	0x70, 0x65, 0x72, 0x74, 0x79, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x0a, 0x70, 0x72, 0x6f,
+	0x70, 0x65, 0x72, 0x74, 0x69, 0x65, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x61, 0x63, 0x6b, 0x61,
+	0x67, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67,
+	0x65, 0x22, 0x8d, 0x01, 0x0a, 0x0b, 0x44, 0x69, 0x66, 0x66, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x23, 0x0a, 0x0d, 0x66, 0x72, 0x6f, 0x6d, 0x5f, 0x73, 0x6e, 0x61, 0x70, 0x73, 0x68,
+	0x6f, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x66, 0x72, 0x6f, 0x6d, 0x53, 0x6e,
+	0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x12, 0x21, 0x0a, 0x0b, 0x74, 0x6f, 0x5f, 0x73, 0x6e, 0x61,
+	0x70, 0x73, 0x68, 0x6f, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x0a, 0x74,
+	0x6f, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x12, 0x30, 0x0a, 0x13, 0x66, 0x69, 0x6c,
+	0x65, 0x5f, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x6f, 0x72, 0x5f, 0x73, 0x65, 0x74,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x48, 0x00, 0x52, 0x11, 0x66, 0x69, 0x6c, 0x65, 0x44, 0x65,
+	0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x6f, 0x72, 0x53, 0x65, 0x74, 0x42, 0x04, 0x0a, 0x02, 0x74,
+	0x6f, 0x22, 0xea, 0x01, 0x0a, 0x0c, 0x44, 0x69, 0x66, 0x66, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x28, 0x0a, 0x10, 0x66, 0x72, 0x6f, 0x6d, 0x5f, 0x73, 0x6e, 0x61, 0x70, 0x73,
+	0x68, 0x6f, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0e, 0x66, 0x72,
+	0x6f, 0x6d, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x49, 0x64, 0x12, 0x2c, 0x0a, 0x12,
+	0x66, 0x72, 0x6f, 0x6d, 0x5f, 0x73, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x5f, 0x68, 0x61,
+	0x73, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x66, 0x72, 0x6f, 0x6d, 0x53, 0x6e,
+	0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x48, 0x61, 0x73, 0x68, 0x12, 0x24, 0x0a, 0x0e, 0x74, 0x6f,
+	0x5f, 0x73, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x0c, 0x74, 0x6f, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x49, 0x64,
+	0x12, 0x28, 0x0a, 0x10, 0x74, 0x6f, 0x5f, 0x73, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x5f,
+	0x68, 0x61, 0x73, 0x68, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x74, 0x6f, 0x53, 0x6e,
+	0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x48, 0x61, 0x73, 0x68, 0x12, 0x32, 0x0a, 0x07, 0x65, 0x6e,
+	0x74, 0x72, 0x69, 0x65, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x74, 0x6b,
+	0x64, 0x2e, 0x70, 0x62, 0x74, 0x79, 0x70, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x69, 0x66, 0x66,
+	0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x07, 0x65, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x2a, 0x99,
+	0x01, 0x0a, 0x11, 0x45, 0x6c, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65,
+	0x54, 0x79, 0x70, 0x65, 0x12, 0x23, 0x0a, 0x1f, 0x45, 0x4c, 0x45, 0x4d, 0x45, 0x4e, 0x54, 0x5f,
+	0x43, 0x48, 0x41, 0x4e, 0x47, 0x45, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50,
+	0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x1d, 0x0a, 0x19, 0x45, 0x4c, 0x45,
+	0x4d, 0x45, 0x4e, 0x54, 0x5f, 0x43, 0x48, 0x41, 0x4e, 0x47, 0x45, 0x5f, 0x54, 0x59, 0x50, 0x45,
+	0x5f, 0x41, 0x44, 0x44, 0x45, 0x44, 0x10, 0x01, 0x12, 0x1f, 0x0a, 0x1b, 0x45, 0x4c, 0x45, 0x4d,
+	0x45, 0x4e, 0x54, 0x5f, 0x43, 0x48, 0x41, 0x4e, 0x47, 0x45, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f,
+	0x52, 0x45, 0x4d, 0x4f, 0x56, 0x45, 0x44, 0x10, 0x02, 0x12, 0x1f, 0x0a, 0x1b, 0x45, 0x4c, 0x45,
+	0x4d, 0x45, 0x4e, 0x54, 0x5f, 0x43, 0x48, 0x41, 0x4e, 0x47, 0x45, 0x5f, 0x54, 0x59, 0x50, 0x45,
+	0x5f, 0x43, 0x48, 0x41, 0x4e, 0x47, 0x45, 0x44, 0x10, 0x03, 0x2a, 0xf7, 0x01, 0x0a, 0x0b, 0x45,
+	0x6c, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1c, 0x0a, 0x18, 0x45, 0x4c,
+	0x45, 0x4d, 0x45, 0x4e, 0x54, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45,
+	0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x15, 0x0a, 0x11, 0x45, 0x4c, 0x45, 0x4d,
+	0x45, 0x4e, 0x54, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x46, 0x49, 0x4c, 0x45, 0x10, 0x01, 0x12,
+	0x18, 0x0a, 0x14, 0x45, 0x4c, 0x45, 0x4d, 0x45, 0x4e, 0x54, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f,
+	0x4d, 0x45, 0x53, 0x53, 0x41, 0x47, 0x45, 0x10, 0x02, 0x12, 0x16, 0x0a, 0x12, 0x45, 0x4c, 0x45,
+	0x4d, 0x45, 0x4e, 0x54, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x46, 0x49, 0x45, 0x4c, 0x44, 0x10,
+	0x03, 0x12, 0x15, 0x0a, 0x11, 0x45, 0x4c, 0x45, 0x4d, 0x45, 0x4e, 0x54, 0x5f, 0x54, 0x59, 0x50,
+	0x45, 0x5f, 0x45, 0x4e, 0x55, 0x4d, 0x10, 0x04, 0x12, 0x1b, 0x0a, 0x17, 0x45, 0x4c, 0x45, 0x4d,
+	0x45, 0x4e, 0x54, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x45, 0x4e, 0x55, 0x4d, 0x5f, 0x56, 0x41,
+	0x4c, 0x55, 0x45, 0x10, 0x05, 0x12, 0x18, 0x0a, 0x14, 0x45, 0x4c, 0x45, 0x4d, 0x45, 0x4e, 0x54,
+	0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x53, 0x45, 0x52, 0x56, 0x49, 0x43, 0x45, 0x10, 0x06, 0x12,
+	0x17, 0x0a, 0x13, 0x45, 0x4c, 0x45, 0x4d, 0x45, 0x4e, 0x54, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f,
+	0x4d, 0x45, 0x54, 0x48, 0x4f, 0x44, 0x10, 0x07, 0x12, 0x1a, 0x0a, 0x16, 0x45, 0x4c, 0x45, 0x4d,
+	0x45, 0x4e, 0x54, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x45, 0x58, 0x54, 0x45, 0x4e, 0x53, 0x49,
+	0x4f, 0x4e, 0x10, 0x08, 0x42, 0x4e, 0x5a, 0x4c, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63,
+	0x6f, 0x6d, 0x2f, 0x74, 0x69, 0x65, 0x72, 0x6b, 0x6c, 0x69, 0x6e, 0x69, 0x6b, 0x2d, 0x64, 0x6f,
+	0x62, 0x65, 0x72, 0x73, 0x62, 0x65, 0x72, 0x67, 0x2f, 0x70, 0x62, 0x74, 0x79, 0x70, 0x65, 0x2d,
+	0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2f, 0x67, 0x65, 0x6e, 0x2f, 0x67, 0x6f, 0x2f, 0x74, 0x6b,
+	0x64, 0x2f, 0x70, 0x62, 0x74, 0x79, 0x70, 0x65, 0x2f, 0x76, 0x31, 0x3b, 0x70, 0x62, 0x74, 0x79,
+	0x70, 0x65, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_tkd_pbtype_v1_diff_proto_rawDescOnce sync.Once
+	file_tkd_pbtype_v1_diff_proto_rawDescData = file_tkd_pbtype_v1_diff_proto_rawDesc
+)
+
+func file_tkd_pbtype_v1_diff_proto_rawDescGZIP() []byte {
+	file_tkd_pbtype_v1_diff_proto_rawDescOnce.Do(func() {
+		file_tkd_pbtype_v1_diff_proto_rawDescData = protoimpl.X.CompressGZIP(file_tkd_pbtype_v1_diff_proto_rawDescData)
+	})
+	return file_tkd_pbtype_v1_diff_proto_rawDescData
+}
+
+var file_tkd_pbtype_v1_diff_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_tkd_pbtype_v1_diff_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_tkd_pbtype_v1_diff_proto_goTypes = []any{
+	(ElementChangeType)(0), // 0: tkd.pbtype.v1.ElementChangeType
+	(ElementType)(0),       // 1: tkd.pbtype.v1.ElementType
+	(*PropertyChange)(nil), // 2: tkd.pbtype.v1.PropertyChange
+	(*DiffEntry)(nil),      // 3: tkd.pbtype.v1.DiffEntry
+	(*DiffRequest)(nil),    // 4: tkd.pbtype.v1.DiffRequest
+	(*DiffResponse)(nil),   // 5: tkd.pbtype.v1.DiffResponse
+}
+var file_tkd_pbtype_v1_diff_proto_depIdxs = []int32{
+	0, // 0: tkd.pbtype.v1.DiffEntry.change:type_name -> tkd.pbtype.v1.ElementChangeType
+	1, // 1: tkd.pbtype.v1.DiffEntry.element:type_name -> tkd.pbtype.v1.ElementType
+	2, // 2: tkd.pbtype.v1.DiffEntry.properties:type_name -> tkd.pbtype.v1.PropertyChange
+	3, // 3: tkd.pbtype.v1.DiffResponse.entries:type_name -> tkd.pbtype.v1.DiffEntry
+	4, // [4:4] is the sub-list for method output_type
+	4, // [4:4] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
+}
+
+func init() { file_tkd_pbtype_v1_diff_proto_init() }
+func file_tkd_pbtype_v1_diff_proto_init() {
+	if File_tkd_pbtype_v1_diff_proto != nil {
+		return
+	}
+	file_tkd_pbtype_v1_diff_proto_msgTypes[2].OneofWrappers = []any{
+		(*DiffRequest_ToSnapshot)(nil),
+		(*DiffRequest_FileDescriptorSet)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_tkd_pbtype_v1_diff_proto_rawDesc,
+			NumEnums:      2,
+			NumMessages:   4,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_tkd_pbtype_v1_diff_proto_goTypes,
+		DependencyIndexes: file_tkd_pbtype_v1_diff_proto_depIdxs,
+		EnumInfos:         file_tkd_pbtype_v1_diff_proto_enumTypes,
+		MessageInfos:      file_tkd_pbtype_v1_diff_proto_msgTypes,
+	}.Build()
+	File_tkd_pbtype_v1_diff_proto = out.File
+	file_tkd_pbtype_v1_diff_proto_rawDesc = nil
+	file_tkd_pbtype_v1_diff_proto_goTypes = nil
+	file_tkd_pbtype_v1_diff_proto_depIdxs = nil
+}
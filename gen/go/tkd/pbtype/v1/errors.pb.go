@@ -0,0 +1,187 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.35.1
+// 	protoc        (unknown)
+// source: tkd/pbtype/v1/errors.proto
+
+package pbtypev1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// NotFoundDetail is attached to NotFound errors returned when resolving a
+// file, symbol or type URL.
+type NotFoundDetail struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The file name, symbol or type URL that has been requested.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// The package the requested symbol most likely belongs to. Empty if a
+	// file name has been requested.
+	Package string `protobuf:"bytes,2,opt,name=package,proto3" json:"package,omitempty"`
+	// The names of the configured sources that normally contain the
+	// package.
+	Sources []string `protobuf:"bytes,3,rep,name=sources,proto3" json:"sources,omitempty"`
+	// Symbols with a similar name, closest match first.
+	SuggestedSymbols []*Symbol `protobuf:"bytes,4,rep,name=suggested_symbols,json=suggestedSymbols,proto3" json:"suggested_symbols,omitempty"`
+	// Files with a similar name, closest match first.
+	SuggestedFiles []string `protobuf:"bytes,5,rep,name=suggested_files,json=suggestedFiles,proto3" json:"suggested_files,omitempty"`
+}
+
+func (x *NotFoundDetail) Reset() {
+	*x = NotFoundDetail{}
+	mi := &file_tkd_pbtype_v1_errors_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NotFoundDetail) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NotFoundDetail) ProtoMessage() {}
+
+func (x *NotFoundDetail) ProtoReflect() protoreflect.Message {
+	mi := &file_tkd_pbtype_v1_errors_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NotFoundDetail.ProtoReflect.Descriptor instead.
+func (*NotFoundDetail) Descriptor() ([]byte, []int) {
+	return file_tkd_pbtype_v1_errors_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *NotFoundDetail) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *NotFoundDetail) GetPackage() string {
+	if x != nil {
+		return x.Package
+	}
+	return ""
+}
+
+func (x *NotFoundDetail) GetSources() []string {
+	if x != nil {
+		return x.Sources
+	}
+	return nil
+}
+
+func (x *NotFoundDetail) GetSuggestedSymbols() []*Symbol {
+	if x != nil {
+		return x.SuggestedSymbols
+	}
+	return nil
+}
+
+func (x *NotFoundDetail) GetSuggestedFiles() []string {
+	if x != nil {
+		return x.SuggestedFiles
+	}
+	return nil
+}
+
+var File_tkd_pbtype_v1_errors_proto protoreflect.FileDescriptor
+
+var file_tkd_pbtype_v1_errors_proto_rawDesc = []byte{
+	0x0a, 0x1a, 0x74, 0x6b, 0x64, 0x2f, 0x70, 0x62, 0x74, 0x79, 0x70, 0x65, 0x2f, 0x76, 0x31, 0x2f,
+	0x65, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0d, 0x74, 0x6b,
+	0x64, 0x2e, 0x70, 0x62, 0x74, 0x79, 0x70, 0x65, 0x2e, 0x76, 0x31, 0x1a, 0x1a, 0x74, 0x6b, 0x64,
+	0x2f, 0x70, 0x62, 0x74, 0x79, 0x70, 0x65, 0x2f, 0x76, 0x31, 0x2f, 0x73, 0x65, 0x61, 0x72, 0x63,
+	0x68, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xc5, 0x01, 0x0a, 0x0e, 0x4e, 0x6f, 0x74, 0x46,
+	0x6f, 0x75, 0x6e, 0x64, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61,
+	0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x18,
+	0x0a, 0x07, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x07, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x6f, 0x75, 0x72,
+	0x63, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x73, 0x6f, 0x75, 0x72, 0x63,
+	0x65, 0x73, 0x12, 0x42, 0x0a, 0x11, 0x73, 0x75, 0x67, 0x67, 0x65, 0x73, 0x74, 0x65, 0x64, 0x5f,
+	0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e,
+	0x74, 0x6b, 0x64, 0x2e, 0x70, 0x62, 0x74, 0x79, 0x70, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x79,
+	0x6d, 0x62, 0x6f, 0x6c, 0x52, 0x10, 0x73, 0x75, 0x67, 0x67, 0x65, 0x73, 0x74, 0x65, 0x64, 0x53,
+	0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x73, 0x12, 0x27, 0x0a, 0x0f, 0x73, 0x75, 0x67, 0x67, 0x65, 0x73,
+	0x74, 0x65, 0x64, 0x5f, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x09, 0x52,
+	0x0e, 0x73, 0x75, 0x67, 0x67, 0x65, 0x73, 0x74, 0x65, 0x64, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x42,
+	0x4e, 0x5a, 0x4c, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x74, 0x69,
+	0x65, 0x72, 0x6b, 0x6c, 0x69, 0x6e, 0x69, 0x6b, 0x2d, 0x64, 0x6f, 0x62, 0x65, 0x72, 0x73, 0x62,
+	0x65, 0x72, 0x67, 0x2f, 0x70, 0x62, 0x74, 0x79, 0x70, 0x65, 0x2d, 0x73, 0x65, 0x72, 0x76, 0x65,
+	0x72, 0x2f, 0x67, 0x65, 0x6e, 0x2f, 0x67, 0x6f, 0x2f, 0x74, 0x6b, 0x64, 0x2f, 0x70, 0x62, 0x74,
+	0x79, 0x70, 0x65, 0x2f, 0x76, 0x31, 0x3b, 0x70, 0x62, 0x74, 0x79, 0x70, 0x65, 0x76, 0x31, 0x62,
+	0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_tkd_pbtype_v1_errors_proto_rawDescOnce sync.Once
+	file_tkd_pbtype_v1_errors_proto_rawDescData = file_tkd_pbtype_v1_errors_proto_rawDesc
+)
+
+func file_tkd_pbtype_v1_errors_proto_rawDescGZIP() []byte {
+	file_tkd_pbtype_v1_errors_proto_rawDescOnce.Do(func() {
+		file_tkd_pbtype_v1_errors_proto_rawDescData = protoimpl.X.CompressGZIP(file_tkd_pbtype_v1_errors_proto_rawDescData)
+	})
+	return file_tkd_pbtype_v1_errors_proto_rawDescData
+}
+
+var file_tkd_pbtype_v1_errors_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_tkd_pbtype_v1_errors_proto_goTypes = []any{
+	(*NotFoundDetail)(nil), // 0: tkd.pbtype.v1.NotFoundDetail
+	(*Symbol)(nil),         // 1: tkd.pbtype.v1.Symbol
+}
+var file_tkd_pbtype_v1_errors_proto_depIdxs = []int32{
+	1, // 0: tkd.pbtype.v1.NotFoundDetail.suggested_symbols:type_name -> tkd.pbtype.v1.Symbol
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_tkd_pbtype_v1_errors_proto_init() }
+func file_tkd_pbtype_v1_errors_proto_init() {
+	if File_tkd_pbtype_v1_errors_proto != nil {
+		return
+	}
+	file_tkd_pbtype_v1_search_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_tkd_pbtype_v1_errors_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_tkd_pbtype_v1_errors_proto_goTypes,
+		DependencyIndexes: file_tkd_pbtype_v1_errors_proto_depIdxs,
+		MessageInfos:      file_tkd_pbtype_v1_errors_proto_msgTypes,
+	}.Build()
+	File_tkd_pbtype_v1_errors_proto = out.File
+	file_tkd_pbtype_v1_errors_proto_rawDesc = nil
+	file_tkd_pbtype_v1_errors_proto_goTypes = nil
+	file_tkd_pbtype_v1_errors_proto_depIdxs = nil
+}
@@ -0,0 +1,233 @@
+// Code generated by protoc-gen-connect-go. DO NOT EDIT.
+//
+// Source: tkd/pbtype/v1/admin.proto
+
+package pbtypev1connect
+
+import (
+	context "context"
+	errors "errors"
+	connect_go "github.com/bufbuild/connect-go"
+	v1 "github.com/tierklinik-dobersberg/pbtype-server/gen/go/tkd/pbtype/v1"
+	http "net/http"
+	strings "strings"
+)
+
+// This is a compile-time assertion to ensure that this generated file and the connect package are
+// compatible. If you get a compiler error that this constant is not defined, this code was
+// generated with a version of connect newer than the one compiled into your binary. You can fix the
+// problem by either regenerating this code with an older version of connect or updating the connect
+// version compiled into your binary.
+const _ = connect_go.IsAtLeastVersion0_1_0
+
+const (
+	// AdminServiceName is the fully-qualified name of the AdminService service.
+	AdminServiceName = "tkd.pbtype.v1.AdminService"
+)
+
+// These constants are the fully-qualified names of the RPCs defined in this package. They're
+// exposed at runtime as Spec.Procedure and as the final two segments of the HTTP route.
+//
+// Note that these are different from the fully-qualified method names used by
+// google.golang.org/protobuf/reflect/protoreflect. To convert from these constants to
+// reflection-formatted method names, remove the leading slash and convert the remaining slash to a
+// period.
+const (
+	// AdminServiceListSourcesProcedure is the fully-qualified name of the AdminService's ListSources
+	// RPC.
+	AdminServiceListSourcesProcedure = "/tkd.pbtype.v1.AdminService/ListSources"
+	// AdminServiceRefreshSourcesProcedure is the fully-qualified name of the AdminService's
+	// RefreshSources RPC.
+	AdminServiceRefreshSourcesProcedure = "/tkd.pbtype.v1.AdminService/RefreshSources"
+	// AdminServiceListSnapshotsProcedure is the fully-qualified name of the AdminService's
+	// ListSnapshots RPC.
+	AdminServiceListSnapshotsProcedure = "/tkd.pbtype.v1.AdminService/ListSnapshots"
+	// AdminServiceUploadSourceProcedure is the fully-qualified name of the AdminService's UploadSource
+	// RPC.
+	AdminServiceUploadSourceProcedure = "/tkd.pbtype.v1.AdminService/UploadSource"
+	// AdminServiceListConflictsProcedure is the fully-qualified name of the AdminService's
+	// ListConflicts RPC.
+	AdminServiceListConflictsProcedure = "/tkd.pbtype.v1.AdminService/ListConflicts"
+)
+
+// AdminServiceClient is a client for the tkd.pbtype.v1.AdminService service.
+type AdminServiceClient interface {
+	// ListSources returns the status of all configured sources.
+	ListSources(context.Context, *connect_go.Request[v1.ListSourcesRequest]) (*connect_go.Response[v1.ListSourcesResponse], error)
+	// RefreshSources refreshes the given sources immediately and waits
+	// for the refresh to complete.
+	RefreshSources(context.Context, *connect_go.Request[v1.RefreshSourcesRequest]) (*connect_go.Response[v1.RefreshSourcesResponse], error)
+	// ListSnapshots returns all snapshots that are retained in memory or
+	// in the archive.
+	ListSnapshots(context.Context, *connect_go.Request[v1.ListSnapshotsRequest]) (*connect_go.Response[v1.ListSnapshotsResponse], error)
+	// UploadSource compiles the uploaded files against the files of all
+	// other sources and, on success, stores and serves them as a named
+	// source. Requires the upload token to be sent as a bearer token.
+	UploadSource(context.Context, *connect_go.Request[v1.UploadSourceRequest]) (*connect_go.Response[v1.UploadSourceResponse], error)
+	// ListConflicts returns all files and symbols that are provided by
+	// more than one source and how they have been resolved.
+	ListConflicts(context.Context, *connect_go.Request[v1.ListConflictsRequest]) (*connect_go.Response[v1.ListConflictsResponse], error)
+}
+
+// NewAdminServiceClient constructs a client for the tkd.pbtype.v1.AdminService service. By default,
+// it uses the Connect protocol with the binary Protobuf Codec, asks for gzipped responses, and
+// sends uncompressed requests. To use the gRPC or gRPC-Web protocols, supply the connect.WithGRPC()
+// or connect.WithGRPCWeb() options.
+//
+// The URL supplied here should be the base URL for the Connect or gRPC server (for example,
+// http://api.acme.com or https://acme.com/grpc).
+func NewAdminServiceClient(httpClient connect_go.HTTPClient, baseURL string, opts ...connect_go.ClientOption) AdminServiceClient {
+	baseURL = strings.TrimRight(baseURL, "/")
+	return &adminServiceClient{
+		listSources: connect_go.NewClient[v1.ListSourcesRequest, v1.ListSourcesResponse](
+			httpClient,
+			baseURL+AdminServiceListSourcesProcedure,
+			opts...,
+		),
+		refreshSources: connect_go.NewClient[v1.RefreshSourcesRequest, v1.RefreshSourcesResponse](
+			httpClient,
+			baseURL+AdminServiceRefreshSourcesProcedure,
+			opts...,
+		),
+		listSnapshots: connect_go.NewClient[v1.ListSnapshotsRequest, v1.ListSnapshotsResponse](
+			httpClient,
+			baseURL+AdminServiceListSnapshotsProcedure,
+			opts...,
+		),
+		uploadSource: connect_go.NewClient[v1.UploadSourceRequest, v1.UploadSourceResponse](
+			httpClient,
+			baseURL+AdminServiceUploadSourceProcedure,
+			opts...,
+		),
+		listConflicts: connect_go.NewClient[v1.ListConflictsRequest, v1.ListConflictsResponse](
+			httpClient,
+			baseURL+AdminServiceListConflictsProcedure,
+			opts...,
+		),
+	}
+}
+
+// adminServiceClient implements AdminServiceClient.
+type adminServiceClient struct {
+	listSources    *connect_go.Client[v1.ListSourcesRequest, v1.ListSourcesResponse]
+	refreshSources *connect_go.Client[v1.RefreshSourcesRequest, v1.RefreshSourcesResponse]
+	listSnapshots  *connect_go.Client[v1.ListSnapshotsRequest, v1.ListSnapshotsResponse]
+	uploadSource   *connect_go.Client[v1.UploadSourceRequest, v1.UploadSourceResponse]
+	listConflicts  *connect_go.Client[v1.ListConflictsRequest, v1.ListConflictsResponse]
+}
+
+// ListSources calls tkd.pbtype.v1.AdminService.ListSources.
+func (c *adminServiceClient) ListSources(ctx context.Context, req *connect_go.Request[v1.ListSourcesRequest]) (*connect_go.Response[v1.ListSourcesResponse], error) {
+	return c.listSources.CallUnary(ctx, req)
+}
+
+// RefreshSources calls tkd.pbtype.v1.AdminService.RefreshSources.
+func (c *adminServiceClient) RefreshSources(ctx context.Context, req *connect_go.Request[v1.RefreshSourcesRequest]) (*connect_go.Response[v1.RefreshSourcesResponse], error) {
+	return c.refreshSources.CallUnary(ctx, req)
+}
+
+// ListSnapshots calls tkd.pbtype.v1.AdminService.ListSnapshots.
+func (c *adminServiceClient) ListSnapshots(ctx context.Context, req *connect_go.Request[v1.ListSnapshotsRequest]) (*connect_go.Response[v1.ListSnapshotsResponse], error) {
+	return c.listSnapshots.CallUnary(ctx, req)
+}
+
+// UploadSource calls tkd.pbtype.v1.AdminService.UploadSource.
+func (c *adminServiceClient) UploadSource(ctx context.Context, req *connect_go.Request[v1.UploadSourceRequest]) (*connect_go.Response[v1.UploadSourceResponse], error) {
+	return c.uploadSource.CallUnary(ctx, req)
+}
+
+// ListConflicts calls tkd.pbtype.v1.AdminService.ListConflicts.
+func (c *adminServiceClient) ListConflicts(ctx context.Context, req *connect_go.Request[v1.ListConflictsRequest]) (*connect_go.Response[v1.ListConflictsResponse], error) {
+	return c.listConflicts.CallUnary(ctx, req)
+}
+
+// AdminServiceHandler is an implementation of the tkd.pbtype.v1.AdminService service.
+type AdminServiceHandler interface {
+	// ListSources returns the status of all configured sources.
+	ListSources(context.Context, *connect_go.Request[v1.ListSourcesRequest]) (*connect_go.Response[v1.ListSourcesResponse], error)
+	// RefreshSources refreshes the given sources immediately and waits
+	// for the refresh to complete.
+	RefreshSources(context.Context, *connect_go.Request[v1.RefreshSourcesRequest]) (*connect_go.Response[v1.RefreshSourcesResponse], error)
+	// ListSnapshots returns all snapshots that are retained in memory or
+	// in the archive.
+	ListSnapshots(context.Context, *connect_go.Request[v1.ListSnapshotsRequest]) (*connect_go.Response[v1.ListSnapshotsResponse], error)
+	// UploadSource compiles the uploaded files against the files of all
+	// other sources and, on success, stores and serves them as a named
+	// source. Requires the upload token to be sent as a bearer token.
+	UploadSource(context.Context, *connect_go.Request[v1.UploadSourceRequest]) (*connect_go.Response[v1.UploadSourceResponse], error)
+	// ListConflicts returns all files and symbols that are provided by
+	// more than one source and how they have been resolved.
+	ListConflicts(context.Context, *connect_go.Request[v1.ListConflictsRequest]) (*connect_go.Response[v1.ListConflictsResponse], error)
+}
+
+// NewAdminServiceHandler builds an HTTP handler from the service implementation. It returns the
+// path on which to mount the handler and the handler itself.
+//
+// By default, handlers support the Connect, gRPC, and gRPC-Web protocols with the binary Protobuf
+// and JSON codecs. They also support gzip compression.
+func NewAdminServiceHandler(svc AdminServiceHandler, opts ...connect_go.HandlerOption) (string, http.Handler) {
+	adminServiceListSourcesHandler := connect_go.NewUnaryHandler(
+		AdminServiceListSourcesProcedure,
+		svc.ListSources,
+		opts...,
+	)
+	adminServiceRefreshSourcesHandler := connect_go.NewUnaryHandler(
+		AdminServiceRefreshSourcesProcedure,
+		svc.RefreshSources,
+		opts...,
+	)
+	adminServiceListSnapshotsHandler := connect_go.NewUnaryHandler(
+		AdminServiceListSnapshotsProcedure,
+		svc.ListSnapshots,
+		opts...,
+	)
+	adminServiceUploadSourceHandler := connect_go.NewUnaryHandler(
+		AdminServiceUploadSourceProcedure,
+		svc.UploadSource,
+		opts...,
+	)
+	adminServiceListConflictsHandler := connect_go.NewUnaryHandler(
+		AdminServiceListConflictsProcedure,
+		svc.ListConflicts,
+		opts...,
+	)
+	return "/tkd.pbtype.v1.AdminService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case AdminServiceListSourcesProcedure:
+			adminServiceListSourcesHandler.ServeHTTP(w, r)
+		case AdminServiceRefreshSourcesProcedure:
+			adminServiceRefreshSourcesHandler.ServeHTTP(w, r)
+		case AdminServiceListSnapshotsProcedure:
+			adminServiceListSnapshotsHandler.ServeHTTP(w, r)
+		case AdminServiceUploadSourceProcedure:
+			adminServiceUploadSourceHandler.ServeHTTP(w, r)
+		case AdminServiceListConflictsProcedure:
+			adminServiceListConflictsHandler.ServeHTTP(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+// UnimplementedAdminServiceHandler returns CodeUnimplemented from all methods.
+type UnimplementedAdminServiceHandler struct{}
+
+func (UnimplementedAdminServiceHandler) ListSources(context.Context, *connect_go.Request[v1.ListSourcesRequest]) (*connect_go.Response[v1.ListSourcesResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("tkd.pbtype.v1.AdminService.ListSources is not implemented"))
+}
+
+func (UnimplementedAdminServiceHandler) RefreshSources(context.Context, *connect_go.Request[v1.RefreshSourcesRequest]) (*connect_go.Response[v1.RefreshSourcesResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("tkd.pbtype.v1.AdminService.RefreshSources is not implemented"))
+}
+
+func (UnimplementedAdminServiceHandler) ListSnapshots(context.Context, *connect_go.Request[v1.ListSnapshotsRequest]) (*connect_go.Response[v1.ListSnapshotsResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("tkd.pbtype.v1.AdminService.ListSnapshots is not implemented"))
+}
+
+func (UnimplementedAdminServiceHandler) UploadSource(context.Context, *connect_go.Request[v1.UploadSourceRequest]) (*connect_go.Response[v1.UploadSourceResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("tkd.pbtype.v1.AdminService.UploadSource is not implemented"))
+}
+
+func (UnimplementedAdminServiceHandler) ListConflicts(context.Context, *connect_go.Request[v1.ListConflictsRequest]) (*connect_go.Response[v1.ListConflictsResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("tkd.pbtype.v1.AdminService.ListConflicts is not implemented"))
+}
@@ -0,0 +1,326 @@
+// Code generated by protoc-gen-connect-go. DO NOT EDIT.
+//
+// Source: tkd/pbtype/v1/descriptor.proto
+
+package pbtypev1connect
+
+import (
+	context "context"
+	errors "errors"
+	connect_go "github.com/bufbuild/connect-go"
+	v1 "github.com/tierklinik-dobersberg/pbtype-server/gen/go/tkd/pbtype/v1"
+	http "net/http"
+	strings "strings"
+)
+
+// This is a compile-time assertion to ensure that this generated file and the connect package are
+// compatible. If you get a compiler error that this constant is not defined, this code was
+// generated with a version of connect newer than the one compiled into your binary. You can fix the
+// problem by either regenerating this code with an older version of connect or updating the connect
+// version compiled into your binary.
+const _ = connect_go.IsAtLeastVersion0_1_0
+
+const (
+	// DescriptorServiceName is the fully-qualified name of the DescriptorService service.
+	DescriptorServiceName = "tkd.pbtype.v1.DescriptorService"
+)
+
+// These constants are the fully-qualified names of the RPCs defined in this package. They're
+// exposed at runtime as Spec.Procedure and as the final two segments of the HTTP route.
+//
+// Note that these are different from the fully-qualified method names used by
+// google.golang.org/protobuf/reflect/protoreflect. To convert from these constants to
+// reflection-formatted method names, remove the leading slash and convert the remaining slash to a
+// period.
+const (
+	// DescriptorServiceResolveProcedure is the fully-qualified name of the DescriptorService's Resolve
+	// RPC.
+	DescriptorServiceResolveProcedure = "/tkd.pbtype.v1.DescriptorService/Resolve"
+	// DescriptorServiceBatchResolveProcedure is the fully-qualified name of the DescriptorService's
+	// BatchResolve RPC.
+	DescriptorServiceBatchResolveProcedure = "/tkd.pbtype.v1.DescriptorService/BatchResolve"
+	// DescriptorServiceListPackagesProcedure is the fully-qualified name of the DescriptorService's
+	// ListPackages RPC.
+	DescriptorServiceListPackagesProcedure = "/tkd.pbtype.v1.DescriptorService/ListPackages"
+	// DescriptorServiceListSymbolsProcedure is the fully-qualified name of the DescriptorService's
+	// ListSymbols RPC.
+	DescriptorServiceListSymbolsProcedure = "/tkd.pbtype.v1.DescriptorService/ListSymbols"
+	// DescriptorServiceWatchProcedure is the fully-qualified name of the DescriptorService's Watch RPC.
+	DescriptorServiceWatchProcedure = "/tkd.pbtype.v1.DescriptorService/Watch"
+	// DescriptorServiceDiffProcedure is the fully-qualified name of the DescriptorService's Diff RPC.
+	DescriptorServiceDiffProcedure = "/tkd.pbtype.v1.DescriptorService/Diff"
+	// DescriptorServiceGetChangelogProcedure is the fully-qualified name of the DescriptorService's
+	// GetChangelog RPC.
+	DescriptorServiceGetChangelogProcedure = "/tkd.pbtype.v1.DescriptorService/GetChangelog"
+	// DescriptorServiceGetProvenanceProcedure is the fully-qualified name of the DescriptorService's
+	// GetProvenance RPC.
+	DescriptorServiceGetProvenanceProcedure = "/tkd.pbtype.v1.DescriptorService/GetProvenance"
+)
+
+// DescriptorServiceClient is a client for the tkd.pbtype.v1.DescriptorService service.
+type DescriptorServiceClient interface {
+	// Resolve resolves a file descriptor and, if requested, all of its
+	// transitive dependencies.
+	Resolve(context.Context, *connect_go.Request[v1.ResolveRequest]) (*connect_go.Response[v1.ResolveResponse], error)
+	// BatchResolve resolves multiple files at once. Items that cannot be
+	// resolved do not fail the request but are reported in the per-item
+	// status.
+	BatchResolve(context.Context, *connect_go.Request[v1.BatchResolveRequest]) (*connect_go.Response[v1.BatchResolveResponse], error)
+	// ListPackages lists all packages of served files.
+	ListPackages(context.Context, *connect_go.Request[v1.ListPackagesRequest]) (*connect_go.Response[v1.ListPackagesResponse], error)
+	// ListSymbols lists and searches messages, enums, services and
+	// extensions of served files.
+	ListSymbols(context.Context, *connect_go.Request[v1.ListSymbolsRequest]) (*connect_go.Response[v1.ListSymbolsResponse], error)
+	// Watch streams the files that changed each time the registry
+	// activates a new snapshot.
+	Watch(context.Context, *connect_go.Request[v1.WatchRequest]) (*connect_go.ServerStreamForClient[v1.WatchResponse], error)
+	// Diff compares two snapshots, or a snapshot and a set of file
+	// descriptors, and returns all messages, fields, enums, services and
+	// options that have been added, removed or changed.
+	Diff(context.Context, *connect_go.Request[v1.DiffRequest]) (*connect_go.Response[v1.DiffResponse], error)
+	// GetChangelog returns a human readable changelog entry for each
+	// activated snapshot that changed the served packages.
+	GetChangelog(context.Context, *connect_go.Request[v1.GetChangelogRequest]) (*connect_go.Response[v1.GetChangelogResponse], error)
+	// GetProvenance returns the source, revision, original path and fetch
+	// time of served files.
+	GetProvenance(context.Context, *connect_go.Request[v1.GetProvenanceRequest]) (*connect_go.Response[v1.GetProvenanceResponse], error)
+}
+
+// NewDescriptorServiceClient constructs a client for the tkd.pbtype.v1.DescriptorService service.
+// By default, it uses the Connect protocol with the binary Protobuf Codec, asks for gzipped
+// responses, and sends uncompressed requests. To use the gRPC or gRPC-Web protocols, supply the
+// connect.WithGRPC() or connect.WithGRPCWeb() options.
+//
+// The URL supplied here should be the base URL for the Connect or gRPC server (for example,
+// http://api.acme.com or https://acme.com/grpc).
+func NewDescriptorServiceClient(httpClient connect_go.HTTPClient, baseURL string, opts ...connect_go.ClientOption) DescriptorServiceClient {
+	baseURL = strings.TrimRight(baseURL, "/")
+	return &descriptorServiceClient{
+		resolve: connect_go.NewClient[v1.ResolveRequest, v1.ResolveResponse](
+			httpClient,
+			baseURL+DescriptorServiceResolveProcedure,
+			opts...,
+		),
+		batchResolve: connect_go.NewClient[v1.BatchResolveRequest, v1.BatchResolveResponse](
+			httpClient,
+			baseURL+DescriptorServiceBatchResolveProcedure,
+			opts...,
+		),
+		listPackages: connect_go.NewClient[v1.ListPackagesRequest, v1.ListPackagesResponse](
+			httpClient,
+			baseURL+DescriptorServiceListPackagesProcedure,
+			opts...,
+		),
+		listSymbols: connect_go.NewClient[v1.ListSymbolsRequest, v1.ListSymbolsResponse](
+			httpClient,
+			baseURL+DescriptorServiceListSymbolsProcedure,
+			opts...,
+		),
+		watch: connect_go.NewClient[v1.WatchRequest, v1.WatchResponse](
+			httpClient,
+			baseURL+DescriptorServiceWatchProcedure,
+			opts...,
+		),
+		diff: connect_go.NewClient[v1.DiffRequest, v1.DiffResponse](
+			httpClient,
+			baseURL+DescriptorServiceDiffProcedure,
+			opts...,
+		),
+		getChangelog: connect_go.NewClient[v1.GetChangelogRequest, v1.GetChangelogResponse](
+			httpClient,
+			baseURL+DescriptorServiceGetChangelogProcedure,
+			opts...,
+		),
+		getProvenance: connect_go.NewClient[v1.GetProvenanceRequest, v1.GetProvenanceResponse](
+			httpClient,
+			baseURL+DescriptorServiceGetProvenanceProcedure,
+			opts...,
+		),
+	}
+}
+
+// descriptorServiceClient implements DescriptorServiceClient.
+type descriptorServiceClient struct {
+	resolve       *connect_go.Client[v1.ResolveRequest, v1.ResolveResponse]
+	batchResolve  *connect_go.Client[v1.BatchResolveRequest, v1.BatchResolveResponse]
+	listPackages  *connect_go.Client[v1.ListPackagesRequest, v1.ListPackagesResponse]
+	listSymbols   *connect_go.Client[v1.ListSymbolsRequest, v1.ListSymbolsResponse]
+	watch         *connect_go.Client[v1.WatchRequest, v1.WatchResponse]
+	diff          *connect_go.Client[v1.DiffRequest, v1.DiffResponse]
+	getChangelog  *connect_go.Client[v1.GetChangelogRequest, v1.GetChangelogResponse]
+	getProvenance *connect_go.Client[v1.GetProvenanceRequest, v1.GetProvenanceResponse]
+}
+
+// Resolve calls tkd.pbtype.v1.DescriptorService.Resolve.
+func (c *descriptorServiceClient) Resolve(ctx context.Context, req *connect_go.Request[v1.ResolveRequest]) (*connect_go.Response[v1.ResolveResponse], error) {
+	return c.resolve.CallUnary(ctx, req)
+}
+
+// BatchResolve calls tkd.pbtype.v1.DescriptorService.BatchResolve.
+func (c *descriptorServiceClient) BatchResolve(ctx context.Context, req *connect_go.Request[v1.BatchResolveRequest]) (*connect_go.Response[v1.BatchResolveResponse], error) {
+	return c.batchResolve.CallUnary(ctx, req)
+}
+
+// ListPackages calls tkd.pbtype.v1.DescriptorService.ListPackages.
+func (c *descriptorServiceClient) ListPackages(ctx context.Context, req *connect_go.Request[v1.ListPackagesRequest]) (*connect_go.Response[v1.ListPackagesResponse], error) {
+	return c.listPackages.CallUnary(ctx, req)
+}
+
+// ListSymbols calls tkd.pbtype.v1.DescriptorService.ListSymbols.
+func (c *descriptorServiceClient) ListSymbols(ctx context.Context, req *connect_go.Request[v1.ListSymbolsRequest]) (*connect_go.Response[v1.ListSymbolsResponse], error) {
+	return c.listSymbols.CallUnary(ctx, req)
+}
+
+// Watch calls tkd.pbtype.v1.DescriptorService.Watch.
+func (c *descriptorServiceClient) Watch(ctx context.Context, req *connect_go.Request[v1.WatchRequest]) (*connect_go.ServerStreamForClient[v1.WatchResponse], error) {
+	return c.watch.CallServerStream(ctx, req)
+}
+
+// Diff calls tkd.pbtype.v1.DescriptorService.Diff.
+func (c *descriptorServiceClient) Diff(ctx context.Context, req *connect_go.Request[v1.DiffRequest]) (*connect_go.Response[v1.DiffResponse], error) {
+	return c.diff.CallUnary(ctx, req)
+}
+
+// GetChangelog calls tkd.pbtype.v1.DescriptorService.GetChangelog.
+func (c *descriptorServiceClient) GetChangelog(ctx context.Context, req *connect_go.Request[v1.GetChangelogRequest]) (*connect_go.Response[v1.GetChangelogResponse], error) {
+	return c.getChangelog.CallUnary(ctx, req)
+}
+
+// GetProvenance calls tkd.pbtype.v1.DescriptorService.GetProvenance.
+func (c *descriptorServiceClient) GetProvenance(ctx context.Context, req *connect_go.Request[v1.GetProvenanceRequest]) (*connect_go.Response[v1.GetProvenanceResponse], error) {
+	return c.getProvenance.CallUnary(ctx, req)
+}
+
+// DescriptorServiceHandler is an implementation of the tkd.pbtype.v1.DescriptorService service.
+type DescriptorServiceHandler interface {
+	// Resolve resolves a file descriptor and, if requested, all of its
+	// transitive dependencies.
+	Resolve(context.Context, *connect_go.Request[v1.ResolveRequest]) (*connect_go.Response[v1.ResolveResponse], error)
+	// BatchResolve resolves multiple files at once. Items that cannot be
+	// resolved do not fail the request but are reported in the per-item
+	// status.
+	BatchResolve(context.Context, *connect_go.Request[v1.BatchResolveRequest]) (*connect_go.Response[v1.BatchResolveResponse], error)
+	// ListPackages lists all packages of served files.
+	ListPackages(context.Context, *connect_go.Request[v1.ListPackagesRequest]) (*connect_go.Response[v1.ListPackagesResponse], error)
+	// ListSymbols lists and searches messages, enums, services and
+	// extensions of served files.
+	ListSymbols(context.Context, *connect_go.Request[v1.ListSymbolsRequest]) (*connect_go.Response[v1.ListSymbolsResponse], error)
+	// Watch streams the files that changed each time the registry
+	// activates a new snapshot.
+	Watch(context.Context, *connect_go.Request[v1.WatchRequest], *connect_go.ServerStream[v1.WatchResponse]) error
+	// Diff compares two snapshots, or a snapshot and a set of file
+	// descriptors, and returns all messages, fields, enums, services and
+	// options that have been added, removed or changed.
+	Diff(context.Context, *connect_go.Request[v1.DiffRequest]) (*connect_go.Response[v1.DiffResponse], error)
+	// GetChangelog returns a human readable changelog entry for each
+	// activated snapshot that changed the served packages.
+	GetChangelog(context.Context, *connect_go.Request[v1.GetChangelogRequest]) (*connect_go.Response[v1.GetChangelogResponse], error)
+	// GetProvenance returns the source, revision, original path and fetch
+	// time of served files.
+	GetProvenance(context.Context, *connect_go.Request[v1.GetProvenanceRequest]) (*connect_go.Response[v1.GetProvenanceResponse], error)
+}
+
+// NewDescriptorServiceHandler builds an HTTP handler from the service implementation. It returns
+// the path on which to mount the handler and the handler itself.
+//
+// By default, handlers support the Connect, gRPC, and gRPC-Web protocols with the binary Protobuf
+// and JSON codecs. They also support gzip compression.
+func NewDescriptorServiceHandler(svc DescriptorServiceHandler, opts ...connect_go.HandlerOption) (string, http.Handler) {
+	descriptorServiceResolveHandler := connect_go.NewUnaryHandler(
+		DescriptorServiceResolveProcedure,
+		svc.Resolve,
+		opts...,
+	)
+	descriptorServiceBatchResolveHandler := connect_go.NewUnaryHandler(
+		DescriptorServiceBatchResolveProcedure,
+		svc.BatchResolve,
+		opts...,
+	)
+	descriptorServiceListPackagesHandler := connect_go.NewUnaryHandler(
+		DescriptorServiceListPackagesProcedure,
+		svc.ListPackages,
+		opts...,
+	)
+	descriptorServiceListSymbolsHandler := connect_go.NewUnaryHandler(
+		DescriptorServiceListSymbolsProcedure,
+		svc.ListSymbols,
+		opts...,
+	)
+	descriptorServiceWatchHandler := connect_go.NewServerStreamHandler(
+		DescriptorServiceWatchProcedure,
+		svc.Watch,
+		opts...,
+	)
+	descriptorServiceDiffHandler := connect_go.NewUnaryHandler(
+		DescriptorServiceDiffProcedure,
+		svc.Diff,
+		opts...,
+	)
+	descriptorServiceGetChangelogHandler := connect_go.NewUnaryHandler(
+		DescriptorServiceGetChangelogProcedure,
+		svc.GetChangelog,
+		opts...,
+	)
+	descriptorServiceGetProvenanceHandler := connect_go.NewUnaryHandler(
+		DescriptorServiceGetProvenanceProcedure,
+		svc.GetProvenance,
+		opts...,
+	)
+	return "/tkd.pbtype.v1.DescriptorService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case DescriptorServiceResolveProcedure:
+			descriptorServiceResolveHandler.ServeHTTP(w, r)
+		case DescriptorServiceBatchResolveProcedure:
+			descriptorServiceBatchResolveHandler.ServeHTTP(w, r)
+		case DescriptorServiceListPackagesProcedure:
+			descriptorServiceListPackagesHandler.ServeHTTP(w, r)
+		case DescriptorServiceListSymbolsProcedure:
+			descriptorServiceListSymbolsHandler.ServeHTTP(w, r)
+		case DescriptorServiceWatchProcedure:
+			descriptorServiceWatchHandler.ServeHTTP(w, r)
+		case DescriptorServiceDiffProcedure:
+			descriptorServiceDiffHandler.ServeHTTP(w, r)
+		case DescriptorServiceGetChangelogProcedure:
+			descriptorServiceGetChangelogHandler.ServeHTTP(w, r)
+		case DescriptorServiceGetProvenanceProcedure:
+			descriptorServiceGetProvenanceHandler.ServeHTTP(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+// UnimplementedDescriptorServiceHandler returns CodeUnimplemented from all methods.
+type UnimplementedDescriptorServiceHandler struct{}
+
+func (UnimplementedDescriptorServiceHandler) Resolve(context.Context, *connect_go.Request[v1.ResolveRequest]) (*connect_go.Response[v1.ResolveResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("tkd.pbtype.v1.DescriptorService.Resolve is not implemented"))
+}
+
+func (UnimplementedDescriptorServiceHandler) BatchResolve(context.Context, *connect_go.Request[v1.BatchResolveRequest]) (*connect_go.Response[v1.BatchResolveResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("tkd.pbtype.v1.DescriptorService.BatchResolve is not implemented"))
+}
+
+func (UnimplementedDescriptorServiceHandler) ListPackages(context.Context, *connect_go.Request[v1.ListPackagesRequest]) (*connect_go.Response[v1.ListPackagesResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("tkd.pbtype.v1.DescriptorService.ListPackages is not implemented"))
+}
+
+func (UnimplementedDescriptorServiceHandler) ListSymbols(context.Context, *connect_go.Request[v1.ListSymbolsRequest]) (*connect_go.Response[v1.ListSymbolsResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("tkd.pbtype.v1.DescriptorService.ListSymbols is not implemented"))
+}
+
+func (UnimplementedDescriptorServiceHandler) Watch(context.Context, *connect_go.Request[v1.WatchRequest], *connect_go.ServerStream[v1.WatchResponse]) error {
+	return connect_go.NewError(connect_go.CodeUnimplemented, errors.New("tkd.pbtype.v1.DescriptorService.Watch is not implemented"))
+}
+
+func (UnimplementedDescriptorServiceHandler) Diff(context.Context, *connect_go.Request[v1.DiffRequest]) (*connect_go.Response[v1.DiffResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("tkd.pbtype.v1.DescriptorService.Diff is not implemented"))
+}
+
+func (UnimplementedDescriptorServiceHandler) GetChangelog(context.Context, *connect_go.Request[v1.GetChangelogRequest]) (*connect_go.Response[v1.GetChangelogResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("tkd.pbtype.v1.DescriptorService.GetChangelog is not implemented"))
+}
+
+func (UnimplementedDescriptorServiceHandler) GetProvenance(context.Context, *connect_go.Request[v1.GetProvenanceRequest]) (*connect_go.Response[v1.GetProvenanceResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("tkd.pbtype.v1.DescriptorService.GetProvenance is not implemented"))
+}
@@ -0,0 +1,322 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/ghodss/yaml"
+)
+
+// DefaultRefreshInterval is used for all sources that do not specify a
+// refresh interval if the configuration does not specify a default.
+const DefaultRefreshInterval = 10 * time.Minute
+
+// DefaultSnapshotHistory is the number of snapshots kept in memory if the
+// configuration does not specify it.
+const DefaultSnapshotHistory = 10
+
+// DefaultChangelogSize is the number of changelog entries kept if the
+// configuration does not specify it.
+const DefaultChangelogSize = 100
+
+// Policies for files and symbols that are provided by more than one source.
+const (
+	// ConflictFail refuses new versions of sources that conflict with
+	// another source. The previous version of such a source is kept.
+	ConflictFail = "fail"
+
+	// ConflictFirst serves the file of the source that is configured
+	// first.
+	ConflictFirst = "first"
+
+	// ConflictPriority serves the file of the source with the highest
+	// priority. Sources with the same priority are ranked by their order.
+	ConflictPriority = "priority"
+)
+
+// Config configures the protobuf sources served by pbtype-server.
+//
+// A configuration file may be written in YAML or JSON:
+//
+//	refreshInterval: 10m
+//	cacheDir: /var/cache/pbtype-server
+//	snapshotHistory: 10
+//	snapshotArchive: 100
+//	conflictPolicy: priority
+//	adminToken: some-admin-token
+//	uploadToken: some-token
+//	notifications:
+//	  - url: https://example.com/hooks/pbtype
+//	    secret: some-secret
+//	    packages:
+//	      - tkd.customer.v1
+//	sources:
+//	  - name: apis
+//	    url: github.com/tierklinik-dobersberg/apis.git
+//	    importRoot: proto
+//	    refreshInterval: 1m
+//	    priority: 10
+//	    exclude:
+//	      - "**/internal/**"
+//	  - name: protovalidate
+//	    url: github.com/bufbuild/protovalidate.git//proto/protovalidate
+//	    importOnly: true
+type Config struct {
+	// RefreshInterval is the default refresh interval for sources that
+	// do not specify their own.
+	RefreshInterval Duration `json:"refreshInterval,omitempty"`
+
+	// CacheDir is a directory used to persist compiled sources, the ID of
+	// the last snapshot and the changelog.
+	CacheDir string `json:"cacheDir,omitempty"`
+
+	// SnapshotHistory is the number of snapshots, including the current
+	// one, that are kept in memory.
+	SnapshotHistory int `json:"snapshotHistory,omitempty"`
+
+	// SnapshotArchive is the number of snapshots that are kept in CacheDir.
+	// Archived snapshots survive restarts and can still be resolved once
+	// they have been dropped from memory. Requires CacheDir.
+	SnapshotArchive int `json:"snapshotArchive,omitempty"`
+
+	// RefuseBreakingChanges prevents new versions of sources that contain
+	// wire-breaking changes from being activated. The previous version of
+	// such a source is kept until the changes are reverted or the option
+	// is disabled.
+	RefuseBreakingChanges bool `json:"refuseBreakingChanges,omitempty"`
+
+	// ConflictPolicy decides which source wins if more than one source
+	// provides the same file or defines the same symbol. One of "fail",
+	// "first" or "priority". Defaults to "first".
+	ConflictPolicy string `json:"conflictPolicy,omitempty"`
+
+	// ChangelogSize is the number of changelog entries that are kept. Each
+	// activated snapshot that changes the served files adds an entry.
+	ChangelogSize int `json:"changelogSize,omitempty"`
+
+	// Notifications holds webhooks that receive a signed POST request for
+	// each new changelog entry.
+	Notifications []Notification `json:"notifications,omitempty"`
+
+	// WebhookSecret is the shared secret used to verify push webhooks.
+	// Webhooks are disabled if empty.
+	WebhookSecret string `json:"webhookSecret,omitempty"`
+
+	// AdminToken is the bearer token required to call the AdminService,
+	// except for UploadSource which requires UploadToken. The
+	// AdminService is disabled if empty.
+	AdminToken string `json:"adminToken,omitempty"`
+
+	// UploadToken is the bearer token required to upload sources using
+	// the AdminService. Uploads are disabled if empty.
+	UploadToken string `json:"uploadToken,omitempty"`
+
+	// UploadDir is the directory uploaded sources are stored in. Defaults
+	// to the "uploads" sub-directory of CacheDir.
+	UploadDir string `json:"uploadDir,omitempty"`
+
+	// Sources holds all protobuf sources.
+	Sources []Source `json:"sources"`
+}
+
+// Source configures a single protobuf source.
+type Source struct {
+	// Name is a unique name for the source. Defaults to URL without
+	// credentials, see RedactURL.
+	Name string `json:"name,omitempty"`
+
+	// URL is the go-getter URL of the source. It may point to a directory
+	// or archive of .proto files or to precompiled descriptor sets. Git
+	// repositories are kept in a persistent working copy and are only
+	// compiled again if the checked out commit changes.
+	URL string `json:"url"`
+
+	// Ref is the branch, tag or commit checked out for git sources. It
+	// overwrites the ref parameter of URL. Defaults to the default branch
+	// of the repository.
+	Ref string `json:"ref,omitempty"`
+
+	// Repository is the URL of the git repository that is matched against
+	// push webhooks. Defaults to URL.
+	Repository string `json:"repository,omitempty"`
+
+	// RefreshInterval overwrites the default refresh interval for this
+	// source.
+	RefreshInterval Duration `json:"refreshInterval,omitempty"`
+
+	// Include holds glob patterns, relative to ImportRoot, of .proto files
+	// that should be compiled. If empty, all .proto files are included.
+	// The special "**" element matches any number of directories. For
+	// sources containing buf modules, patterns are relative to the module
+	// roots instead.
+	Include []string `json:"include,omitempty"`
+
+	// Exclude holds glob patterns, relative to ImportRoot, of .proto files
+	// that should be skipped.
+	//
+	// Both Include and Exclude are also matched against the paths of
+	// descriptor sets (.binpb, .protoset and .pb files) found below
+	// ImportRoot and against the names of the files they contain.
+	Exclude []string `json:"exclude,omitempty"`
+
+	// ImportRoot is a sub-directory of the downloaded source that is used
+	// as the import path.
+	ImportRoot string `json:"importRoot,omitempty"`
+
+	// ImportOnly marks the source as only being used to satisfy imports of
+	// other sources. Files of import-only sources are only served if they
+	// are (transitively) imported by a file of a served source.
+	ImportOnly bool `json:"importOnly,omitempty"`
+
+	// BufModules holds names of buf modules, like
+	// buf.build/googleapis/googleapis, that are provided by this source in
+	// addition to the modules named in its buf.yaml. They are used to
+	// check the declared dependencies of buf modules in other sources.
+	BufModules []string `json:"bufModules,omitempty"`
+
+	// Priority ranks the source if the conflict policy is "priority".
+	// Sources with a higher priority win conflicts.
+	Priority int `json:"priority,omitempty"`
+}
+
+// Notification configures an outbound webhook that is notified about
+// changelog entries.
+type Notification struct {
+	// URL is the http or https URL the changelog entries are posted to.
+	URL string `json:"url"`
+
+	// Secret is used to sign the payload using HMAC-SHA256. The signature
+	// is sent in the X-Pbtype-Signature header. Payloads are not signed if
+	// empty.
+	Secret string `json:"secret,omitempty"`
+
+	// Packages limits notifications to changes of the listed packages and
+	// their sub-packages. If empty, all changes are sent.
+	Packages []string `json:"packages,omitempty"`
+}
+
+// Duration is a time.Duration that is encoded as a string like "10m".
+type Duration time.Duration
+
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
+
+func (d *Duration) UnmarshalJSON(blob []byte) error {
+	var s string
+	if err := json.Unmarshal(blob, &s); err != nil {
+		return fmt.Errorf("invalid duration: %w", err)
+	}
+
+	parsed, err := time.ParseDuration(s)
+	if err != nil {
+		return err
+	}
+
+	*d = Duration(parsed)
+
+	return nil
+}
+
+// Load reads the configuration file at path. Both YAML and JSON are
+// supported.
+func Load(path string) (*Config, error) {
+	blob, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var cfg Config
+	if err := yaml.Unmarshal(blob, &cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	return &cfg, nil
+}
+
+// Validate applies default values and ensures the configuration is valid.
+func (cfg *Config) Validate() error {
+	if cfg.RefreshInterval <= 0 {
+		cfg.RefreshInterval = Duration(DefaultRefreshInterval)
+	}
+
+	if cfg.SnapshotHistory <= 0 {
+		cfg.SnapshotHistory = DefaultSnapshotHistory
+	}
+
+	if cfg.SnapshotArchive > 0 && cfg.CacheDir == "" {
+		return errors.New("snapshotArchive requires cacheDir to be set")
+	}
+
+	if cfg.UploadToken != "" && cfg.UploadDir == "" {
+		if cfg.CacheDir == "" {
+			return errors.New("uploadToken requires uploadDir or cacheDir to be set")
+		}
+
+		cfg.UploadDir = filepath.Join(cfg.CacheDir, "uploads")
+	}
+
+	if cfg.ChangelogSize <= 0 {
+		cfg.ChangelogSize = DefaultChangelogSize
+	}
+
+	switch cfg.ConflictPolicy {
+	case "":
+		cfg.ConflictPolicy = ConflictFirst
+	case ConflictFail, ConflictFirst, ConflictPriority:
+	default:
+		return fmt.Errorf("invalid conflictPolicy %q", cfg.ConflictPolicy)
+	}
+
+	for idx, n := range cfg.Notifications {
+		u, err := url.Parse(n.URL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("notification #%d: invalid url %q", idx, n.URL)
+		}
+	}
+
+	// sources may also be uploaded at runtime
+	if len(cfg.Sources) == 0 && cfg.UploadToken == "" {
+		return errors.New("no sources configured")
+	}
+
+	names := make(map[string]struct{}, len(cfg.Sources))
+
+	for idx := range cfg.Sources {
+		src := &cfg.Sources[idx]
+
+		if src.URL == "" {
+			return fmt.Errorf("source #%d: missing url", idx)
+		}
+
+		// names are reported by the API, so they must not leak
+		// credentials.
+		if src.Name == "" {
+			src.Name = RedactURL(src.URL)
+		}
+
+		if _, ok := names[src.Name]; ok {
+			return fmt.Errorf("source %q: duplicate name", src.Name)
+		}
+		names[src.Name] = struct{}{}
+
+		if src.RefreshInterval <= 0 {
+			src.RefreshInterval = cfg.RefreshInterval
+		}
+
+		for _, patterns := range [][]string{src.Include, src.Exclude} {
+			for _, pattern := range patterns {
+				if err := validateGlob(pattern); err != nil {
+					return fmt.Errorf("source %q: invalid pattern %q: %w", src.Name, pattern, err)
+				}
+			}
+		}
+	}
+
+	return nil
+}
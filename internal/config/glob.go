@@ -0,0 +1,82 @@
+package config
+
+import (
+	"path"
+	"strings"
+)
+
+// Matches reports whether the file at name, relative to the import root,
+// is selected by the include and exclude patterns of the source.
+func (src Source) Matches(name string) bool {
+	if len(src.Include) > 0 {
+		included := false
+
+		for _, pattern := range src.Include {
+			if matchGlob(pattern, name) {
+				included = true
+
+				break
+			}
+		}
+
+		if !included {
+			return false
+		}
+	}
+
+	for _, pattern := range src.Exclude {
+		if matchGlob(pattern, name) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// matchGlob matches name against pattern. Besides the syntax supported by
+// path.Match, a "**" path element matches zero or more directories.
+func matchGlob(pattern, name string) bool {
+	return matchSegments(strings.Split(pattern, "/"), strings.Split(name, "/"))
+}
+
+func matchSegments(pattern, name []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			for skip := 0; skip <= len(name); skip++ {
+				if matchSegments(pattern[1:], name[skip:]) {
+					return true
+				}
+			}
+
+			return false
+		}
+
+		if len(name) == 0 {
+			return false
+		}
+
+		if ok, _ := path.Match(pattern[0], name[0]); !ok {
+			return false
+		}
+
+		pattern = pattern[1:]
+		name = name[1:]
+	}
+
+	return len(name) == 0
+}
+
+// validateGlob reports whether pattern is a valid glob pattern.
+func validateGlob(pattern string) error {
+	for _, segment := range strings.Split(pattern, "/") {
+		if segment == "**" {
+			continue
+		}
+
+		if _, err := path.Match(segment, ""); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
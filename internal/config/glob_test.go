@@ -0,0 +1,67 @@
+package config
+
+import "testing"
+
+func TestMatchGlob(t *testing.T) {
+	cases := []struct {
+		pattern string
+		name    string
+		want    bool
+	}{
+		{"a.proto", "a.proto", true},
+		{"a.proto", "b.proto", false},
+		{"*.proto", "a.proto", true},
+		{"*.proto", "dir/a.proto", false},
+		{"dir/*.proto", "dir/a.proto", true},
+		{"dir/*.proto", "dir/sub/a.proto", false},
+		{"dir/?.proto", "dir/a.proto", true},
+		{"dir/[ab].proto", "dir/c.proto", false},
+		{"**", "a.proto", true},
+		{"**", "dir/sub/a.proto", true},
+		{"**/*.proto", "a.proto", true},
+		{"**/*.proto", "dir/sub/a.proto", true},
+		{"**/*.proto", "dir/sub/a.txt", false},
+		{"dir/**", "dir/a.proto", true},
+		{"dir/**", "other/a.proto", false},
+		{"dir/**/a.proto", "dir/a.proto", true},
+		{"dir/**/a.proto", "dir/x/y/a.proto", true},
+		{"dir/**/a.proto", "dir/x/y/b.proto", false},
+		{"**/internal/**", "acme/internal/v1/a.proto", true},
+		{"**/internal/**", "acme/v1/a.proto", false},
+		{"dir", "dir/a.proto", false},
+	}
+
+	for _, c := range cases {
+		if got := matchGlob(c.pattern, c.name); got != c.want {
+			t.Errorf("matchGlob(%q, %q) = %t, want %t", c.pattern, c.name, got, c.want)
+		}
+	}
+}
+
+func TestSourceMatches(t *testing.T) {
+	src := Source{
+		Include: []string{"acme/**", "common/*.proto"},
+		Exclude: []string{"**/internal/**"},
+	}
+
+	cases := []struct {
+		name string
+		want bool
+	}{
+		{"acme/v1/a.proto", true},
+		{"acme/internal/v1/a.proto", false},
+		{"common/c.proto", true},
+		{"common/sub/c.proto", false},
+		{"other/o.proto", false},
+	}
+
+	for _, c := range cases {
+		if got := src.Matches(c.name); got != c.want {
+			t.Errorf("Matches(%q) = %t, want %t", c.name, got, c.want)
+		}
+	}
+
+	if !(Source{}).Matches("any/file.proto") {
+		t.Errorf("a source without patterns must match all files")
+	}
+}
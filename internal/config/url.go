@@ -0,0 +1,54 @@
+package config
+
+import (
+	"net/url"
+	"strings"
+)
+
+// secretParams holds go-getter URL parameters whose values are secrets.
+var secretParams = []string{"sshkey", "aws_access_key_secret", "aws_access_token", "token", "access_token", "private_token"}
+
+// RedactURL returns url without the user information and with the values of
+// parameters holding secrets, like the sshkey parameter of go-getter,
+// replaced by "redacted". Forced getters like "git::" are kept. URLs that do
+// not contain credentials are returned unchanged.
+func RedactURL(raw string) string {
+	forced, rest, ok := strings.Cut(raw, "::")
+	if !ok || strings.Contains(forced, "/") {
+		forced, rest = "", raw
+	}
+
+	// scp-like git URLs, like git@github.com:acme/protos.git, cannot be
+	// parsed but do not carry secrets either.
+	u, err := url.Parse(rest)
+	if err != nil {
+		return raw
+	}
+
+	changed := false
+
+	if u.User != nil {
+		u.User = nil
+		changed = true
+	}
+
+	query := u.Query()
+	for _, name := range secretParams {
+		if query.Has(name) {
+			query.Set(name, "redacted")
+			changed = true
+		}
+	}
+
+	if !changed {
+		return raw
+	}
+
+	u.RawQuery = query.Encode()
+
+	if forced != "" {
+		return forced + "::" + u.String()
+	}
+
+	return u.String()
+}
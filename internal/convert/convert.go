@@ -0,0 +1,56 @@
+package convert
+
+import (
+	pbtypev1 "github.com/tierklinik-dobersberg/pbtype-server/gen/go/tkd/pbtype/v1"
+	"github.com/tierklinik-dobersberg/pbtype-server/internal/registry"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// ChangelogEntry converts a changelog entry to its protobuf representation.
+func ChangelogEntry(entry registry.ChangelogEntry) *pbtypev1.ChangelogEntry {
+	res := &pbtypev1.ChangelogEntry{
+		SnapshotId:           entry.SnapshotID,
+		SnapshotHash:         entry.SnapshotHash,
+		PreviousSnapshotId:   entry.PreviousSnapshotID,
+		PreviousSnapshotHash: entry.PreviousSnapshotHash,
+		Time:                 timestamppb.New(entry.Time),
+		Title:                entry.Title(),
+	}
+
+	for _, pkg := range entry.Packages {
+		change := &pbtypev1.PackageChange{
+			Package: pkg.Package,
+			Change:  ElementChangeType(pkg.Change),
+			Summary: pkg.Summary(),
+		}
+
+		for _, diff := range pkg.Entries {
+			change.Entries = append(change.Entries, DiffEntry(diff))
+		}
+
+		res.Packages = append(res.Packages, change)
+	}
+
+	return res
+}
+
+// DiffEntry converts a diff entry to its protobuf representation.
+func DiffEntry(entry registry.DiffEntry) *pbtypev1.DiffEntry {
+	res := &pbtypev1.DiffEntry{
+		Change:   ElementChangeType(entry.Change),
+		Element:  ElementType(entry.Element),
+		Name:     entry.Name,
+		FileName: entry.File,
+		Package:  entry.Package,
+	}
+
+	for _, prop := range entry.Properties {
+		res.Properties = append(res.Properties, &pbtypev1.PropertyChange{
+			Name:     prop.Name,
+			OldValue: prop.OldValue,
+			NewValue: prop.NewValue,
+		})
+	}
+
+	return res
+}
@@ -0,0 +1,97 @@
+package convert
+
+import (
+	pbtypev1 "github.com/tierklinik-dobersberg/pbtype-server/gen/go/tkd/pbtype/v1"
+	"github.com/tierklinik-dobersberg/pbtype-server/internal/registry"
+)
+
+var symbolKinds = map[registry.SymbolKind]pbtypev1.SymbolKind{
+	registry.SymbolMessage:   pbtypev1.SymbolKind_SYMBOL_KIND_MESSAGE,
+	registry.SymbolEnum:      pbtypev1.SymbolKind_SYMBOL_KIND_ENUM,
+	registry.SymbolService:   pbtypev1.SymbolKind_SYMBOL_KIND_SERVICE,
+	registry.SymbolExtension: pbtypev1.SymbolKind_SYMBOL_KIND_EXTENSION,
+}
+
+// SymbolKind converts a symbol kind to its protobuf representation.
+func SymbolKind(kind registry.SymbolKind) pbtypev1.SymbolKind {
+	return symbolKinds[kind]
+}
+
+// RegistrySymbolKind converts a protobuf symbol kind to the kind used by
+// the registry. It returns false for unspecified or unknown kinds.
+func RegistrySymbolKind(kind pbtypev1.SymbolKind) (registry.SymbolKind, bool) {
+	for result, proto := range symbolKinds {
+		if proto == kind {
+			return result, true
+		}
+	}
+
+	return 0, false
+}
+
+var fileChangeTypes = map[registry.ChangeType]pbtypev1.FileChangeType{
+	registry.FileAdded:    pbtypev1.FileChangeType_FILE_CHANGE_TYPE_ADDED,
+	registry.FileRemoved:  pbtypev1.FileChangeType_FILE_CHANGE_TYPE_REMOVED,
+	registry.FileModified: pbtypev1.FileChangeType_FILE_CHANGE_TYPE_MODIFIED,
+}
+
+// FileChangeType converts the type of a file change to its protobuf
+// representation.
+func FileChangeType(change registry.ChangeType) pbtypev1.FileChangeType {
+	return fileChangeTypes[change]
+}
+
+var elementChangeTypes = map[registry.DiffChange]pbtypev1.ElementChangeType{
+	registry.ElementAdded:   pbtypev1.ElementChangeType_ELEMENT_CHANGE_TYPE_ADDED,
+	registry.ElementRemoved: pbtypev1.ElementChangeType_ELEMENT_CHANGE_TYPE_REMOVED,
+	registry.ElementChanged: pbtypev1.ElementChangeType_ELEMENT_CHANGE_TYPE_CHANGED,
+}
+
+// ElementChangeType converts the change of an element to its protobuf
+// representation.
+func ElementChangeType(change registry.DiffChange) pbtypev1.ElementChangeType {
+	return elementChangeTypes[change]
+}
+
+var elementTypes = map[registry.ElementType]pbtypev1.ElementType{
+	registry.ElementFile:      pbtypev1.ElementType_ELEMENT_TYPE_FILE,
+	registry.ElementMessage:   pbtypev1.ElementType_ELEMENT_TYPE_MESSAGE,
+	registry.ElementField:     pbtypev1.ElementType_ELEMENT_TYPE_FIELD,
+	registry.ElementEnum:      pbtypev1.ElementType_ELEMENT_TYPE_ENUM,
+	registry.ElementEnumValue: pbtypev1.ElementType_ELEMENT_TYPE_ENUM_VALUE,
+	registry.ElementService:   pbtypev1.ElementType_ELEMENT_TYPE_SERVICE,
+	registry.ElementMethod:    pbtypev1.ElementType_ELEMENT_TYPE_METHOD,
+	registry.ElementExtension: pbtypev1.ElementType_ELEMENT_TYPE_EXTENSION,
+}
+
+// ElementType converts the type of a diffed element to its protobuf
+// representation.
+func ElementType(element registry.ElementType) pbtypev1.ElementType {
+	return elementTypes[element]
+}
+
+var conflictKinds = map[registry.ConflictKind]pbtypev1.ConflictKind{
+	registry.ConflictFile:   pbtypev1.ConflictKind_CONFLICT_KIND_FILE,
+	registry.ConflictSymbol: pbtypev1.ConflictKind_CONFLICT_KIND_SYMBOL,
+}
+
+// ConflictKind converts a conflict kind to its protobuf representation.
+func ConflictKind(kind registry.ConflictKind) pbtypev1.ConflictKind {
+	return conflictKinds[kind]
+}
+
+var breakingChangeKinds = map[registry.BreakingChangeKind]pbtypev1.BreakingChangeKind{
+	registry.FieldRemoved:       pbtypev1.BreakingChangeKind_BREAKING_CHANGE_KIND_FIELD_REMOVED,
+	registry.FieldNumberChanged: pbtypev1.BreakingChangeKind_BREAKING_CHANGE_KIND_FIELD_NUMBER_CHANGED,
+	registry.FieldTypeChanged:   pbtypev1.BreakingChangeKind_BREAKING_CHANGE_KIND_FIELD_TYPE_CHANGED,
+	registry.FieldRenamed:       pbtypev1.BreakingChangeKind_BREAKING_CHANGE_KIND_FIELD_RENAMED,
+	registry.MessageRemoved:     pbtypev1.BreakingChangeKind_BREAKING_CHANGE_KIND_MESSAGE_REMOVED,
+	registry.MessageRenamed:     pbtypev1.BreakingChangeKind_BREAKING_CHANGE_KIND_MESSAGE_RENAMED,
+	registry.EnumValueRemoved:   pbtypev1.BreakingChangeKind_BREAKING_CHANGE_KIND_ENUM_VALUE_REMOVED,
+}
+
+// BreakingChangeKind converts the kind of a breaking change to its protobuf
+// representation.
+func BreakingChangeKind(kind registry.BreakingChangeKind) pbtypev1.BreakingChangeKind {
+	return breakingChangeKinds[kind]
+}
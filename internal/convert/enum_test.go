@@ -0,0 +1,55 @@
+package convert
+
+import (
+	"testing"
+
+	pbtypev1 "github.com/tierklinik-dobersberg/pbtype-server/gen/go/tkd/pbtype/v1"
+)
+
+// TestEnumsComplete ensures that every specified value of the protobuf enums
+// has a registry counterpart.
+func TestEnumsComplete(t *testing.T) {
+	cases := []struct {
+		name   string
+		values map[int32]string
+		mapped func(int32) bool
+	}{
+		{"SymbolKind", pbtypev1.SymbolKind_name, func(v int32) bool {
+			_, ok := RegistrySymbolKind(pbtypev1.SymbolKind(v))
+			return ok
+		}},
+		{"FileChangeType", pbtypev1.FileChangeType_name, func(v int32) bool {
+			return contains(fileChangeTypes, pbtypev1.FileChangeType(v))
+		}},
+		{"ElementChangeType", pbtypev1.ElementChangeType_name, func(v int32) bool {
+			return contains(elementChangeTypes, pbtypev1.ElementChangeType(v))
+		}},
+		{"ElementType", pbtypev1.ElementType_name, func(v int32) bool {
+			return contains(elementTypes, pbtypev1.ElementType(v))
+		}},
+		{"ConflictKind", pbtypev1.ConflictKind_name, func(v int32) bool {
+			return contains(conflictKinds, pbtypev1.ConflictKind(v))
+		}},
+		{"BreakingChangeKind", pbtypev1.BreakingChangeKind_name, func(v int32) bool {
+			return contains(breakingChangeKinds, pbtypev1.BreakingChangeKind(v))
+		}},
+	}
+
+	for _, c := range cases {
+		for value, name := range c.values {
+			if value != 0 && !c.mapped(value) {
+				t.Errorf("%s: %s has no registry counterpart", c.name, name)
+			}
+		}
+	}
+}
+
+func contains[K, V comparable](m map[K]V, value V) bool {
+	for _, v := range m {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,208 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/tierklinik-dobersberg/pbtype-server/internal/config"
+	"github.com/tierklinik-dobersberg/pbtype-server/internal/convert"
+	"github.com/tierklinik-dobersberg/pbtype-server/internal/registry"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+const (
+	// SignatureHeader holds the hex encoded HMAC-SHA256 of the payload,
+	// prefixed with "sha256=".
+	SignatureHeader = "X-Pbtype-Signature"
+
+	// EventHeader holds the type of the event, which is always
+	// "changelog".
+	EventHeader = "X-Pbtype-Event"
+
+	// DeliveryHeader holds the ID of the snapshot the payload refers to.
+	DeliveryHeader = "X-Pbtype-Delivery"
+)
+
+// maxAttempts is the number of times a notification is sent before it is
+// dropped.
+const maxAttempts = 5
+
+// requestTimeout limits how long a single delivery may take.
+const requestTimeout = 10 * time.Second
+
+// Notifier posts new changelog entries of the registry to webhooks. The
+// payload is the JSON encoding of a tkd.pbtype.v1.ChangelogEntry.
+type Notifier struct {
+	registry *registry.Registry
+	targets  []config.Notification
+	client   *http.Client
+
+	// backoff is the delay before the first retry of a failed delivery.
+	// It doubles with each attempt.
+	backoff time.Duration
+}
+
+func New(registry *registry.Registry, targets []config.Notification) *Notifier {
+	return &Notifier{
+		registry: registry,
+		targets:  targets,
+		client:   &http.Client{Timeout: requestTimeout},
+		backoff:  time.Second,
+	}
+}
+
+// Start subscribes to the changelog of the registry and delivers new
+// entries in the background until ctx is cancelled. Entries missed because
+// deliveries fell behind are sent once the notifier catches up. Entries
+// recorded before Start, including those restored from the cache
+// directory, are never sent.
+func (n *Notifier) Start(ctx context.Context) {
+	last := n.latest()
+
+	entries, cancel := n.registry.SubscribeChangelog()
+
+	go func() {
+		for {
+			if !n.run(ctx, entries, &last) {
+				cancel()
+
+				return
+			}
+
+			cancel()
+			entries, cancel = n.registry.SubscribeChangelog()
+
+			n.catchUp(ctx, &last)
+		}
+	}()
+}
+
+// latest returns the ID of the snapshot of the newest changelog entry of
+// the registry or zero if the changelog is empty.
+func (n *Notifier) latest() uint64 {
+	if entries := n.registry.Changelog(); len(entries) > 0 {
+		return entries[0].SnapshotID
+	}
+
+	return 0
+}
+
+// catchUp delivers all retained changelog entries that are newer than
+// last, oldest first, and updates last.
+func (n *Notifier) catchUp(ctx context.Context, last *uint64) {
+	missed := n.registry.Changelog()
+	for idx := len(missed) - 1; idx >= 0; idx-- {
+		if missed[idx].SnapshotID > *last {
+			n.deliver(ctx, missed[idx])
+			*last = missed[idx].SnapshotID
+		}
+	}
+}
+
+// run delivers entries until the channel is closed or ctx is cancelled. It
+// returns false if ctx has been cancelled.
+func (n *Notifier) run(ctx context.Context, entries <-chan registry.ChangelogEntry, last *uint64) bool {
+	for {
+		select {
+		case <-ctx.Done():
+			return false
+
+		case entry, ok := <-entries:
+			if !ok {
+				return true
+			}
+
+			if entry.SnapshotID > *last {
+				n.deliver(ctx, entry)
+				*last = entry.SnapshotID
+			}
+		}
+	}
+}
+
+// deliver sends entry to all targets that are interested in at least one
+// of the changed packages.
+func (n *Notifier) deliver(ctx context.Context, entry registry.ChangelogEntry) {
+	for _, target := range n.targets {
+		filtered := entry.Filter(target.Packages)
+		if len(filtered.Packages) == 0 {
+			continue
+		}
+
+		blob, err := protojson.Marshal(convert.ChangelogEntry(filtered))
+		if err != nil {
+			slog.Error("failed to marshal changelog entry", "error", err)
+
+			continue
+		}
+
+		backoff := n.backoff
+		for attempt := 1; ; attempt++ {
+			err := n.post(ctx, target, entry.SnapshotID, blob)
+			if err == nil {
+				slog.Info("sent changelog notification", "url", target.URL, "snapshot", entry.SnapshotID)
+
+				break
+			}
+
+			if attempt == maxAttempts {
+				slog.Error("failed to send changelog notification, giving up", "url", target.URL, "snapshot", entry.SnapshotID, "error", err)
+
+				break
+			}
+
+			slog.Warn("failed to send changelog notification, retrying", "url", target.URL, "snapshot", entry.SnapshotID, "error", err, "backoff", backoff)
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(backoff):
+			}
+
+			backoff *= 2
+		}
+	}
+}
+
+func (n *Notifier) post(ctx context.Context, target config.Notification, snapshot uint64, blob []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, target.URL, bytes.NewReader(blob))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(EventHeader, "changelog")
+	req.Header.Set(DeliveryHeader, strconv.FormatUint(snapshot, 10))
+
+	if target.Secret != "" {
+		req.Header.Set(SignatureHeader, "sha256="+Sign([]byte(target.Secret), blob))
+	}
+
+	res, err := n.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status code %d", res.StatusCode)
+	}
+
+	return nil
+}
+
+// Sign returns the hex encoded HMAC-SHA256 of payload using secret.
+func Sign(secret []byte, payload []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(payload)
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,210 @@
+package notify
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	pbtypev1 "github.com/tierklinik-dobersberg/pbtype-server/gen/go/tkd/pbtype/v1"
+	"github.com/tierklinik-dobersberg/pbtype-server/internal/config"
+	"github.com/tierklinik-dobersberg/pbtype-server/internal/registry"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// delivery is a single request received by a recorder.
+type delivery struct {
+	header http.Header
+	entry  *pbtypev1.ChangelogEntry
+	body   []byte
+}
+
+// recorder is a webhook target that records all successful deliveries.
+// The first failures attempts are answered with an error.
+type recorder struct {
+	l          sync.Mutex
+	deliveries []delivery
+	attempts   int
+	failures   int
+}
+
+func (rec *recorder) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	rec.l.Lock()
+	defer rec.l.Unlock()
+
+	rec.attempts++
+	if rec.attempts <= rec.failures {
+		w.WriteHeader(http.StatusInternalServerError)
+
+		return
+	}
+
+	body, _ := io.ReadAll(r.Body)
+
+	var entry pbtypev1.ChangelogEntry
+	if err := protojson.Unmarshal(body, &entry); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+
+		return
+	}
+
+	rec.deliveries = append(rec.deliveries, delivery{header: r.Header, entry: &entry, body: body})
+}
+
+// newRecorder starts a recorder and returns it together with its URL.
+func newRecorder(t *testing.T, failures int) (*recorder, string) {
+	t.Helper()
+
+	rec := &recorder{failures: failures}
+
+	server := httptest.NewServer(rec)
+	t.Cleanup(server.Close)
+
+	return rec, server.URL
+}
+
+// newRegistry returns a registry whose persisted changelog holds entry 1,
+// which changed acme.v1, and entry 2, which changed other.v1.
+func newRegistry(t *testing.T) *registry.Registry {
+	t.Helper()
+
+	cacheDir := t.TempDir()
+
+	entries := []registry.ChangelogEntry{
+		{SnapshotID: 1, Packages: []registry.PackageChange{{Package: "acme.v1", Change: registry.ElementAdded}}},
+		{SnapshotID: 2, PreviousSnapshotID: 1, Packages: []registry.PackageChange{{Package: "other.v1", Change: registry.ElementAdded}}},
+	}
+
+	blob, err := json.Marshal(entries)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.WriteFile(filepath.Join(cacheDir, "changelog.json"), blob, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	return registry.New(config.Config{CacheDir: cacheDir, ChangelogSize: 10})
+}
+
+func TestSign(t *testing.T) {
+	var (
+		secret  = []byte("secret")
+		payload = []byte(`{"snapshotId":"1"}`)
+		mac     = hmac.New(sha256.New, secret)
+	)
+
+	mac.Write(payload)
+
+	if got, want := Sign(secret, payload), hex.EncodeToString(mac.Sum(nil)); got != want {
+		t.Errorf("got %s, want %s", got, want)
+	}
+}
+
+func TestDeliver(t *testing.T) {
+	reg := newRegistry(t)
+	entry := reg.Changelog()[0]
+
+	t.Run("signature", func(t *testing.T) {
+		rec, url := newRecorder(t, 0)
+
+		New(reg, []config.Notification{{URL: url, Secret: "secret"}}).deliver(context.Background(), entry)
+
+		if len(rec.deliveries) != 1 {
+			t.Fatalf("expected one delivery, got %d", len(rec.deliveries))
+		}
+
+		header := rec.deliveries[0].header
+		if got, want := header.Get(SignatureHeader), "sha256="+Sign([]byte("secret"), rec.deliveries[0].body); got != want {
+			t.Errorf("unexpected signature %q, want %q", got, want)
+		}
+
+		if header.Get(EventHeader) != "changelog" || header.Get(DeliveryHeader) != "2" {
+			t.Errorf("unexpected headers %v", header)
+		}
+	})
+
+	t.Run("retry", func(t *testing.T) {
+		rec, url := newRecorder(t, 2)
+
+		n := New(reg, []config.Notification{{URL: url}})
+		n.backoff = time.Millisecond
+		n.deliver(context.Background(), entry)
+
+		if rec.attempts != 3 || len(rec.deliveries) != 1 {
+			t.Errorf("expected a delivery on the third attempt, got %d attempts", rec.attempts)
+		}
+
+		if rec.deliveries[0].header.Get(SignatureHeader) != "" {
+			t.Errorf("expected the payload not to be signed without a secret")
+		}
+	})
+
+	t.Run("give up", func(t *testing.T) {
+		rec, url := newRecorder(t, maxAttempts+1)
+
+		n := New(reg, []config.Notification{{URL: url}})
+		n.backoff = time.Millisecond
+		n.deliver(context.Background(), entry)
+
+		if rec.attempts != maxAttempts {
+			t.Errorf("expected %d attempts, got %d", maxAttempts, rec.attempts)
+		}
+	})
+
+	t.Run("packages", func(t *testing.T) {
+		var (
+			matching, matchingURL   = newRecorder(t, 0)
+			unrelated, unrelatedURL = newRecorder(t, 0)
+		)
+
+		New(reg, []config.Notification{
+			{URL: matchingURL, Packages: []string{"other"}},
+			{URL: unrelatedURL, Packages: []string{"acme"}},
+		}).deliver(context.Background(), entry)
+
+		if len(matching.deliveries) != 1 || len(matching.deliveries[0].entry.Packages) != 1 {
+			t.Errorf("expected the change of other.v1 to be delivered, got %v", matching.deliveries)
+		}
+
+		if unrelated.attempts != 0 {
+			t.Errorf("expected no delivery to targets of unchanged packages")
+		}
+	})
+}
+
+func TestCatchUp(t *testing.T) {
+	reg := newRegistry(t)
+	rec, url := newRecorder(t, 0)
+
+	n := New(reg, []config.Notification{{URL: url}})
+
+	// entries restored from the cache are not sent again
+	last := n.latest()
+	if last != 2 {
+		t.Fatalf("expected to start after snapshot 2, got %d", last)
+	}
+
+	n.catchUp(context.Background(), &last)
+
+	if rec.attempts != 0 {
+		t.Errorf("expected no entries to be replayed, got %d deliveries", rec.attempts)
+	}
+
+	// entries missed while not subscribed are sent
+	last = 1
+	n.catchUp(context.Background(), &last)
+
+	if len(rec.deliveries) != 1 || rec.deliveries[0].entry.SnapshotId != 2 || last != 2 {
+		t.Errorf("expected only snapshot 2 to be delivered, got %d deliveries, last %d", len(rec.deliveries), last)
+	}
+}
@@ -0,0 +1,401 @@
+package registry
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/bufbuild/protocompile/linker"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// ErrBreakingChange is returned for sources that have not been activated
+// because they contain wire-breaking changes.
+var ErrBreakingChange = errors.New("wire-breaking changes")
+
+// BreakingChangeKind classifies a BreakingChange.
+type BreakingChangeKind int
+
+const (
+	FieldRemoved BreakingChangeKind = iota + 1
+	FieldNumberChanged
+	FieldTypeChanged
+	FieldRenamed
+	MessageRemoved
+	MessageRenamed
+	EnumValueRemoved
+)
+
+func (kind BreakingChangeKind) String() string {
+	switch kind {
+	case FieldRemoved:
+		return "field removed"
+	case FieldNumberChanged:
+		return "field number changed"
+	case FieldTypeChanged:
+		return "field type changed"
+	case FieldRenamed:
+		return "field renamed"
+	case MessageRemoved:
+		return "message removed"
+	case MessageRenamed:
+		return "message renamed"
+	case EnumValueRemoved:
+		return "enum value removed"
+	}
+
+	return fmt.Sprintf("BreakingChangeKind(%d)", int(kind))
+}
+
+// BreakingChange describes an incompatible change between two versions of
+// a source.
+type BreakingChange struct {
+	Kind BreakingChangeKind
+
+	// Element is the full name of the removed or changed element in the
+	// previous version and File the file that declared it.
+	Element protoreflect.FullName
+	File    string
+
+	// Wire is set if data encoded using the previous version can no
+	// longer be decoded correctly.
+	Wire bool
+
+	// Description is a human readable description of the change.
+	Description string
+}
+
+func (change BreakingChange) String() string {
+	return change.Description
+}
+
+// hasWireBreaking reports whether any of changes is wire-breaking.
+func hasWireBreaking(changes []BreakingChange) bool {
+	for _, change := range changes {
+		if change.Wire {
+			return true
+		}
+	}
+
+	return false
+}
+
+// breakingChanges compares two versions of the files of a source and
+// returns all breaking changes, ordered by file and element.
+func breakingChanges(old, new linker.Files) []BreakingChange {
+	var (
+		changes     []BreakingChange
+		oldMessages = collectMessages(old)
+		newMessages = collectMessages(new)
+		removed     []protoreflect.MessageDescriptor
+		kept        []protoreflect.MessageDescriptor
+		added       = make(map[string][]protoreflect.MessageDescriptor)
+		renamed     = make(map[protoreflect.FullName]protoreflect.FullName)
+	)
+
+	for name, msg := range newMessages {
+		if _, ok := oldMessages[name]; !ok {
+			// messages without fields are all alike, so they are never
+			// considered to be renamed
+			if sig := messageSignature(msg); sig != "" {
+				added[sig] = append(added[sig], msg)
+			}
+		}
+	}
+
+	for name, msg := range oldMessages {
+		if _, ok := newMessages[name]; !ok {
+			// only report the outermost removed message
+			if parent, ok := msg.Parent().(protoreflect.MessageDescriptor); ok {
+				if _, exists := newMessages[parent.FullName()]; !exists {
+					continue
+				}
+			}
+
+			removed = append(removed, msg)
+
+			continue
+		}
+
+		kept = append(kept, msg)
+	}
+
+	for _, msg := range removed {
+		change := BreakingChange{
+			Kind:    MessageRemoved,
+			Element: msg.FullName(),
+			File:    msg.ParentFile().Path(),
+
+			// google.protobuf.Any values refer to messages by name
+			Wire:        true,
+			Description: fmt.Sprintf("message %s has been removed", msg.FullName()),
+		}
+
+		if candidates := added[messageSignature(msg)]; len(candidates) == 1 {
+			change.Kind = MessageRenamed
+			change.Description = fmt.Sprintf("message %s has been renamed to %s", msg.FullName(), candidates[0].FullName())
+
+			renamed[msg.FullName()] = candidates[0].FullName()
+		}
+
+		changes = append(changes, change)
+	}
+
+	// fields referring to a renamed message are covered by the rename
+	for _, msg := range kept {
+		changes = append(changes, fieldChanges(msg, newMessages[msg.FullName()], renamed)...)
+	}
+
+	newEnums := collectEnums(new)
+	for name, enum := range collectEnums(old) {
+		newEnum, ok := newEnums[name]
+
+		// enums nested in a removed message are covered by its removal
+		if parent, nested := enum.Parent().(protoreflect.MessageDescriptor); !ok && nested {
+			if _, exists := newMessages[parent.FullName()]; !exists {
+				continue
+			}
+		}
+
+		values := enum.Values()
+		for i := 0; i < values.Len(); i++ {
+			value := values.Get(i)
+
+			if !ok {
+				changes = append(changes, BreakingChange{
+					Kind:        EnumValueRemoved,
+					Element:     value.FullName(),
+					File:        enum.ParentFile().Path(),
+					Wire:        true,
+					Description: fmt.Sprintf("enum value %s (%d) has been removed along with enum %s", value.FullName(), value.Number(), enum.FullName()),
+				})
+
+				continue
+			}
+
+			if newEnum.Values().ByNumber(value.Number()) != nil {
+				continue
+			}
+
+			changes = append(changes, BreakingChange{
+				Kind:        EnumValueRemoved,
+				Element:     value.FullName(),
+				File:        enum.ParentFile().Path(),
+				Wire:        !newEnum.ReservedRanges().Has(value.Number()),
+				Description: fmt.Sprintf("enum value %s (%d) has been removed", value.FullName(), value.Number()),
+			})
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		if changes[i].File != changes[j].File {
+			return changes[i].File < changes[j].File
+		}
+
+		return changes[i].Element < changes[j].Element
+	})
+
+	return changes
+}
+
+// fieldChanges returns all breaking changes between the fields of two
+// versions of a message. renamed maps the names of renamed messages to
+// their new names.
+func fieldChanges(old, new protoreflect.MessageDescriptor, renamed map[protoreflect.FullName]protoreflect.FullName) []BreakingChange {
+	var (
+		changes []BreakingChange
+		fields  = old.Fields()
+		file    = old.ParentFile().Path()
+	)
+
+	for i := 0; i < fields.Len(); i++ {
+		field := fields.Get(i)
+		newField := new.Fields().ByNumber(field.Number())
+
+		if newField == nil {
+			if renumbered := new.Fields().ByName(field.Name()); renumbered != nil {
+				changes = append(changes, BreakingChange{
+					Kind:        FieldNumberChanged,
+					Element:     field.FullName(),
+					File:        file,
+					Wire:        true,
+					Description: fmt.Sprintf("field %s changed its number from %d to %d", field.FullName(), field.Number(), renumbered.Number()),
+				})
+
+				continue
+			}
+
+			changes = append(changes, BreakingChange{
+				Kind:        FieldRemoved,
+				Element:     field.FullName(),
+				File:        file,
+				Wire:        !new.ReservedRanges().Has(field.Number()),
+				Description: fmt.Sprintf("field %s (%d) has been removed", field.FullName(), field.Number()),
+			})
+
+			continue
+		}
+
+		if oldType, newType := fieldType(field), fieldType(newField); oldType != newType && !renamedType(field, newField, renamed) {
+			changes = append(changes, BreakingChange{
+				Kind:        FieldTypeChanged,
+				Element:     field.FullName(),
+				File:        file,
+				Wire:        !wireCompatible(field, newField),
+				Description: fmt.Sprintf("field %s changed its type from %s to %s", field.FullName(), oldType, newType),
+			})
+		}
+
+		if field.Name() != newField.Name() {
+			changes = append(changes, BreakingChange{
+				Kind:        FieldRenamed,
+				Element:     field.FullName(),
+				File:        file,
+				Description: fmt.Sprintf("field %s (%d) has been renamed to %s", field.FullName(), field.Number(), newField.Name()),
+			})
+		}
+	}
+
+	return changes
+}
+
+// fieldType returns a description of the type and cardinality of field.
+func fieldType(field protoreflect.FieldDescriptor) string {
+	if field.IsMap() {
+		return fmt.Sprintf("map<%s, %s>", fieldType(field.MapKey()), fieldType(field.MapValue()))
+	}
+
+	var name string
+	switch field.Kind() {
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		name = string(field.Message().FullName())
+	case protoreflect.EnumKind:
+		name = string(field.Enum().FullName())
+	default:
+		name = field.Kind().String()
+	}
+
+	if field.IsList() {
+		return "repeated " + name
+	}
+
+	return name
+}
+
+// renamedType reports whether the type of new only differs from the type of
+// old by referring to the renamed version of a message.
+func renamedType(old, new protoreflect.FieldDescriptor, renamed map[protoreflect.FullName]protoreflect.FullName) bool {
+	if old.IsMap() && new.IsMap() {
+		return fieldType(old.MapKey()) == fieldType(new.MapKey()) &&
+			(fieldType(old.MapValue()) == fieldType(new.MapValue()) || renamedType(old.MapValue(), new.MapValue(), renamed))
+	}
+
+	if old.IsMap() || new.IsMap() || old.IsList() != new.IsList() || old.Kind() != new.Kind() || old.Message() == nil {
+		return false
+	}
+
+	newName, ok := renamed[old.Message().FullName()]
+
+	return ok && newName == new.Message().FullName()
+}
+
+// wireGroups holds groups of scalar kinds that share the same wire
+// encoding.
+var wireGroups = [][]protoreflect.Kind{
+	{protoreflect.Int32Kind, protoreflect.Int64Kind, protoreflect.Uint32Kind, protoreflect.Uint64Kind, protoreflect.BoolKind, protoreflect.EnumKind},
+	{protoreflect.Sint32Kind, protoreflect.Sint64Kind},
+	{protoreflect.Fixed32Kind, protoreflect.Sfixed32Kind},
+	{protoreflect.Fixed64Kind, protoreflect.Sfixed64Kind},
+	{protoreflect.StringKind, protoreflect.BytesKind},
+}
+
+// wireCompatible reports whether values of old can be decoded as new.
+func wireCompatible(old, new protoreflect.FieldDescriptor) bool {
+	if old.IsList() != new.IsList() || old.IsMap() || new.IsMap() {
+		return false
+	}
+
+	for _, group := range wireGroups {
+		if slices.Contains(group, old.Kind()) && slices.Contains(group, new.Kind()) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// messageSignature returns a string describing the numbers and types of
+// all fields of msg. Messages with the same signature are considered to be
+// renamed versions of each other.
+func messageSignature(msg protoreflect.MessageDescriptor) string {
+	var (
+		fields = msg.Fields()
+		parts  = make([]string, fields.Len())
+	)
+
+	for i := 0; i < fields.Len(); i++ {
+		parts[i] = fmt.Sprintf("%d:%s", fields.Get(i).Number(), fieldType(fields.Get(i)))
+	}
+
+	sort.Strings(parts)
+
+	return strings.Join(parts, ";")
+}
+
+// collectMessages returns all messages declared in files, including nested
+// ones but without map entries, by full name.
+func collectMessages(files linker.Files) map[protoreflect.FullName]protoreflect.MessageDescriptor {
+	result := make(map[protoreflect.FullName]protoreflect.MessageDescriptor)
+
+	var walk func(messages protoreflect.MessageDescriptors)
+	walk = func(messages protoreflect.MessageDescriptors) {
+		for i := 0; i < messages.Len(); i++ {
+			msg := messages.Get(i)
+			if msg.IsMapEntry() {
+				continue
+			}
+
+			result[msg.FullName()] = msg
+			walk(msg.Messages())
+		}
+	}
+
+	for _, file := range files {
+		walk(file.Messages())
+	}
+
+	return result
+}
+
+// collectEnums returns all enums declared in files, including nested ones,
+// by full name.
+func collectEnums(files linker.Files) map[protoreflect.FullName]protoreflect.EnumDescriptor {
+	result := make(map[protoreflect.FullName]protoreflect.EnumDescriptor)
+
+	var walk func(desc interface {
+		Enums() protoreflect.EnumDescriptors
+		Messages() protoreflect.MessageDescriptors
+	})
+	walk = func(desc interface {
+		Enums() protoreflect.EnumDescriptors
+		Messages() protoreflect.MessageDescriptors
+	}) {
+		enums := desc.Enums()
+		for i := 0; i < enums.Len(); i++ {
+			result[enums.Get(i).FullName()] = enums.Get(i)
+		}
+
+		messages := desc.Messages()
+		for i := 0; i < messages.Len(); i++ {
+			walk(messages.Get(i))
+		}
+	}
+
+	for _, file := range files {
+		walk(file)
+	}
+
+	return result
+}
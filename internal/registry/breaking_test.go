@@ -0,0 +1,150 @@
+package registry
+
+import (
+	"testing"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+func TestBreakingChanges(t *testing.T) {
+	type change struct {
+		kind    BreakingChangeKind
+		element protoreflect.FullName
+		wire    bool
+	}
+
+	cases := []struct {
+		name     string
+		old, new string
+		want     []change
+	}{
+		{
+			name: "unchanged",
+			old:  `message A { string name = 1; }`,
+			new:  `message A { string name = 1; }`,
+		},
+		{
+			name: "field added",
+			old:  `message A { string name = 1; }`,
+			new:  `message A { string name = 1; int32 age = 2; }`,
+		},
+		{
+			name: "field removed",
+			old:  `message A { string name = 1; int32 age = 2; }`,
+			new:  `message A { string name = 1; }`,
+			want: []change{{FieldRemoved, "test.v1.A.age", true}},
+		},
+		{
+			name: "field removed and reserved",
+			old:  `message A { string name = 1; int32 age = 2; }`,
+			new:  `message A { string name = 1; reserved 2; }`,
+			want: []change{{FieldRemoved, "test.v1.A.age", false}},
+		},
+		{
+			name: "field renumbered",
+			old:  `message A { string name = 1; }`,
+			new:  `message A { string name = 2; }`,
+			want: []change{{FieldNumberChanged, "test.v1.A.name", true}},
+		},
+		{
+			name: "field renamed",
+			old:  `message A { string name = 1; }`,
+			new:  `message A { string title = 1; }`,
+			want: []change{{FieldRenamed, "test.v1.A.name", false}},
+		},
+		{
+			name: "compatible type",
+			old:  `message A { int32 count = 1; }`,
+			new:  `message A { int64 count = 1; }`,
+			want: []change{{FieldTypeChanged, "test.v1.A.count", false}},
+		},
+		{
+			name: "incompatible type",
+			old:  `message A { int32 count = 1; }`,
+			new:  `message A { string count = 1; }`,
+			want: []change{{FieldTypeChanged, "test.v1.A.count", true}},
+		},
+		{
+			name: "repeated",
+			old:  `message A { string tags = 1; }`,
+			new:  `message A { repeated string tags = 1; }`,
+			want: []change{{FieldTypeChanged, "test.v1.A.tags", true}},
+		},
+		{
+			name: "message removed",
+			old:  `message A { string name = 1; message B { string name = 1; } } message C {}`,
+			new:  `message C {}`,
+			want: []change{{MessageRemoved, "test.v1.A", true}},
+		},
+		{
+			name: "message renamed",
+			old:  `message A { string name = 1; }`,
+			new:  `message B { string name = 1; }`,
+			want: []change{{MessageRenamed, "test.v1.A", true}},
+		},
+		{
+			name: "empty message removed",
+			old:  `message A {} message C {}`,
+			new:  `message B {} message C {}`,
+			want: []change{{MessageRemoved, "test.v1.A", true}},
+		},
+		{
+			name: "enum value removed",
+			old:  `enum E { E_UNSPECIFIED = 0; E_ONE = 1; E_TWO = 2; }`,
+			new:  `enum E { E_UNSPECIFIED = 0; E_ONE = 1; reserved 2; }`,
+			want: []change{{EnumValueRemoved, "test.v1.E_TWO", false}},
+		},
+		{
+			name: "message renamed with references",
+			old:  `message A { string name = 1; } message C { A a = 1; repeated A list = 2; map<string, A> byName = 3; }`,
+			new:  `message B { string name = 1; } message C { B a = 1; repeated B list = 2; map<string, B> byName = 3; }`,
+			want: []change{{MessageRenamed, "test.v1.A", true}},
+		},
+		{
+			name: "enum removed",
+			old:  `enum E { E_UNSPECIFIED = 0; E_ONE = 1; } message A { enum N { N_UNSPECIFIED = 0; } } message C {}`,
+			new:  `message C {}`,
+			want: []change{
+				{MessageRemoved, "test.v1.A", true},
+				{EnumValueRemoved, "test.v1.E_ONE", true},
+				{EnumValueRemoved, "test.v1.E_UNSPECIFIED", true},
+			},
+		},
+		{
+			name: "ordered",
+			old:  `message B { string b = 1; } message A { string a = 1; string b = 2; }`,
+			new:  `message B {} message A {}`,
+			want: []change{
+				{FieldRemoved, "test.v1.A.a", true},
+				{FieldRemoved, "test.v1.A.b", true},
+				{FieldRemoved, "test.v1.B.b", true},
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			compile := func(body string) map[string]string {
+				return map[string]string{
+					"test/v1/test.proto": `syntax = "proto3"; package test.v1; ` + body,
+				}
+			}
+
+			got := breakingChanges(compileFiles(t, compile(c.old)), compileFiles(t, compile(c.new)))
+
+			if len(got) != len(c.want) {
+				t.Fatalf("got %d changes, want %d: %v", len(got), len(c.want), got)
+			}
+
+			for idx, want := range c.want {
+				if got[idx].Kind != want.kind || got[idx].Element != want.element || got[idx].Wire != want.wire {
+					t.Errorf("change %d: got %s %s (wire=%t), want %s %s (wire=%t)", idx, got[idx].Kind, got[idx].Element, got[idx].Wire, want.kind, want.element, want.wire)
+				}
+
+				if got[idx].File != "test/v1/test.proto" {
+					t.Errorf("change %d: got file %q", idx, got[idx].File)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,340 @@
+package registry
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/ghodss/yaml"
+)
+
+// bufModule is a directory of a buf module or workspace that is used as an
+// import path.
+type bufModule struct {
+	// dir is the absolute path of the module root.
+	dir string
+
+	// includes holds directories, relative to dir, that make up the
+	// module. If empty, the whole directory is part of the module.
+	includes []string
+
+	// excludes holds directories, relative to dir, that are not part of
+	// the module.
+	excludes []string
+}
+
+// excluded reports whether the file or directory at rel, relative to the
+// module root, is excluded from the module. Directories containing an
+// included directory are not excluded so they can be walked.
+func (mod bufModule) excluded(rel string, dir bool) bool {
+	for _, ex := range mod.excludes {
+		if within(rel, ex) {
+			return true
+		}
+	}
+
+	if len(mod.includes) == 0 {
+		return false
+	}
+
+	for _, inc := range mod.includes {
+		if within(rel, inc) || (dir && (rel == "." || strings.HasPrefix(inc, rel+"/"))) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// within reports whether the slash separated path rel equals dir or is
+// located below it.
+func within(rel string, dir string) bool {
+	return rel == dir || strings.HasPrefix(rel, dir+"/")
+}
+
+// bufLayout describes the buf modules found in a source.
+type bufLayout struct {
+	modules []bufModule
+
+	// names holds the names of all named modules, like
+	// buf.build/acme/petapis, and deps the names of all modules they
+	// depend on as declared in buf.yaml or buf.lock.
+	names []string
+	deps  []string
+}
+
+// bufConfig holds the parts of buf.yaml files (v1, v1beta1 and v2) that are
+// relevant for compiling a module.
+type bufConfig struct {
+	Version string   `json:"version"`
+	Name    string   `json:"name"`
+	Deps    []string `json:"deps"`
+
+	// v1 and v1beta1
+	Build struct {
+		Roots    []string `json:"roots"`
+		Excludes []string `json:"excludes"`
+	} `json:"build"`
+
+	// v2
+	Modules []struct {
+		Path     string   `json:"path"`
+		Name     string   `json:"name"`
+		Includes []string `json:"includes"`
+		Excludes []string `json:"excludes"`
+	} `json:"modules"`
+}
+
+// bufWork holds the content of a buf.work.yaml file.
+type bufWork struct {
+	Version     string   `json:"version"`
+	Directories []string `json:"directories"`
+}
+
+// bufLock holds the content of a buf.lock file (v1 and v2).
+type bufLock struct {
+	Deps []struct {
+		// v2
+		Name string `json:"name"`
+
+		// v1
+		Remote     string `json:"remote"`
+		Owner      string `json:"owner"`
+		Repository string `json:"repository"`
+	} `json:"deps"`
+}
+
+// readBufLayout returns the modules defined by the buf.work.yaml or, if
+// there is none, the buf.yaml in root. Configurations in sub-directories,
+// like modules below examples/ or testdata/, are ignored so all files of
+// the source are still compiled from root. It returns nil if root does not
+// contain a buf configuration.
+func readBufLayout(root string) (*bufLayout, error) {
+	var (
+		layout = &bufLayout{}
+		seen   = make(map[string]struct{})
+	)
+
+	work, err := readYAML[bufWork](filepath.Join(root, "buf.work.yaml"))
+	if err != nil {
+		return nil, err
+	}
+
+	if work != nil {
+		for _, sub := range work.Directories {
+			if err := layout.addModuleDir(root, sub, seen); err != nil {
+				return nil, err
+			}
+		}
+	} else {
+		cfg, err := readYAML[bufConfig](filepath.Join(root, "buf.yaml"))
+		if err != nil {
+			return nil, err
+		}
+
+		if cfg != nil {
+			if err := layout.addConfig(root, cfg, seen); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if len(layout.modules) == 0 {
+		return nil, nil
+	}
+
+	return layout, nil
+}
+
+// addModuleDir adds the module in the workspace directory sub of base.
+// The module may have its own (v1) buf.yaml.
+func (layout *bufLayout) addModuleDir(base string, sub string, seen map[string]struct{}) error {
+	dir, err := subDir(base, sub)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := readYAML[bufConfig](filepath.Join(dir, "buf.yaml"))
+	if err != nil {
+		return err
+	}
+
+	if cfg == nil {
+		layout.add(bufModule{dir: dir}, "", seen)
+
+		return nil
+	}
+
+	return layout.addConfig(dir, cfg, seen)
+}
+
+// addConfig adds the modules defined by the buf.yaml in dir.
+func (layout *bufLayout) addConfig(dir string, cfg *bufConfig, seen map[string]struct{}) error {
+	layout.deps = append(layout.deps, cfg.Deps...)
+
+	lock, err := readYAML[bufLock](filepath.Join(dir, "buf.lock"))
+	if err != nil {
+		return err
+	}
+
+	if lock != nil {
+		for _, dep := range lock.Deps {
+			name := dep.Name
+			if name == "" {
+				name = path.Join(dep.Remote, dep.Owner, dep.Repository)
+			}
+
+			layout.deps = append(layout.deps, name)
+		}
+	}
+
+	if cfg.Version != "v2" {
+		// v1beta1 allows multiple roots within a single module.
+		roots := cfg.Build.Roots
+		if len(roots) == 0 {
+			roots = []string{"."}
+		}
+
+		for _, root := range roots {
+			mod := bufModule{}
+
+			mod.dir, err = subDir(dir, root)
+			if err != nil {
+				return err
+			}
+
+			// v1 excludes are relative to the buf.yaml
+			mod.excludes = relativeDirs(dir, mod.dir, cfg.Build.Excludes)
+
+			layout.add(mod, cfg.Name, seen)
+		}
+
+		return nil
+	}
+
+	if len(cfg.Modules) == 0 {
+		layout.add(bufModule{dir: dir}, cfg.Name, seen)
+
+		return nil
+	}
+
+	for _, m := range cfg.Modules {
+		mod := bufModule{}
+
+		mod.dir, err = subDir(dir, m.Path)
+		if err != nil {
+			return err
+		}
+
+		// v2 includes and excludes are relative to the buf.yaml as well
+		mod.includes = relativeDirs(dir, mod.dir, m.Includes)
+		mod.excludes = relativeDirs(dir, mod.dir, m.Excludes)
+
+		layout.add(mod, m.Name, seen)
+	}
+
+	return nil
+}
+
+func (layout *bufLayout) add(mod bufModule, name string, seen map[string]struct{}) {
+	if _, ok := seen[mod.dir]; ok {
+		return
+	}
+	seen[mod.dir] = struct{}{}
+
+	layout.modules = append(layout.modules, mod)
+
+	if name != "" {
+		layout.names = append(layout.names, name)
+	}
+}
+
+// subDir joins base and the relative path sub and ensures the result does
+// not escape base.
+func subDir(base string, sub string) (string, error) {
+	clean := path.Clean(filepath.ToSlash(sub))
+	if path.IsAbs(clean) || clean == ".." || strings.HasPrefix(clean, "../") {
+		return "", fmt.Errorf("invalid module directory %q", sub)
+	}
+
+	return filepath.Join(base, filepath.FromSlash(clean)), nil
+}
+
+// relativeDirs converts directories relative to the directory of a buf.yaml
+// to paths relative to the module root dir. Directories outside of the
+// module are dropped.
+func relativeDirs(base string, dir string, dirs []string) []string {
+	var result []string
+
+	for _, d := range dirs {
+		rel, err := filepath.Rel(dir, filepath.Join(base, filepath.FromSlash(path.Clean(d))))
+		if err != nil {
+			continue
+		}
+
+		rel = filepath.ToSlash(rel)
+		if rel == ".." || strings.HasPrefix(rel, "../") {
+			continue
+		}
+
+		result = append(result, rel)
+	}
+
+	return result
+}
+
+// readYAML parses the YAML file at path. It returns nil if the file does
+// not exist.
+func readYAML[T any](path string) (*T, error) {
+	blob, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	var result T
+	if err := yaml.Unmarshal(blob, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", filepath.Base(path), err)
+	}
+
+	return &result, nil
+}
+
+// moduleName strips the reference from a buf dependency like
+// buf.build/acme/petapis:v1.
+func moduleName(dep string) string {
+	name, _, _ := strings.Cut(dep, ":")
+
+	return name
+}
+
+// checkBufDependencies warns about buf dependencies of fetched sources that
+// are not provided by any configured source. Must only be called from the
+// polling loop.
+func (reg *Registry) checkBufDependencies(fetched []*fetchedSource) {
+	provided := make(map[string]struct{})
+	for _, src := range reg.sources {
+		for _, name := range append(src.bufModules, src.BufModules...) {
+			provided[moduleName(name)] = struct{}{}
+		}
+	}
+
+	for idx, res := range fetched {
+		if res == nil {
+			continue
+		}
+
+		for _, dep := range res.bufDeps {
+			if _, ok := provided[moduleName(dep)]; !ok {
+				slog.Warn("buf dependency is not provided by any source, imports from it cannot be resolved", "source", reg.sources[idx].Name, "dependency", moduleName(dep))
+			}
+		}
+	}
+}
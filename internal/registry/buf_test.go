@@ -0,0 +1,207 @@
+package registry
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestReadBufLayout(t *testing.T) {
+	type module struct {
+		dir      string
+		includes []string
+		excludes []string
+	}
+
+	cases := []struct {
+		name    string
+		files   map[string]string
+		modules []module
+		names   []string
+		deps    []string
+		err     bool
+	}{
+		{
+			name:  "no configuration",
+			files: map[string]string{"acme/v1/a.proto": ""},
+		},
+		{
+			name: "nested configuration",
+			files: map[string]string{
+				"acme/v1/a.proto":        "",
+				"examples/buf.yaml":      "version: v1\nname: buf.build/acme/examples\n",
+				"examples/ex/v1/a.proto": "",
+			},
+		},
+		{
+			name: "v1",
+			files: map[string]string{
+				"buf.yaml": "version: v1\nname: buf.build/acme/apis\ndeps:\n  - buf.build/googleapis/googleapis\nbuild:\n  excludes:\n    - acme/internal\n",
+				"buf.lock": "version: v1\ndeps:\n  - remote: buf.build\n    owner: bufbuild\n    repository: protovalidate\n",
+			},
+			modules: []module{{dir: ".", excludes: []string{"acme/internal"}}},
+			names:   []string{"buf.build/acme/apis"},
+			deps:    []string{"buf.build/googleapis/googleapis", "buf.build/bufbuild/protovalidate"},
+		},
+		{
+			name: "v1beta1 roots",
+			files: map[string]string{
+				"buf.yaml": "version: v1beta1\nbuild:\n  roots:\n    - proto\n    - vendor\n  excludes:\n    - proto/internal\n",
+			},
+			modules: []module{
+				{dir: "proto", excludes: []string{"internal"}},
+				{dir: "vendor"},
+			},
+		},
+		{
+			name: "v2",
+			files: map[string]string{
+				"buf.yaml": "version: v2\nmodules:\n  - path: proto\n    name: buf.build/acme/apis\n    excludes:\n      - proto/internal\n  - path: vendor\n",
+				"buf.lock": "version: v2\ndeps:\n  - name: buf.build/bufbuild/protovalidate\n",
+			},
+			modules: []module{
+				{dir: "proto", excludes: []string{"internal"}},
+				{dir: "vendor"},
+			},
+			names: []string{"buf.build/acme/apis"},
+			deps:  []string{"buf.build/bufbuild/protovalidate"},
+		},
+		{
+			name: "v2 includes",
+			files: map[string]string{
+				"buf.yaml": "version: v2\nmodules:\n  - path: proto\n    includes:\n      - proto/acme\n    excludes:\n      - proto/acme/internal\n",
+			},
+			modules: []module{
+				{dir: "proto", includes: []string{"acme"}, excludes: []string{"acme/internal"}},
+			},
+		},
+		{
+			name: "workspace",
+			files: map[string]string{
+				"buf.work.yaml":     "version: v1\ndirectories:\n  - proto\n  - vendor\n",
+				"buf.yaml":          "version: v1\nname: buf.build/acme/ignored\n",
+				"proto/buf.yaml":    "version: v1\nname: buf.build/acme/apis\n",
+				"vendor/a.proto":    "",
+				"examples/buf.yaml": "version: v1\n",
+			},
+			modules: []module{{dir: "proto"}, {dir: "vendor"}},
+			names:   []string{"buf.build/acme/apis"},
+		},
+		{
+			name: "escaping module",
+			files: map[string]string{
+				"buf.work.yaml": "version: v1\ndirectories:\n  - ../other\n",
+			},
+			err: true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			root := t.TempDir()
+			writeFiles(t, root, c.files)
+
+			layout, err := readBufLayout(root)
+			if c.err {
+				if err == nil {
+					t.Fatalf("expected an error")
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if c.modules == nil {
+				if layout != nil {
+					t.Fatalf("expected no layout, got %+v", layout)
+				}
+
+				return
+			}
+
+			if layout == nil {
+				t.Fatalf("expected a layout")
+			}
+
+			var modules []module
+			for _, mod := range layout.modules {
+				rel, _ := filepath.Rel(root, mod.dir)
+				modules = append(modules, module{dir: filepath.ToSlash(rel), includes: mod.includes, excludes: mod.excludes})
+			}
+
+			if !slices.EqualFunc(modules, c.modules, func(a, b module) bool {
+				return a.dir == b.dir && slices.Equal(a.includes, b.includes) && slices.Equal(a.excludes, b.excludes)
+			}) {
+				t.Errorf("modules: got %+v, want %+v", modules, c.modules)
+			}
+
+			if !slices.Equal(layout.names, c.names) {
+				t.Errorf("names: got %v, want %v", layout.names, c.names)
+			}
+
+			if !slices.Equal(layout.deps, c.deps) {
+				t.Errorf("deps: got %v, want %v", layout.deps, c.deps)
+			}
+		})
+	}
+}
+
+func TestBufModuleExcluded(t *testing.T) {
+	mod := bufModule{
+		includes: []string{"acme/v1"},
+		excludes: []string{"acme/v1/internal"},
+	}
+
+	cases := []struct {
+		rel      string
+		dir      bool
+		excluded bool
+	}{
+		{rel: ".", dir: true},
+		{rel: "acme", dir: true},
+		{rel: "acme/v1", dir: true},
+		{rel: "acme/v1/a.proto"},
+		{rel: "acme/a.proto", excluded: true},
+		{rel: "acme/v2", dir: true, excluded: true},
+		{rel: "acme/v1/internal", dir: true, excluded: true},
+		{rel: "acme/v1/internal/a.proto", excluded: true},
+		{rel: "other", dir: true, excluded: true},
+	}
+
+	for _, c := range cases {
+		if got := mod.excluded(c.rel, c.dir); got != c.excluded {
+			t.Errorf("excluded(%q, %t) = %t, want %t", c.rel, c.dir, got, c.excluded)
+		}
+	}
+}
+
+func TestReadBufLayoutSymlink(t *testing.T) {
+	var (
+		dir  = t.TempDir()
+		root = filepath.Join(dir, "protos")
+		link = filepath.Join(dir, "link")
+	)
+
+	writeFiles(t, root, map[string]string{
+		"buf.yaml":           "version: v2\nmodules:\n  - path: proto\n",
+		"proto/a/v1/a.proto": "",
+	})
+
+	// go-getter symlinks local directories instead of copying them.
+	if err := os.Symlink(root, link); err != nil {
+		t.Fatal(err)
+	}
+
+	layout, err := readBufLayout(link)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if layout == nil || len(layout.modules) != 1 || layout.modules[0].dir != filepath.Join(link, "proto") {
+		t.Fatalf("expected the module below the symlink, got %+v", layout)
+	}
+}
@@ -0,0 +1,270 @@
+package registry
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/bufbuild/protocompile/linker"
+	"github.com/tierklinik-dobersberg/pbtype-server/internal/config"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// cacheMetadata is stored next to the serialized FileDescriptorSet of each
+// cached source.
+type cacheMetadata struct {
+	Source   string    `json:"source"`
+	Config   string    `json:"config"`
+	Compiled time.Time `json:"compiled"`
+	Files    []string  `json:"files"`
+
+	// Revision is the git commit the files have been compiled from and
+	// ContentHash the hash over the fetched files.
+	Revision    string `json:"revision,omitempty"`
+	ContentHash string `json:"contentHash,omitempty"`
+
+	// Fetched is the time the source has been fetched and Origins maps
+	// the paths of files to their path within the source.
+	Fetched time.Time         `json:"fetched"`
+	Origins map[string]string `json:"origins,omitempty"`
+}
+
+// cacheKey returns the file name prefix used to cache the source with the
+// given name.
+func cacheKey(name string) string {
+	sum := sha256.Sum256([]byte(name))
+
+	return hex.EncodeToString(sum[:16])
+}
+
+// configHash returns a hash over the configuration of src that determines
+// which files are compiled. Cached files are discarded if it changes.
+func configHash(src config.Source) string {
+	// marshaling a struct of strings does not fail
+	blob, _ := json.Marshal(struct {
+		URL        string   `json:"url"`
+		Ref        string   `json:"ref"`
+		ImportRoot string   `json:"importRoot"`
+		Include    []string `json:"include"`
+		Exclude    []string `json:"exclude"`
+	}{src.URL, src.Ref, src.ImportRoot, src.Include, src.Exclude})
+
+	sum := sha256.Sum256(blob)
+
+	return hex.EncodeToString(sum[:])
+}
+
+// storeCache writes the files of src, compiled from res, to the cache
+// directory. It is a no-op if no cache directory is configured.
+func (reg *Registry) storeCache(src *source, res *fetchedSource, files linker.Files, compiled time.Time) error {
+	if reg.cacheDir == "" {
+		return nil
+	}
+
+	set := &descriptorpb.FileDescriptorSet{}
+	meta := cacheMetadata{
+		Source:      config.RedactURL(src.URL),
+		Config:      configHash(src.Source),
+		Compiled:    compiled,
+		Revision:    res.revision,
+		ContentHash: res.hash,
+		Fetched:     res.fetched,
+		Origins:     res.origins,
+	}
+
+	set.File = filesToProtos(files)
+
+	for _, file := range files {
+		meta.Files = append(meta.Files, file.Path())
+	}
+
+	blob, err := proto.Marshal(set)
+	if err != nil {
+		return fmt.Errorf("failed to marshal file descriptor set: %w", err)
+	}
+
+	metaBlob, err := json.MarshalIndent(meta, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal cache metadata: %w", err)
+	}
+
+	if err := os.MkdirAll(reg.cacheDir, 0o755); err != nil {
+		return fmt.Errorf("failed to create cache directory: %w", err)
+	}
+
+	base := filepath.Join(reg.cacheDir, cacheKey(src.Name))
+
+	// Write the descriptor set before the metadata file since only sources
+	// with metadata are loaded from the cache.
+	if err := writeFileAtomic(base+".binpb", blob); err != nil {
+		return err
+	}
+
+	return writeFileAtomic(base+".json", metaBlob)
+}
+
+// readCache reads the cached descriptors of src. It returns nil if the
+// source is not cached or has been cached using a different configuration.
+func (reg *Registry) readCache(src *source) (*fetchedSource, *cacheMetadata, error) {
+	base := filepath.Join(reg.cacheDir, cacheKey(src.Name))
+
+	metaBlob, err := os.ReadFile(base + ".json")
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil, nil
+		}
+
+		return nil, nil, err
+	}
+
+	var meta cacheMetadata
+	if err := json.Unmarshal(metaBlob, &meta); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse cache metadata: %w", err)
+	}
+
+	if meta.Fetched.IsZero() {
+		return nil, nil, errors.New("invalid cache metadata: missing fetch time")
+	}
+
+	if meta.Config != configHash(src.Source) {
+		slog.Info("ignoring cached protobuf source with outdated configuration", "source", src.Name)
+
+		return nil, nil, nil
+	}
+
+	blob, err := os.ReadFile(base + ".binpb")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var set descriptorpb.FileDescriptorSet
+	if err := proto.Unmarshal(blob, &set); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse file descriptor set: %w", err)
+	}
+
+	return newProtoSource(set.File), &meta, nil
+}
+
+// loadCache loads all cached sources and activates them as the initial
+// snapshot. It is a no-op if no cache directory is configured.
+func (reg *Registry) loadCache(ctx context.Context) {
+	if reg.cacheDir == "" {
+		return
+	}
+
+	var (
+		cached = make([]*fetchedSource, len(reg.sources))
+		errs   = make([]error, len(reg.sources))
+		metas  = make([]*cacheMetadata, len(reg.sources))
+	)
+
+	for idx, src := range reg.sources {
+		res, meta, err := reg.readCache(src)
+		if err != nil {
+			slog.Error("failed to read cached protobuf source", "source", src.Name, "error", err)
+
+			continue
+		}
+
+		cached[idx] = res
+		metas[idx] = meta
+	}
+
+	current := reg.sourceFiles()
+
+	if compiled := reg.compileFetched(ctx, cached, errs, current); compiled == 0 {
+		return
+	}
+
+	reg.l.Lock()
+
+	for idx, src := range reg.sources {
+		if cached[idx] != nil && errs[idx] == nil {
+			src.setFiles(current[idx])
+			src.status.LastSuccess = metas[idx].Compiled
+			src.revision = metas[idx].Revision
+			src.contentHash = metas[idx].ContentHash
+
+			src.provenance = src.newProvenance(current[idx], metas[idx].Origins, src.revision, metas[idx].Fetched)
+
+			if src.status.LastSuccess.After(reg.status.LastSuccess) {
+				reg.status.LastSuccess = src.status.LastSuccess
+			}
+
+			slog.Info("loaded protobuf source from cache", "source", src.Name, "compiled", metas[idx].Compiled, "revision", src.revision, "files", len(src.files))
+		}
+	}
+
+	prev, snap := reg.activate()
+	reg.l.Unlock()
+
+	reg.recordChangelog(prev, snap)
+}
+
+// writeFileAtomic writes blob to a temporary file and renames it to path.
+func writeFileAtomic(path string, blob []byte) error {
+	tmp := path + ".tmp"
+
+	if err := os.WriteFile(tmp, blob, 0o644); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp, path)
+}
+
+// writeQueue runs queued writes one after another, in the order they have
+// been queued, without blocking the caller.
+type writeQueue struct {
+	l       sync.Mutex
+	queue   []func()
+	running bool
+	pending sync.WaitGroup
+}
+
+// enqueue queues fn and starts a worker if none is running.
+func (q *writeQueue) enqueue(fn func()) {
+	q.l.Lock()
+	defer q.l.Unlock()
+
+	q.pending.Add(1)
+	q.queue = append(q.queue, fn)
+
+	if !q.running {
+		q.running = true
+
+		go q.run()
+	}
+}
+
+func (q *writeQueue) run() {
+	for {
+		q.l.Lock()
+		if len(q.queue) == 0 {
+			q.running = false
+			q.l.Unlock()
+
+			return
+		}
+
+		fn := q.queue[0]
+		q.queue = q.queue[1:]
+		q.l.Unlock()
+
+		fn()
+		q.pending.Done()
+	}
+}
+
+// wait blocks until all queued writes are done.
+func (q *writeQueue) wait() {
+	q.pending.Wait()
+}
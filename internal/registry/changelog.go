@@ -0,0 +1,308 @@
+package registry
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/bufbuild/protocompile/linker"
+)
+
+// ChangelogEntry describes how the served packages changed when a snapshot
+// has been activated.
+type ChangelogEntry struct {
+	// SnapshotID and SnapshotHash identify the activated snapshot.
+	SnapshotID   uint64 `json:"snapshotId"`
+	SnapshotHash string `json:"snapshotHash"`
+
+	// PreviousSnapshotID and PreviousSnapshotHash identify the snapshot
+	// that has been replaced.
+	PreviousSnapshotID   uint64 `json:"previousSnapshotId"`
+	PreviousSnapshotHash string `json:"previousSnapshotHash"`
+
+	// Time is the time the snapshot has been activated.
+	Time time.Time `json:"time"`
+
+	// Packages holds all changed packages, ordered by name.
+	Packages []PackageChange `json:"packages"`
+}
+
+// Title returns a short, human readable summary of the entry, like
+// "Snapshot 12: changed foo.v1, added bar.v1".
+func (entry ChangelogEntry) Title() string {
+	parts := make([]string, len(entry.Packages))
+	for idx, pkg := range entry.Packages {
+		parts[idx] = fmt.Sprintf("%s %s", pkg.Change, pkg.name())
+	}
+
+	return fmt.Sprintf("Snapshot %d: %s", entry.SnapshotID, strings.Join(parts, ", "))
+}
+
+// Filter returns a copy of entry that only contains changes of the given
+// packages and their sub-packages. If packages is empty, entry is returned
+// unchanged.
+func (entry ChangelogEntry) Filter(packages []string) ChangelogEntry {
+	if len(packages) == 0 {
+		return entry
+	}
+
+	result := entry
+	result.Packages = nil
+
+	for _, pkg := range entry.Packages {
+		for _, name := range packages {
+			if pkg.Package == name || strings.HasPrefix(pkg.Package, name+".") {
+				result.Packages = append(result.Packages, pkg)
+
+				break
+			}
+		}
+	}
+
+	return result
+}
+
+// PackageChange describes how a single package changed.
+type PackageChange struct {
+	Package string `json:"package"`
+
+	// Change is ElementAdded or ElementRemoved if the package has not been
+	// served before or is no longer served.
+	Change DiffChange `json:"change"`
+
+	// Entries holds all elements of the package that have been added,
+	// removed or changed.
+	Entries []DiffEntry `json:"entries"`
+}
+
+// Summary returns a short description of the change, like
+// "2 added, 1 changed".
+func (change PackageChange) Summary() string {
+	counts := make(map[DiffChange]int)
+	for _, entry := range change.Entries {
+		counts[entry.Change]++
+	}
+
+	var parts []string
+	for _, kind := range []DiffChange{ElementAdded, ElementRemoved, ElementChanged} {
+		if counts[kind] > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", counts[kind], kind))
+		}
+	}
+
+	return strings.Join(parts, ", ")
+}
+
+func (change PackageChange) name() string {
+	if change.Package == "" {
+		return "(no package)"
+	}
+
+	return change.Package
+}
+
+// Changelog returns all retained changelog entries, newest first.
+func (reg *Registry) Changelog() []ChangelogEntry {
+	reg.l.RLock()
+	defer reg.l.RUnlock()
+
+	result := make([]ChangelogEntry, len(reg.changelog))
+	for idx, entry := range reg.changelog {
+		result[len(result)-1-idx] = entry
+	}
+
+	return result
+}
+
+// SubscribeChangelog returns a channel that receives each new changelog
+// entry. Like for Subscribe, the channel is closed if the subscriber does
+// not keep up or once cancel is called. Subscribers may use Changelog to
+// catch up on entries they missed.
+func (reg *Registry) SubscribeChangelog() (entries <-chan ChangelogEntry, cancel func()) {
+	reg.l.Lock()
+	defer reg.l.Unlock()
+
+	ch := make(chan ChangelogEntry, subscriberBuffer)
+
+	if reg.changelogSubscribers == nil {
+		reg.changelogSubscribers = make(map[chan ChangelogEntry]struct{})
+	}
+	reg.changelogSubscribers[ch] = struct{}{}
+
+	cancel = func() {
+		reg.l.Lock()
+		defer reg.l.Unlock()
+
+		if _, ok := reg.changelogSubscribers[ch]; ok {
+			delete(reg.changelogSubscribers, ch)
+			close(ch)
+		}
+	}
+
+	return ch, cancel
+}
+
+// recordChangelog adds a changelog entry describing the changes between
+// prev and snap and notifies all changelog subscribers. Nothing is recorded
+// if prev or snap is nil or if no element changed. The files are diffed
+// before reg.l is taken, so callers must not hold it. It must only be called
+// from the polling loop so entries are recorded in order.
+func (reg *Registry) recordChangelog(prev, snap *Snapshot) {
+	if prev == nil || snap == nil {
+		return
+	}
+
+	entries := diffFiles(prev.files, snap.files)
+	if len(entries) == 0 {
+		return
+	}
+
+	entry := ChangelogEntry{
+		SnapshotID:           snap.ID,
+		SnapshotHash:         snap.Hash,
+		PreviousSnapshotID:   prev.ID,
+		PreviousSnapshotHash: prev.Hash,
+		Time:                 snap.Created,
+		Packages:             groupByPackage(entries, packagesOf(prev.files), packagesOf(snap.files)),
+	}
+
+	reg.l.Lock()
+	defer reg.l.Unlock()
+
+	reg.changelog = append(reg.changelog, entry)
+	if n := len(reg.changelog); n > reg.changelogSize {
+		reg.changelog = reg.changelog[n-reg.changelogSize:]
+	}
+
+	slog.Info("recorded changelog entry", "snapshot", snap.ID, "packages", len(entry.Packages))
+
+	for ch := range reg.changelogSubscribers {
+		select {
+		case ch <- entry:
+		default:
+			slog.Warn("dropping slow changelog subscriber")
+			delete(reg.changelogSubscribers, ch)
+			close(ch)
+		}
+	}
+
+	if reg.cacheDir != "" {
+		blob, err := json.MarshalIndent(reg.changelog, "", "  ")
+		if err != nil {
+			slog.Error("failed to marshal changelog", "error", err)
+
+			return
+		}
+
+		reg.writes.enqueue(func() {
+			reg.storeChangelog(blob)
+		})
+	}
+}
+
+// groupByPackage groups entries by the package of the file that declares
+// the element. oldPackages and newPackages hold the packages served before
+// and after the change.
+func groupByPackage(entries []DiffEntry, oldPackages, newPackages map[string]struct{}) []PackageChange {
+	byPackage := make(map[string]*PackageChange)
+
+	for _, entry := range entries {
+		change, ok := byPackage[entry.Package]
+		if !ok {
+			change = &PackageChange{
+				Package: entry.Package,
+				Change:  ElementChanged,
+			}
+
+			_, existed := oldPackages[entry.Package]
+			_, exists := newPackages[entry.Package]
+
+			switch {
+			case !existed:
+				change.Change = ElementAdded
+			case !exists:
+				change.Change = ElementRemoved
+			}
+
+			byPackage[entry.Package] = change
+		}
+
+		change.Entries = append(change.Entries, entry)
+	}
+
+	result := make([]PackageChange, 0, len(byPackage))
+	for _, change := range byPackage {
+		result = append(result, *change)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Package < result[j].Package
+	})
+
+	return result
+}
+
+func packagesOf(files linker.Files) map[string]struct{} {
+	result := make(map[string]struct{})
+	for _, file := range files {
+		result[string(file.Package())] = struct{}{}
+	}
+
+	return result
+}
+
+// changelogPath returns the path of the persisted changelog.
+func (reg *Registry) changelogPath() string {
+	return filepath.Join(reg.cacheDir, "changelog.json")
+}
+
+// storeChangelog persists the marshaled changelog in the cache directory.
+func (reg *Registry) storeChangelog(blob []byte) {
+	if err := os.MkdirAll(reg.cacheDir, 0o755); err != nil {
+		slog.Error("failed to create cache directory", "error", err)
+
+		return
+	}
+
+	if err := writeFileAtomic(reg.changelogPath(), blob); err != nil {
+		slog.Error("failed to store changelog", "error", err)
+	}
+}
+
+// loadChangelog restores the changelog persisted in the cache directory.
+func (reg *Registry) loadChangelog() {
+	if reg.cacheDir == "" {
+		return
+	}
+
+	blob, err := os.ReadFile(reg.changelogPath())
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			slog.Error("failed to read changelog", "error", err)
+		}
+
+		return
+	}
+
+	var entries []ChangelogEntry
+	if err := json.Unmarshal(blob, &entries); err != nil {
+		slog.Error("failed to parse changelog", "error", err)
+
+		return
+	}
+
+	if n := len(entries); n > reg.changelogSize {
+		entries = entries[n-reg.changelogSize:]
+	}
+
+	reg.l.Lock()
+	defer reg.l.Unlock()
+
+	reg.changelog = entries
+}
@@ -0,0 +1,74 @@
+package registry
+
+import (
+	"testing"
+
+	"github.com/bufbuild/protocompile/linker"
+	"github.com/tierklinik-dobersberg/pbtype-server/internal/config"
+)
+
+func TestChangelogPersistence(t *testing.T) {
+	var (
+		cfg = config.Config{
+			CacheDir:      t.TempDir(),
+			ChangelogSize: 2,
+		}
+		versions = []linker.Files{
+			compileFiles(t, map[string]string{"a.proto": `syntax = "proto3"; package a.v1; message A {}`}),
+			compileFiles(t, map[string]string{"a.proto": `syntax = "proto3"; package a.v1; message A { string name = 1; }`}),
+			compileFiles(t, map[string]string{
+				"a.proto": `syntax = "proto3"; package a.v1; message A { string name = 1; }`,
+				"b.proto": `syntax = "proto3"; package b.v1; message B {}`,
+			}),
+			compileFiles(t, map[string]string{"b.proto": `syntax = "proto3"; package b.v1; message B {}`}),
+		}
+	)
+
+	reg := New(cfg)
+
+	entries, cancel := reg.SubscribeChangelog()
+	defer cancel()
+
+	var prev *Snapshot
+	for _, files := range versions {
+		snap := activate(t, reg, files)
+		reg.recordChangelog(prev, snap)
+		prev = snap
+	}
+
+	reg.writes.wait()
+
+	for _, id := range []uint64{2, 3, 4} {
+		if entry := <-entries; entry.SnapshotID != id || entry.PreviousSnapshotID != id-1 {
+			t.Errorf("unexpected entry %+v, expected snapshot %d", entry, id)
+		}
+	}
+
+	changelog := reg.Changelog()
+
+	// only the last two entries are retained, newest first
+	if len(changelog) != 2 || changelog[0].SnapshotID != 4 || changelog[1].SnapshotID != 3 {
+		t.Fatalf("unexpected changelog %+v", changelog)
+	}
+
+	if pkgs := changelog[0].Packages; len(pkgs) != 1 || pkgs[0].Package != "a.v1" || pkgs[0].Change != ElementRemoved {
+		t.Errorf("expected package a.v1 to be removed, got %+v", pkgs)
+	}
+
+	if pkgs := changelog[1].Packages; len(pkgs) != 1 || pkgs[0].Package != "b.v1" || pkgs[0].Change != ElementAdded {
+		t.Errorf("expected package b.v1 to be added, got %+v", pkgs)
+	}
+
+	restarted := New(cfg)
+
+	restored := restarted.Changelog()
+	if len(restored) != len(changelog) {
+		t.Fatalf("expected %d entries, got %d", len(changelog), len(restored))
+	}
+
+	for idx := range restored {
+		if restored[idx].SnapshotID != changelog[idx].SnapshotID || restored[idx].SnapshotHash != changelog[idx].SnapshotHash {
+			t.Errorf("entry %d: got %+v, want %+v", idx, restored[idx], changelog[idx])
+		}
+	}
+}
@@ -0,0 +1,322 @@
+package registry
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+
+	"github.com/bufbuild/protocompile/linker"
+	"github.com/tierklinik-dobersberg/pbtype-server/internal/config"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// ErrConflict is returned if a new version of a source has been refused
+// because it provides files or symbols of another source.
+var ErrConflict = errors.New("conflict with another source")
+
+// ConflictKind describes what two sources conflict on.
+type ConflictKind int
+
+const (
+	// ConflictFile is used if two sources provide a file with the same
+	// path.
+	ConflictFile ConflictKind = iota + 1
+
+	// ConflictSymbol is used if two sources define the same fully
+	// qualified name in different files.
+	ConflictSymbol
+)
+
+func (kind ConflictKind) String() string {
+	switch kind {
+	case ConflictFile:
+		return "file"
+	case ConflictSymbol:
+		return "symbol"
+	}
+
+	return "unknown"
+}
+
+// Conflict describes a file path or symbol that is provided by more than one
+// source.
+type Conflict struct {
+	Kind ConflictKind
+
+	// Name is the path of the file or the fully qualified name of the
+	// symbol.
+	Name string
+
+	// Source and File identify the origin that is served.
+	Source string
+	File   string
+
+	// OtherSource and OtherFile identify the origin that is not served.
+	OtherSource string
+	OtherFile   string
+
+	// Refused is set if a new version of OtherSource has been refused
+	// because of the conflict. Otherwise, OtherFile has been dropped from
+	// the served files.
+	Refused bool
+}
+
+func (c Conflict) String() string {
+	if c.Kind == ConflictFile {
+		return fmt.Sprintf("file %s is provided by sources %q and %q", c.Name, c.Source, c.OtherSource)
+	}
+
+	return fmt.Sprintf("symbol %s is defined by %s of source %q and %s of source %q", c.Name, c.File, c.Source, c.OtherFile, c.OtherSource)
+}
+
+// Conflicts returns all conflicts detected when the current snapshot has
+// been activated, followed by the conflicts that caused new versions of
+// sources to be refused.
+func (reg *Registry) Conflicts() []Conflict {
+	reg.l.RLock()
+	defer reg.l.RUnlock()
+
+	result := append([]Conflict(nil), reg.conflicts...)
+	for _, src := range reg.sources {
+		result = append(result, src.conflicts...)
+	}
+
+	return result
+}
+
+// ConflictPolicy returns the configured conflict policy.
+func (reg *Registry) ConflictPolicy() string {
+	return reg.conflictPolicy
+}
+
+// precedence returns the indexes of all sources, ordered by the precedence
+// they have if they conflict with each other.
+func (reg *Registry) precedence() []int {
+	order := make([]int, len(reg.sources))
+	for idx := range order {
+		order[idx] = idx
+	}
+
+	if reg.conflictPolicy == config.ConflictPriority {
+		sort.SliceStable(order, func(i, j int) bool {
+			return reg.sources[order[i]].Priority > reg.sources[order[j]].Priority
+		})
+	}
+
+	return order
+}
+
+type symbolOrigin struct {
+	source int
+	file   string
+}
+
+// conflictIndex records which source provides each file path and symbol.
+type conflictIndex struct {
+	sources []*source
+	files   map[string]int
+	symbols map[protoreflect.FullName]symbolOrigin
+}
+
+func newConflictIndex(sources []*source) *conflictIndex {
+	return &conflictIndex{
+		sources: sources,
+		files:   make(map[string]int),
+		symbols: make(map[protoreflect.FullName]symbolOrigin),
+	}
+}
+
+// check returns the conflicts of file, provided by the source at idx, with
+// the files added so far.
+func (ci *conflictIndex) check(idx int, file linker.File) []Conflict {
+	var result []Conflict
+
+	if other, ok := ci.files[file.Path()]; ok && other != idx {
+		result = append(result, Conflict{
+			Kind:        ConflictFile,
+			Name:        file.Path(),
+			Source:      ci.sources[other].Name,
+			File:        file.Path(),
+			OtherSource: ci.sources[idx].Name,
+			OtherFile:   file.Path(),
+		})
+
+		// symbols of files with the same path are expected to match
+		return result
+	}
+
+	fileSymbols(file, func(name protoreflect.FullName) {
+		if origin, ok := ci.symbols[name]; ok && origin.source != idx {
+			result = append(result, Conflict{
+				Kind:        ConflictSymbol,
+				Name:        string(name),
+				Source:      ci.sources[origin.source].Name,
+				File:        origin.file,
+				OtherSource: ci.sources[idx].Name,
+				OtherFile:   file.Path(),
+			})
+		}
+	})
+
+	return result
+}
+
+// add records file as being provided by the source at idx.
+func (ci *conflictIndex) add(idx int, file linker.File) {
+	if _, ok := ci.files[file.Path()]; !ok {
+		ci.files[file.Path()] = idx
+	}
+
+	fileSymbols(file, func(name protoreflect.FullName) {
+		if _, ok := ci.symbols[name]; !ok {
+			ci.symbols[name] = symbolOrigin{source: idx, file: file.Path()}
+		}
+	})
+}
+
+// fileSymbols calls fn for each service, message, enum and extension
+// declared in file.
+func fileSymbols(file protoreflect.FileDescriptor, fn func(protoreflect.FullName)) {
+	services := file.Services()
+	for i := 0; i < services.Len(); i++ {
+		fn(services.Get(i).FullName())
+	}
+
+	indexTypes(file, func(name protoreflect.FullName, _ SymbolKind) {
+		fn(name)
+	})
+}
+
+// findConflicts returns the conflicts of files, the new version of the
+// source at idx, with the current files of all other sources. idx may refer
+// to a source that is not part of current yet.
+func findConflicts(sources []*source, idx int, files linker.Files, current []linker.Files) []Conflict {
+	ci := newConflictIndex(sources)
+
+	for other, otherFiles := range current {
+		if other == idx {
+			continue
+		}
+
+		for _, file := range otherFiles {
+			ci.add(other, file)
+		}
+	}
+
+	var result []Conflict
+	for _, file := range files {
+		result = append(result, ci.check(idx, file)...)
+	}
+
+	for idx := range result {
+		result[idx].Refused = true
+	}
+
+	return result
+}
+
+// setRefusedConflicts records the conflicts that caused a new version of
+// the source at idx to be refused.
+func (reg *Registry) setRefusedConflicts(idx int, conflicts []Conflict) {
+	for _, c := range conflicts {
+		slog.Warn("refused protobuf source because of a conflict", "kind", c.Kind, "name", c.Name, "source", c.Source, "file", c.File, "other_source", c.OtherSource, "other_file", c.OtherFile)
+	}
+
+	reg.l.Lock()
+	defer reg.l.Unlock()
+
+	reg.sources[idx].conflicts = conflicts
+}
+
+// resolveConflicts returns the files of all sources, ordered by precedence.
+// Files that conflict with a file of a source with a higher precedence are
+// dropped and the conflicts are recorded. Files importing a dropped file of
+// their own source are dropped as well, as they have been linked against the
+// dropped version. Callers must hold reg.l.
+func (reg *Registry) resolveConflicts() []sourceFile {
+	var (
+		ci        = newConflictIndex(reg.sources)
+		result    []sourceFile
+		conflicts []Conflict
+		importers = make(map[string][]string)
+	)
+
+	for _, idx := range reg.precedence() {
+		var (
+			src     = reg.sources[idx]
+			dropped = make(map[string]struct{})
+		)
+
+		for _, file := range src.files {
+			if found := ci.check(idx, file); len(found) > 0 {
+				conflicts = append(conflicts, found...)
+				dropped[file.Path()] = struct{}{}
+			}
+		}
+
+		for changed := len(dropped) > 0; changed; {
+			changed = false
+
+			for _, file := range src.files {
+				if _, ok := dropped[file.Path()]; ok || !importsAny(file, dropped) {
+					continue
+				}
+
+				dropped[file.Path()] = struct{}{}
+				importers[src.Name] = append(importers[src.Name], file.Path())
+				changed = true
+			}
+		}
+
+		for _, file := range src.files {
+			if _, ok := dropped[file.Path()]; ok {
+				continue
+			}
+
+			ci.add(idx, file)
+			result = append(result, sourceFile{source: idx, file: file})
+		}
+	}
+
+	// only log conflicts once
+	known := make(map[Conflict]struct{}, len(reg.conflicts))
+	for _, c := range reg.conflicts {
+		known[c] = struct{}{}
+	}
+
+	for _, c := range conflicts {
+		if _, ok := known[c]; ok {
+			continue
+		}
+
+		slog.Warn("dropped conflicting protobuf file", "kind", c.Kind, "name", c.Name, "source", c.Source, "file", c.File, "other_source", c.OtherSource, "other_file", c.OtherFile)
+
+		if files, ok := importers[c.OtherSource]; ok {
+			slog.Warn("dropped protobuf files importing conflicting files", "source", c.OtherSource, "files", files)
+			delete(importers, c.OtherSource)
+		}
+	}
+
+	reg.conflicts = conflicts
+
+	return result
+}
+
+// importsAny reports whether file directly imports one of paths.
+func importsAny(file linker.File, paths map[string]struct{}) bool {
+	imports := file.Imports()
+	for i := 0; i < imports.Len(); i++ {
+		if _, ok := paths[imports.Get(i).Path()]; ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// sourceFile is a file provided by the source at index source.
+type sourceFile struct {
+	source int
+	file   linker.File
+}
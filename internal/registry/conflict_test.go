@@ -0,0 +1,138 @@
+package registry
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/bufbuild/protocompile/linker"
+	"github.com/tierklinik-dobersberg/pbtype-server/internal/config"
+)
+
+func TestResolveConflicts(t *testing.T) {
+	const header = `syntax = "proto3"; package test.v1; `
+
+	var (
+		first = compileFiles(t, map[string]string{
+			"a.proto": header + `message A {}`,
+			"b.proto": header + `message B {}`,
+		})
+		second = compileFiles(t, map[string]string{
+			"a.proto": header + `message A { string name = 1; }`,
+			"c.proto": header + `message C {}`,
+			"d.proto": header + `import "a.proto"; message G { A a = 1; }`,
+			"f.proto": header + `import "d.proto"; message F { G g = 1; }`,
+		})
+		third = compileFiles(t, map[string]string{
+			"other.proto": header + `message B {} message D {}`,
+			"e.proto":     header + `message E {}`,
+		})
+	)
+
+	cases := []struct {
+		name       string
+		policy     string
+		priorities []int
+		files      []string
+		conflicts  []Conflict
+	}{
+		{
+			name:   "first",
+			policy: config.ConflictFirst,
+			// d.proto and f.proto have been linked against the dropped a.proto
+			files: []string{"first:a.proto", "first:b.proto", "second:c.proto", "third:e.proto"},
+			conflicts: []Conflict{
+				{Kind: ConflictFile, Name: "a.proto", Source: "first", File: "a.proto", OtherSource: "second", OtherFile: "a.proto"},
+				{Kind: ConflictSymbol, Name: "test.v1.B", Source: "first", File: "b.proto", OtherSource: "third", OtherFile: "other.proto"},
+			},
+		},
+		{
+			name:       "priority",
+			policy:     config.ConflictPriority,
+			priorities: []int{0, 10, 5},
+			files:      []string{"second:a.proto", "second:c.proto", "second:d.proto", "second:f.proto", "third:e.proto", "third:other.proto"},
+			conflicts: []Conflict{
+				{Kind: ConflictFile, Name: "a.proto", Source: "second", File: "a.proto", OtherSource: "first", OtherFile: "a.proto"},
+				{Kind: ConflictSymbol, Name: "test.v1.B", Source: "third", File: "other.proto", OtherSource: "first", OtherFile: "b.proto"},
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			reg := &Registry{conflictPolicy: c.policy}
+
+			for idx, name := range []string{"first", "second", "third"} {
+				src := &source{Source: config.Source{Name: name}}
+				src.setFiles([]linker.Files{first, second, third}[idx])
+
+				if c.priorities != nil {
+					src.Priority = c.priorities[idx]
+				}
+
+				reg.sources = append(reg.sources, src)
+			}
+
+			var files []string
+			for _, provided := range reg.resolveConflicts() {
+				files = append(files, reg.sources[provided.source].Name+":"+provided.file.Path())
+			}
+
+			if !slices.Equal(files, c.files) {
+				t.Errorf("files: got %v, want %v", files, c.files)
+			}
+
+			if !slices.Equal(reg.conflicts, c.conflicts) {
+				t.Errorf("conflicts: got %+v, want %+v", reg.conflicts, c.conflicts)
+			}
+		})
+	}
+}
+
+func TestFindConflicts(t *testing.T) {
+	const header = `syntax = "proto3"; package test.v1; `
+
+	sources := []*source{
+		{Source: config.Source{Name: "first"}},
+		{Source: config.Source{Name: "upload"}},
+	}
+
+	current := []linker.Files{
+		compileFiles(t, map[string]string{"a.proto": header + `message A {}`}),
+	}
+
+	cases := []struct {
+		name  string
+		files map[string]string
+		want  []Conflict
+	}{
+		{
+			name:  "none",
+			files: map[string]string{"b.proto": header + `message B {}`},
+		},
+		{
+			name:  "file",
+			files: map[string]string{"a.proto": header + `message A {}`},
+			want: []Conflict{
+				{Kind: ConflictFile, Name: "a.proto", Source: "first", File: "a.proto", OtherSource: "upload", OtherFile: "a.proto", Refused: true},
+			},
+		},
+		{
+			name:  "symbol",
+			files: map[string]string{"b.proto": header + `message A {}`},
+			want: []Conflict{
+				{Kind: ConflictSymbol, Name: "test.v1.A", Source: "first", File: "a.proto", OtherSource: "upload", OtherFile: "b.proto", Refused: true},
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			// the upload at index 1 is not part of current yet
+			got := findConflicts(sources, 1, compileFiles(t, c.files), current)
+
+			if !slices.Equal(got, c.want) {
+				t.Errorf("got %+v, want %+v", got, c.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,467 @@
+package registry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/bufbuild/protocompile/linker"
+	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// ErrInvalidDescriptors is returned if file descriptors passed to the
+// registry cannot be linked.
+var ErrInvalidDescriptors = errors.New("invalid file descriptors")
+
+// DiffChange describes how an element changed.
+type DiffChange int
+
+const (
+	ElementAdded DiffChange = iota + 1
+	ElementRemoved
+	ElementChanged
+)
+
+func (change DiffChange) String() string {
+	switch change {
+	case ElementAdded:
+		return "added"
+	case ElementRemoved:
+		return "removed"
+	case ElementChanged:
+		return "changed"
+	}
+
+	return fmt.Sprintf("DiffChange(%d)", int(change))
+}
+
+// ElementType is the type of element a DiffEntry refers to.
+type ElementType int
+
+const (
+	ElementFile ElementType = iota + 1
+	ElementMessage
+	ElementField
+	ElementEnum
+	ElementEnumValue
+	ElementService
+	ElementMethod
+	ElementExtension
+)
+
+func (element ElementType) String() string {
+	switch element {
+	case ElementFile:
+		return "file"
+	case ElementMessage:
+		return "message"
+	case ElementField:
+		return "field"
+	case ElementEnum:
+		return "enum"
+	case ElementEnumValue:
+		return "enum value"
+	case ElementService:
+		return "service"
+	case ElementMethod:
+		return "method"
+	case ElementExtension:
+		return "extension"
+	}
+
+	return fmt.Sprintf("ElementType(%d)", int(element))
+}
+
+// DiffEntry describes a single element that differs between two sets of
+// files.
+type DiffEntry struct {
+	Change  DiffChange  `json:"change"`
+	Element ElementType `json:"element"`
+
+	// Name is the full name of the element or the path of a file.
+	Name string `json:"name"`
+
+	// File is the path of the file that declares the element and Package
+	// the package of that file.
+	File    string `json:"file"`
+	Package string `json:"package,omitempty"`
+
+	// Properties holds all properties of a changed element that differ.
+	Properties []PropertyChange `json:"properties,omitempty"`
+}
+
+// String returns a human readable description of the entry, like
+// "changed field foo.v1.Bar.baz: number 1 -> 2".
+func (entry DiffEntry) String() string {
+	desc := fmt.Sprintf("%s %s %s", entry.Change, entry.Element, entry.Name)
+
+	if len(entry.Properties) == 0 {
+		return desc
+	}
+
+	props := make([]string, len(entry.Properties))
+	for idx, prop := range entry.Properties {
+		props[idx] = fmt.Sprintf("%s %s -> %s", prop.Name, FormatValue(prop.OldValue), FormatValue(prop.NewValue))
+	}
+
+	return desc + ": " + strings.Join(props, ", ")
+}
+
+// FormatValue formats the old or new value of a PropertyChange for display.
+// Empty values are formatted as "<none>".
+func FormatValue(value string) string {
+	if value = strings.TrimSpace(value); value == "" {
+		return "<none>"
+	}
+
+	return value
+}
+
+// PropertyChange describes a changed property of an element.
+type PropertyChange struct {
+	Name     string `json:"name"`
+	OldValue string `json:"oldValue,omitempty"`
+	NewValue string `json:"newValue,omitempty"`
+}
+
+// DiffSnapshots returns the differences between the snapshots identified by
+// from and to. See Registry.Snapshot for how snapshots are identified.
+func (reg *Registry) DiffSnapshots(ctx context.Context, from, to string) (*Snapshot, *Snapshot, []DiffEntry, error) {
+	fromSnap, err := reg.Snapshot(ctx, from)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	toSnap, err := reg.Snapshot(ctx, to)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	return fromSnap, toSnap, diffFiles(fromSnap.files, toSnap.files), nil
+}
+
+// DiffFiles returns the differences between the snapshot identified by from
+// and files. Imports of files are resolved using the snapshot. Only files
+// with a path contained in files are compared.
+func (reg *Registry) DiffFiles(ctx context.Context, from string, files []*descriptorpb.FileDescriptorProto) (*Snapshot, []DiffEntry, error) {
+	snap, err := reg.Snapshot(ctx, from)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	linked, err := newProtoSource(files).compile(ctx, []linker.Files{snap.files})
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %s", ErrInvalidDescriptors, err)
+	}
+
+	var old linker.Files
+	for _, file := range snap.files {
+		if linked.FindFileByPath(file.Path()) != nil {
+			old = append(old, file)
+		}
+	}
+
+	return snap, diffFiles(old, linked), nil
+}
+
+// differ collects the entries of a diff.
+type differ struct {
+	entries []DiffEntry
+}
+
+func (d *differ) add(change DiffChange, element ElementType, name string, file protoreflect.FileDescriptor, props []PropertyChange) {
+	d.entries = append(d.entries, DiffEntry{
+		Change:     change,
+		Element:    element,
+		Name:       name,
+		File:       file.Path(),
+		Package:    string(file.Package()),
+		Properties: props,
+	})
+}
+
+// diffFiles returns all elements that differ between old and new, ordered
+// by name.
+func diffFiles(old, new linker.Files) []DiffEntry {
+	d := &differ{}
+
+	oldFiles := make(map[string]protoreflect.FileDescriptor, len(old))
+	for _, file := range old {
+		oldFiles[file.Path()] = file
+	}
+
+	newFiles := make(map[string]protoreflect.FileDescriptor, len(new))
+	for _, file := range new {
+		newFiles[file.Path()] = file
+	}
+
+	for path, oldFile := range oldFiles {
+		newFile, ok := newFiles[path]
+		if !ok {
+			d.add(ElementRemoved, ElementFile, path, oldFile, nil)
+
+			continue
+		}
+
+		var props []PropertyChange
+		props = compareProperty(props, "package", string(oldFile.Package()), string(newFile.Package()))
+		props = compareProperty(props, "syntax", oldFile.Syntax().String(), newFile.Syntax().String())
+		props = compareOptions(props, oldFile, newFile)
+
+		if len(props) > 0 {
+			d.add(ElementChanged, ElementFile, path, newFile, props)
+		}
+	}
+
+	for path, newFile := range newFiles {
+		if _, ok := oldFiles[path]; !ok {
+			d.add(ElementAdded, ElementFile, path, newFile, nil)
+		}
+	}
+
+	// elements are compared by full name so moving an element to another
+	// file shows up as a change of its file.
+	var (
+		oldElements = collectElements(old)
+		newElements = collectElements(new)
+	)
+
+	for name, oldDesc := range oldElements {
+		newDesc, ok := newElements[name]
+
+		switch {
+		case !ok:
+			// only report the outermost removed element
+			if parent := oldDesc.Parent(); parent != nil {
+				if _, isFile := parent.(protoreflect.FileDescriptor); !isFile {
+					if _, exists := newElements[parent.FullName()]; !exists {
+						continue
+					}
+				}
+			}
+
+			d.add(ElementRemoved, elementType(oldDesc), string(name), oldDesc.ParentFile(), nil)
+
+		case elementType(oldDesc) != elementType(newDesc):
+			d.add(ElementRemoved, elementType(oldDesc), string(name), oldDesc.ParentFile(), nil)
+			d.add(ElementAdded, elementType(newDesc), string(name), newDesc.ParentFile(), nil)
+
+		default:
+			if props := compareElements(oldDesc, newDesc); len(props) > 0 {
+				d.add(ElementChanged, elementType(newDesc), string(name), newDesc.ParentFile(), props)
+			}
+		}
+	}
+
+	for name, newDesc := range newElements {
+		if _, ok := oldElements[name]; ok {
+			continue
+		}
+
+		if parent := newDesc.Parent(); parent != nil {
+			if _, isFile := parent.(protoreflect.FileDescriptor); !isFile {
+				if _, exists := oldElements[parent.FullName()]; !exists {
+					continue
+				}
+			}
+		}
+
+		d.add(ElementAdded, elementType(newDesc), string(name), newDesc.ParentFile(), nil)
+	}
+
+	sort.Slice(d.entries, func(i, j int) bool {
+		if d.entries[i].Name != d.entries[j].Name {
+			return d.entries[i].Name < d.entries[j].Name
+		}
+
+		return d.entries[i].Change < d.entries[j].Change
+	})
+
+	return d.entries
+}
+
+// compareElements returns all properties that differ between two versions
+// of the same element.
+func compareElements(old, new protoreflect.Descriptor) []PropertyChange {
+	var props []PropertyChange
+
+	if old.ParentFile().Path() != new.ParentFile().Path() {
+		props = compareProperty(props, "file", old.ParentFile().Path(), new.ParentFile().Path())
+	}
+
+	switch old := old.(type) {
+	case protoreflect.FieldDescriptor:
+		new := new.(protoreflect.FieldDescriptor)
+
+		props = compareProperty(props, "number", strconv.Itoa(int(old.Number())), strconv.Itoa(int(new.Number())))
+		props = compareProperty(props, "type", fieldType(old), fieldType(new))
+		props = compareProperty(props, "json_name", old.JSONName(), new.JSONName())
+		props = compareProperty(props, "oneof", oneofName(old), oneofName(new))
+
+		if old.IsExtension() {
+			props = compareProperty(props, "extendee", string(old.ContainingMessage().FullName()), string(new.ContainingMessage().FullName()))
+		}
+
+	case protoreflect.EnumValueDescriptor:
+		new := new.(protoreflect.EnumValueDescriptor)
+
+		props = compareProperty(props, "number", strconv.Itoa(int(old.Number())), strconv.Itoa(int(new.Number())))
+
+	case protoreflect.MethodDescriptor:
+		new := new.(protoreflect.MethodDescriptor)
+
+		props = compareProperty(props, "input", string(old.Input().FullName()), string(new.Input().FullName()))
+		props = compareProperty(props, "output", string(old.Output().FullName()), string(new.Output().FullName()))
+		props = compareProperty(props, "client_streaming", strconv.FormatBool(old.IsStreamingClient()), strconv.FormatBool(new.IsStreamingClient()))
+		props = compareProperty(props, "server_streaming", strconv.FormatBool(old.IsStreamingServer()), strconv.FormatBool(new.IsStreamingServer()))
+	}
+
+	return compareOptions(props, old, new)
+}
+
+func compareProperty(props []PropertyChange, name, old, new string) []PropertyChange {
+	if old == new {
+		return props
+	}
+
+	return append(props, PropertyChange{
+		Name:     name,
+		OldValue: old,
+		NewValue: new,
+	})
+}
+
+// compareOptions adds a property change if the options of old and new
+// differ.
+func compareOptions(props []PropertyChange, old, new protoreflect.Descriptor) []PropertyChange {
+	oldBlob, _ := proto.MarshalOptions{Deterministic: true}.Marshal(old.Options())
+	newBlob, _ := proto.MarshalOptions{Deterministic: true}.Marshal(new.Options())
+
+	if string(oldBlob) == string(newBlob) {
+		return props
+	}
+
+	return append(props, PropertyChange{
+		Name:     "options",
+		OldValue: formatOptions(old.Options()),
+		NewValue: formatOptions(new.Options()),
+	})
+}
+
+func formatOptions(opts proto.Message) string {
+	if opts == nil || !opts.ProtoReflect().IsValid() {
+		return ""
+	}
+
+	return prototext.MarshalOptions{EmitUnknown: true}.Format(opts)
+}
+
+func oneofName(field protoreflect.FieldDescriptor) string {
+	if oneof := field.ContainingOneof(); oneof != nil && !oneof.IsSynthetic() {
+		return string(oneof.Name())
+	}
+
+	return ""
+}
+
+func elementType(desc protoreflect.Descriptor) ElementType {
+	switch desc := desc.(type) {
+	case protoreflect.MessageDescriptor:
+		return ElementMessage
+	case protoreflect.FieldDescriptor:
+		if desc.IsExtension() {
+			return ElementExtension
+		}
+
+		return ElementField
+	case protoreflect.EnumDescriptor:
+		return ElementEnum
+	case protoreflect.EnumValueDescriptor:
+		return ElementEnumValue
+	case protoreflect.ServiceDescriptor:
+		return ElementService
+	case protoreflect.MethodDescriptor:
+		return ElementMethod
+	}
+
+	return 0
+}
+
+// collectElements returns all messages, fields, enums, enum values,
+// services, methods and extensions declared in files by full name. Map
+// entry messages are skipped.
+func collectElements(files linker.Files) map[protoreflect.FullName]protoreflect.Descriptor {
+	var (
+		result = make(map[protoreflect.FullName]protoreflect.Descriptor)
+		walk   func(desc protoreflect.Descriptor)
+	)
+
+	add := func(desc protoreflect.Descriptor) {
+		result[desc.FullName()] = desc
+	}
+
+	walk = func(desc protoreflect.Descriptor) {
+		container, ok := desc.(interface {
+			Messages() protoreflect.MessageDescriptors
+			Enums() protoreflect.EnumDescriptors
+			Extensions() protoreflect.ExtensionDescriptors
+		})
+		if !ok {
+			return
+		}
+
+		enums := container.Enums()
+		for i := 0; i < enums.Len(); i++ {
+			add(enums.Get(i))
+
+			values := enums.Get(i).Values()
+			for j := 0; j < values.Len(); j++ {
+				add(values.Get(j))
+			}
+		}
+
+		extensions := container.Extensions()
+		for i := 0; i < extensions.Len(); i++ {
+			add(extensions.Get(i))
+		}
+
+		messages := container.Messages()
+		for i := 0; i < messages.Len(); i++ {
+			msg := messages.Get(i)
+			if msg.IsMapEntry() {
+				continue
+			}
+
+			add(msg)
+
+			fields := msg.Fields()
+			for j := 0; j < fields.Len(); j++ {
+				add(fields.Get(j))
+			}
+
+			walk(msg)
+		}
+	}
+
+	for _, file := range files {
+		walk(file)
+
+		services := file.Services()
+		for i := 0; i < services.Len(); i++ {
+			add(services.Get(i))
+
+			methods := services.Get(i).Methods()
+			for j := 0; j < methods.Len(); j++ {
+				add(methods.Get(j))
+			}
+		}
+	}
+
+	return result
+}
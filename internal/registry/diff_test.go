@@ -0,0 +1,129 @@
+package registry
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestDiffFiles(t *testing.T) {
+	const header = `syntax = "proto3"; package test.v1; `
+
+	cases := []struct {
+		name     string
+		old, new map[string]string
+		want     []string
+	}{
+		{
+			name: "unchanged",
+			old:  map[string]string{"a.proto": header + `message A { string name = 1; }`},
+			new:  map[string]string{"a.proto": header + `message A { string name = 1; }`},
+		},
+		{
+			name: "file added",
+			old:  map[string]string{"a.proto": header + `message A {}`},
+			new: map[string]string{
+				"a.proto": header + `message A {}`,
+				"b.proto": header + `message B { string name = 1; }`,
+			},
+			want: []string{
+				"added file b.proto",
+				"added message test.v1.B",
+			},
+		},
+		{
+			name: "file removed",
+			old: map[string]string{
+				"a.proto": header + `message A {}`,
+				"b.proto": header + `enum E { E_UNSPECIFIED = 0; }`,
+			},
+			new: map[string]string{"a.proto": header + `message A {}`},
+			want: []string{
+				"removed file b.proto",
+				"removed enum test.v1.E",
+			},
+		},
+		{
+			name: "file options",
+			old:  map[string]string{"a.proto": header + `message A {}`},
+			new:  map[string]string{"a.proto": header + `option go_package = "example.com/test"; message A {}`},
+			want: []string{"changed file a.proto [options]"},
+		},
+		{
+			name: "fields",
+			old:  map[string]string{"a.proto": header + `message A { string name = 1; int32 count = 2; bool gone = 3; }`},
+			new:  map[string]string{"a.proto": header + `message A { string name = 4; int64 count = 2; string added = 5; }`},
+			want: []string{
+				"added field test.v1.A.added",
+				"changed field test.v1.A.count [type]",
+				"removed field test.v1.A.gone",
+				"changed field test.v1.A.name [number]",
+			},
+		},
+		{
+			name: "nested elements",
+			old:  map[string]string{"a.proto": header + `message A { message B { string name = 1; } }`},
+			new:  map[string]string{"a.proto": header + `message A { message C { string name = 1; } }`},
+			want: []string{
+				"removed message test.v1.A.B",
+				"added message test.v1.A.C",
+			},
+		},
+		{
+			name: "moved",
+			old: map[string]string{
+				"a.proto": header + `message A {}`,
+				"b.proto": header + `message B {}`,
+			},
+			new: map[string]string{
+				"a.proto": header + `message A {} message B {}`,
+				"b.proto": header,
+			},
+			want: []string{"changed message test.v1.B [file]"},
+		},
+		{
+			name: "services",
+			old:  map[string]string{"a.proto": header + `message A {} service S { rpc Get(A) returns (A); }`},
+			new:  map[string]string{"a.proto": header + `message A {} message B {} service S { rpc Get(A) returns (stream B); }`},
+			want: []string{
+				"added message test.v1.B",
+				"changed method test.v1.S.Get [output server_streaming]",
+			},
+		},
+		{
+			name: "enum values",
+			old:  map[string]string{"a.proto": header + `enum E { E_UNSPECIFIED = 0; E_ONE = 1; }`},
+			new:  map[string]string{"a.proto": header + `enum E { E_UNSPECIFIED = 0; E_ONE = 2; E_TWO = 3; }`},
+			want: []string{
+				"changed enum value test.v1.E_ONE [number]",
+				"added enum value test.v1.E_TWO",
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			var got []string
+
+			for _, entry := range diffFiles(compileFiles(t, c.old), compileFiles(t, c.new)) {
+				line := fmt.Sprintf("%s %s %s", entry.Change, entry.Element, entry.Name)
+
+				if len(entry.Properties) > 0 {
+					names := make([]string, len(entry.Properties))
+					for idx, prop := range entry.Properties {
+						names[idx] = prop.Name
+					}
+
+					line += " [" + strings.Join(names, " ") + "]"
+				}
+
+				got = append(got, line)
+			}
+
+			if !slices.Equal(got, c.want) {
+				t.Errorf("got:\n\t%s\nwant:\n\t%s", strings.Join(got, "\n\t"), strings.Join(c.want, "\n\t"))
+			}
+		})
+	}
+}
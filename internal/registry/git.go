@@ -0,0 +1,203 @@
+package registry
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/hashicorp/go-getter"
+	"github.com/tierklinik-dobersberg/pbtype-server/internal/config"
+)
+
+// errNotModified is returned by source.fetch if the resolved revision of a
+// git source has already been compiled.
+var errNotModified = errors.New("source not modified")
+
+// gitRepository describes a source that is checked out using a persistent
+// git working copy instead of being downloaded by go-getter.
+type gitRepository struct {
+	// url is the URL of the remote repository.
+	url string
+
+	// subdir is the sub-directory of the repository that holds the
+	// source, if any.
+	subdir string
+
+	// ref is the branch, tag or commit that is checked out. If empty, the
+	// default branch of the remote is used.
+	ref string
+
+	// workdir is the directory of the working copy.
+	workdir string
+}
+
+// parseGitSource returns the git repository of src if its URL is detected as
+// a git URL by go-getter, like "github.com/acme/protos.git//proto" or
+// "git::https://example.com/protos.git?ref=v1.0.0". It returns nil for all
+// other URLs and for git URLs using options other than ref, which are still
+// handled by go-getter.
+func parseGitSource(src config.Source, workdir string) *gitRepository {
+	pwd, err := os.Getwd()
+	if err != nil {
+		return nil
+	}
+
+	detected, err := getter.Detect(src.URL, pwd, getter.Detectors)
+	if err != nil {
+		return nil
+	}
+
+	forced, raw, ok := strings.Cut(detected, "::")
+	if !ok || forced != "git" {
+		return nil
+	}
+
+	raw, subdir := getter.SourceDirSubdir(raw)
+
+	u, err := url.Parse(raw)
+	if err != nil {
+		return nil
+	}
+
+	query := u.Query()
+	ref := query.Get("ref")
+	query.Del("ref")
+
+	if len(query) > 0 {
+		return nil
+	}
+
+	u.RawQuery = ""
+
+	if src.Ref != "" {
+		ref = src.Ref
+	}
+
+	return &gitRepository{
+		url:     u.String(),
+		subdir:  subdir,
+		ref:     ref,
+		workdir: workdir,
+	}
+}
+
+// checkout updates the working copy of repo and checks out the configured
+// ref. It returns the resolved commit or errNotModified if it equals
+// current.
+func (repo *gitRepository) checkout(ctx context.Context, current string) (string, error) {
+	if _, err := os.Stat(filepath.Join(repo.workdir, ".git")); err != nil {
+		if err := os.MkdirAll(repo.workdir, 0o755); err != nil {
+			return "", fmt.Errorf("failed to create working copy: %w", err)
+		}
+
+		if _, err := repo.git(ctx, "init", "-q"); err != nil {
+			return "", err
+		}
+
+		if _, err := repo.git(ctx, "remote", "add", "origin", repo.url); err != nil {
+			return "", err
+		}
+	} else if _, err := repo.git(ctx, "remote", "set-url", "origin", repo.url); err != nil {
+		return "", err
+	}
+
+	ref := repo.ref
+	if ref == "" {
+		ref = "HEAD"
+	}
+
+	// only the requested revision is needed, so keep the history shallow.
+	// Fetching commits by hash requires support by the remote, which all
+	// major hosters provide.
+	if _, err := repo.git(ctx, "fetch", "-q", "--depth", "1", "--no-tags", "--force", "--", "origin", ref); err != nil {
+		return "", err
+	}
+
+	commit, err := repo.git(ctx, "rev-parse", "FETCH_HEAD^{commit}")
+	if err != nil {
+		return "", err
+	}
+
+	if commit == current {
+		return commit, errNotModified
+	}
+
+	if _, err := repo.git(ctx, "checkout", "-q", "--force", "--detach", commit); err != nil {
+		return "", err
+	}
+
+	if _, err := repo.git(ctx, "clean", "-q", "-ffdx"); err != nil {
+		return "", err
+	}
+
+	slog.Info("checked out git revision", "url", config.RedactURL(repo.url), "ref", ref, "commit", commit)
+
+	return commit, nil
+}
+
+// git runs git with args in the working copy and returns its trimmed
+// output.
+func (repo *gitRepository) git(ctx context.Context, args ...string) (string, error) {
+	var stdout, stderr bytes.Buffer
+
+	cmd := exec.CommandContext(ctx, "git", append([]string{"-C", repo.workdir}, args...)...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	// never prompt for credentials
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+
+	if err := cmd.Run(); err != nil {
+		msg := strings.TrimSpace(stderr.String())
+		if msg == "" {
+			msg = err.Error()
+		}
+
+		return "", fmt.Errorf("git %s: %s", args[0], repo.redact(msg))
+	}
+
+	return strings.TrimSpace(stdout.String()), nil
+}
+
+// redact removes the credentials of the remote URL from msg, as git may
+// echo the URL in its error messages.
+func (repo *gitRepository) redact(msg string) string {
+	msg = strings.ReplaceAll(msg, repo.url, config.RedactURL(repo.url))
+
+	u, err := url.Parse(repo.url)
+	if err != nil || u.User == nil {
+		return msg
+	}
+
+	msg = strings.ReplaceAll(msg, u.User.String(), "redacted")
+
+	// tokens are often passed as the user name
+	secret, ok := u.User.Password()
+	if !ok {
+		secret = u.User.Username()
+	}
+
+	if secret != "" {
+		msg = strings.ReplaceAll(msg, secret, "redacted")
+	}
+
+	return msg
+}
+
+// gitWorkdir returns the directory of the working copy for the source with
+// the given name. Working copies are kept in the "git" sub-directory of the
+// cache directory or of the system's temporary directory.
+func gitWorkdir(cacheDir string, name string) string {
+	if cacheDir == "" {
+		cacheDir = filepath.Join(os.TempDir(), "pbtype-server")
+	}
+
+	return filepath.Join(cacheDir, "git", cacheKey(name))
+}
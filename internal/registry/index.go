@@ -0,0 +1,256 @@
+package registry
+
+import (
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/bufbuild/protocompile/linker"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// SymbolKind describes the kind of a Symbol.
+type SymbolKind int
+
+const (
+	SymbolMessage SymbolKind = iota + 1
+	SymbolEnum
+	SymbolService
+	SymbolExtension
+)
+
+// Symbol is a message, enum, service or extension declared in a served file.
+type Symbol struct {
+	Name    protoreflect.FullName
+	Kind    SymbolKind
+	File    string
+	Package protoreflect.FullName
+}
+
+// SymbolQuery filters symbols returned by Registry.ListSymbols.
+type SymbolQuery struct {
+	// Package limits results to symbols of the given package.
+	Package protoreflect.FullName
+
+	// Kinds limits results to symbols of the given kinds.
+	Kinds []SymbolKind
+
+	// Query matches symbol names. See Fuzzy.
+	Query string
+
+	// Fuzzy enables fuzzy matching. Otherwise, Query is matched as a
+	// prefix of the fully-qualified or the simple name of a symbol.
+	Fuzzy bool
+}
+
+// index holds all paths, packages and symbols of the served files. It is
+// rebuilt each time a new set of files is activated.
+type index struct {
+	files    []string
+	packages []protoreflect.FullName
+	symbols  []Symbol
+}
+
+func buildIndex(files linker.Files) *index {
+	var (
+		idx      = &index{}
+		packages = make(map[protoreflect.FullName]struct{})
+	)
+
+	for _, file := range files {
+		pkg := file.Package()
+
+		idx.files = append(idx.files, file.Path())
+
+		if _, ok := packages[pkg]; !ok {
+			packages[pkg] = struct{}{}
+			idx.packages = append(idx.packages, pkg)
+		}
+
+		add := func(name protoreflect.FullName, kind SymbolKind) {
+			idx.symbols = append(idx.symbols, Symbol{
+				Name:    name,
+				Kind:    kind,
+				File:    file.Path(),
+				Package: pkg,
+			})
+		}
+
+		services := file.Services()
+		for i := 0; i < services.Len(); i++ {
+			add(services.Get(i).FullName(), SymbolService)
+		}
+
+		indexTypes(file, add)
+	}
+
+	sort.Strings(idx.files)
+
+	sort.Slice(idx.packages, func(i, j int) bool {
+		return idx.packages[i] < idx.packages[j]
+	})
+
+	sort.Slice(idx.symbols, func(i, j int) bool {
+		return idx.symbols[i].Name < idx.symbols[j].Name
+	})
+
+	return idx
+}
+
+// indexTypes calls add for all messages, enums and extensions declared in
+// desc, including nested ones.
+func indexTypes(desc interface {
+	Messages() protoreflect.MessageDescriptors
+	Enums() protoreflect.EnumDescriptors
+	Extensions() protoreflect.ExtensionDescriptors
+}, add func(protoreflect.FullName, SymbolKind)) {
+	enums := desc.Enums()
+	for i := 0; i < enums.Len(); i++ {
+		add(enums.Get(i).FullName(), SymbolEnum)
+	}
+
+	extensions := desc.Extensions()
+	for i := 0; i < extensions.Len(); i++ {
+		add(extensions.Get(i).FullName(), SymbolExtension)
+	}
+
+	messages := desc.Messages()
+	for i := 0; i < messages.Len(); i++ {
+		msg := messages.Get(i)
+
+		// skip synthetic map entry messages
+		if msg.IsMapEntry() {
+			continue
+		}
+
+		add(msg.FullName(), SymbolMessage)
+		indexTypes(msg, add)
+	}
+}
+
+// ListPackages returns all packages of served files that match query. See
+// SymbolQuery for how query is matched.
+func (reg *Registry) ListPackages(query string, fuzzy bool) []protoreflect.FullName {
+	idx := reg.getIndex()
+
+	var result []scored[protoreflect.FullName]
+	for _, pkg := range idx.packages {
+		if score, ok := matchName(query, string(pkg), string(pkg), fuzzy); ok {
+			result = append(result, scored[protoreflect.FullName]{pkg, score})
+		}
+	}
+
+	return sortScored(result)
+}
+
+// ListSymbols returns all symbols of served files that match query.
+func (reg *Registry) ListSymbols(query SymbolQuery) []Symbol {
+	idx := reg.getIndex()
+
+	var result []scored[Symbol]
+	for _, sym := range idx.symbols {
+		if query.Package != "" && sym.Package != query.Package {
+			continue
+		}
+
+		if len(query.Kinds) > 0 && !slices.Contains(query.Kinds, sym.Kind) {
+			continue
+		}
+
+		if score, ok := matchName(query.Query, string(sym.Name), string(sym.Name.Name()), query.Fuzzy); ok {
+			result = append(result, scored[Symbol]{sym, score})
+		}
+	}
+
+	return sortScored(result)
+}
+
+func (reg *Registry) getIndex() *index {
+	reg.l.RLock()
+	defer reg.l.RUnlock()
+
+	if reg.index == nil {
+		return &index{}
+	}
+
+	return reg.index
+}
+
+type scored[T any] struct {
+	value T
+	score int
+}
+
+// sortScored returns all values ordered by descending score. Values with
+// the same score keep their order.
+func sortScored[T any](values []scored[T]) []T {
+	sort.SliceStable(values, func(i, j int) bool {
+		return values[i].score > values[j].score
+	})
+
+	result := make([]T, len(values))
+	for idx, v := range values {
+		result[idx] = v.value
+	}
+
+	return result
+}
+
+// matchName matches query against the full and the simple name of a symbol
+// and returns a score. Prefix matches all have the same score so results
+// stay in alphabetical order.
+func matchName(query, fullName, name string, fuzzy bool) (int, bool) {
+	if query == "" {
+		return 0, true
+	}
+
+	query = strings.ToLower(query)
+	fullName = strings.ToLower(fullName)
+	name = strings.ToLower(name)
+
+	if !fuzzy {
+		return 0, strings.HasPrefix(fullName, query) || strings.HasPrefix(name, query)
+	}
+
+	score, ok := fuzzyScore(query, fullName)
+	if nameScore, nameOK := fuzzyScore(query, name); nameOK && (!ok || nameScore >= score) {
+		// prefer matches on the simple name
+		return nameScore + 1, true
+	}
+
+	return score, ok
+}
+
+// fuzzyScore reports whether all characters of query appear in s in order.
+// Consecutive matches, matches at the start of a name segment and short
+// names score higher.
+func fuzzyScore(query, s string) (int, bool) {
+	var (
+		score int
+		pos   int
+		last  = -2
+	)
+
+	for _, r := range query {
+		idx := strings.IndexRune(s[pos:], r)
+		if idx < 0 {
+			return 0, false
+		}
+
+		idx += pos
+
+		switch {
+		case idx == last+1:
+			score += 3
+		case idx == 0 || s[idx-1] == '.' || s[idx-1] == '_':
+			score += 2
+		default:
+			score++
+		}
+
+		last = idx
+		pos = idx + len(string(r))
+	}
+
+	return score*100 - len(s), true
+}
@@ -0,0 +1,94 @@
+package registry
+
+import (
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/bufbuild/protocompile/linker"
+	"github.com/tierklinik-dobersberg/pbtype-server/internal/config"
+)
+
+// Provenance describes where a served file comes from.
+type Provenance struct {
+	// Source is the name of the source that provides the file and URL its
+	// URL without credentials.
+	Source string `json:"source"`
+	URL    string `json:"url"`
+
+	// Revision is the git commit the file has been checked out at. Only
+	// set for git sources.
+	Revision string `json:"revision,omitempty"`
+
+	// Path is the path of the file within the downloaded source, like
+	// "proto/tkd/idm/v1/user.proto" for a source with the import root
+	// "proto". For files of descriptor sets, it is the path of the
+	// descriptor set.
+	Path string `json:"path"`
+
+	// Fetched is the time the source has been fetched or uploaded.
+	Fetched time.Time `json:"fetched"`
+}
+
+// FileProvenance returns the provenance of the file at path. It returns
+// false if the file is not part of the snapshot or has not been provided by
+// a source, like the standard imports.
+func (snap *Snapshot) FileProvenance(path string) (Provenance, bool) {
+	prov, ok := snap.Provenance[path]
+
+	return prov, ok
+}
+
+// Files returns the paths of all files of the snapshot that have been
+// provided by a source, ordered by path.
+func (snap *Snapshot) Files() []string {
+	result := make([]string, 0, len(snap.Provenance))
+	for path := range snap.Provenance {
+		result = append(result, path)
+	}
+
+	sort.Strings(result)
+
+	return result
+}
+
+// origin records the path of a fetched file within the downloaded source.
+// base is the directory of the downloaded source and path the absolute path
+// of the file providing name.
+func (f *fetchedSource) origin(name string, base string, path string) {
+	rel, err := filepath.Rel(base, path)
+	if err != nil {
+		return
+	}
+
+	if f.origins == nil {
+		f.origins = make(map[string]string)
+	}
+
+	f.origins[name] = filepath.ToSlash(rel)
+}
+
+// newProvenance returns the provenance of all files of src that have been
+// fetched at fetched from revision. origins maps the import paths of files
+// to their path within the source; files without an origin are expected at
+// their import path.
+func (src *source) newProvenance(files linker.Files, origins map[string]string, revision string, fetched time.Time) map[string]Provenance {
+	result := make(map[string]Provenance, len(files))
+
+	for _, file := range files {
+		path, ok := origins[file.Path()]
+		if !ok {
+			path = file.Path()
+		}
+
+		result[file.Path()] = Provenance{
+			Source:   src.Name,
+			URL:      config.RedactURL(src.URL),
+			Revision: revision,
+			Path:     path,
+			Fetched:  fetched,
+		}
+	}
+
+	return result
+}
@@ -4,17 +4,15 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"io/fs"
-	"log"
 	"log/slog"
 	"os"
-	"path/filepath"
+	"slices"
+	"sort"
 	"sync"
 	"time"
 
-	"github.com/bufbuild/protocompile"
 	"github.com/bufbuild/protocompile/linker"
-	"github.com/hashicorp/go-getter"
+	"github.com/tierklinik-dobersberg/pbtype-server/internal/config"
 	"github.com/tierklinik-dobersberg/pbtype-server/pkg/protoresolve"
 	"google.golang.org/protobuf/reflect/protoreflect"
 	"google.golang.org/protobuf/reflect/protoregistry"
@@ -34,30 +32,134 @@ import (
 )
 
 var (
-	ErrPollingStarted = errors.New("polling already started")
+	ErrPollingStarted    = errors.New("polling already started")
+	ErrPollingNotStarted = errors.New("polling not started")
+	ErrUnknownSource     = errors.New("unknown source")
 )
 
 type Registry struct {
-	started  chan struct{}
-	interval time.Duration
-	sources  []string
+	started chan struct{}
+	refresh chan refreshRequest
+	uploads chan uploadRequest
+
+	// sources is only modified by the polling loop while holding l, so the
+	// polling loop may read it without holding l.
+	sources  []*source
+	cacheDir string
+
+	// uploadDir is the directory uploaded sources are stored in and
+	// refreshInterval the refresh interval used for them.
+	uploadDir       string
+	refreshInterval time.Duration
+
+	snapshotHistory int
+	snapshotArchive int
+	changelogSize   int
+
+	// writes persists archived snapshots and the changelog in the order
+	// they have been recorded.
+	writes writeQueue
+
+	refuseBreaking bool
+	conflictPolicy string
 
 	l        sync.RWMutex
 	files    linker.Files
 	resolver linker.Resolver
+	index    *index
+	status   Status
+
+	// fileHashes holds the hash of each served file and subscribers all
+	// channels notified about changes to them.
+	fileHashes  map[string]string
+	subscribers map[chan ChangeEvent]struct{}
+
+	// snapshots holds the retained snapshots, oldest first, and
+	// lastSnapshot the most recently activated one.
+	snapshots    []*Snapshot
+	lastSnapshot SnapshotInfo
+
+	// archived indexes the snapshot archive and loaded holds recently used
+	// snapshots loaded from it. Both have their own lock.
+	archived archiveIndex
+	loaded   snapshotCache
+
+	// changelog holds the retained changelog entries, oldest first, and
+	// changelogSubscribers all channels notified about new entries.
+	changelog            []ChangelogEntry
+	changelogSubscribers map[chan ChangelogEntry]struct{}
+
+	// conflicts holds the conflicts detected when the current files have
+	// been activated.
+	conflicts []Conflict
 }
 
-func New(interval time.Duration, sources []string) *Registry {
-	return &Registry{
-		interval: interval,
-		sources:  sources,
-		started:  make(chan struct{}),
+// Status describes the outcome of the most recent source refreshes.
+type Status struct {
+	// LastRefresh is the time the last refresh has been started.
+	LastRefresh time.Time
+
+	// LastSuccess is the time of the last refresh that produced a new
+	// snapshot. For the registry itself, this is the last refresh where at
+	// least one source has been compiled successfully.
+	LastSuccess time.Time
+
+	// LastError holds the error of the last refresh, if any. If set, the
+	// registry still serves the snapshot from LastSuccess. For the registry
+	// itself, this joins the errors of all failed sources.
+	LastError error
+}
+
+// New returns a new registry for the sources in cfg. The configuration must
+// have been validated using config.Config.Validate. If cfg.CacheDir is set,
+// compiled sources are persisted there and loaded once polling is started.
+// The last cfg.SnapshotHistory snapshots are kept in memory and the last
+// cfg.SnapshotArchive snapshots are archived in cfg.CacheDir. Up to
+// cfg.ChangelogSize changelog entries are retained. Sources uploaded to
+// cfg.UploadDir are added once polling is started.
+func New(cfg config.Config) *Registry {
+	reg := &Registry{
+		cacheDir:        cfg.CacheDir,
+		snapshotHistory: max(cfg.SnapshotHistory, 1),
+		snapshotArchive: cfg.SnapshotArchive,
+		changelogSize:   max(cfg.ChangelogSize, 1),
+		refuseBreaking:  cfg.RefuseBreakingChanges,
+		conflictPolicy:  cfg.ConflictPolicy,
+		uploadDir:       cfg.UploadDir,
+		refreshInterval: time.Duration(cfg.RefreshInterval),
+		started:         make(chan struct{}),
+		refresh:         make(chan refreshRequest),
+		uploads:         make(chan uploadRequest),
 	}
+
+	for _, src := range cfg.Sources {
+		reg.sources = append(reg.sources, &source{
+			Source: src,
+			git:    parseGitSource(src, gitWorkdir(cfg.CacheDir, src.Name)),
+		})
+	}
+
+	// The changelog is loaded right away so changelog subscribers started
+	// before polling know which entries have already been recorded.
+	reg.loadChangelog()
+	reg.loadArchive()
+
+	return reg
 }
 
 func (reg *Registry) FileContainingSymbol(name protoreflect.FullName) (protoreflect.FileDescriptor, error) {
-	resolver := reg.getResolver()
+	return fileContainingSymbol(reg.getResolver(), name)
+}
+
+func (reg *Registry) FileByFilename(name string) (protoreflect.FileDescriptor, error) {
+	return fileByFilename(reg.getResolver(), name)
+}
 
+func (reg *Registry) FileContaingURL(url string) (protoreflect.FileDescriptor, error) {
+	return fileContainingURL(reg.getResolver(), url)
+}
+
+func fileContainingSymbol(resolver linker.Resolver, name protoreflect.FullName) (protoreflect.FileDescriptor, error) {
 	response, err := resolver.FindDescriptorByName(name)
 	if err != nil {
 		return nil, err
@@ -71,9 +173,7 @@ func (reg *Registry) FileContainingSymbol(name protoreflect.FullName) (protorefl
 	return response.ParentFile(), nil
 }
 
-func (reg *Registry) FileByFilename(name string) (protoreflect.FileDescriptor, error) {
-	resolver := reg.getResolver()
-
+func fileByFilename(resolver linker.Resolver, name string) (protoreflect.FileDescriptor, error) {
 	res, err := resolver.FindFileByPath(name)
 	if err != nil && errors.Is(err, protoregistry.NotFound) {
 		// fallback to the global files registry
@@ -83,9 +183,7 @@ func (reg *Registry) FileByFilename(name string) (protoreflect.FileDescriptor, e
 	return res, err
 }
 
-func (reg *Registry) FileContaingURL(url string) (protoreflect.FileDescriptor, error) {
-	resolver := reg.getResolver()
-
+func fileContainingURL(resolver linker.Resolver, url string) (protoreflect.FileDescriptor, error) {
 	message, err := resolver.FindMessageByURL(url)
 	if err != nil {
 		return nil, err
@@ -94,10 +192,247 @@ func (reg *Registry) FileContaingURL(url string) (protoreflect.FileDescriptor, e
 	return message.Descriptor().ParentFile(), nil
 }
 
+// FileContainingExtension returns the file that declares the extension with
+// the given field number of the message named by extendee.
+func (reg *Registry) FileContainingExtension(extendee protoreflect.FullName, number protoreflect.FieldNumber) (protoreflect.FileDescriptor, error) {
+	resolver := reg.getResolver()
+
+	ext, err := resolver.FindExtensionByNumber(extendee, number)
+	if err != nil {
+		return nil, err
+	}
+
+	return ext.TypeDescriptor().ParentFile(), nil
+}
+
+// ExtensionNumbersOfType returns the field numbers of all known extensions
+// of the message with the given name, in ascending order.
+func (reg *Registry) ExtensionNumbersOfType(name protoreflect.FullName) ([]protoreflect.FieldNumber, error) {
+	desc, err := reg.getResolver().FindDescriptorByName(name)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, ok := desc.(protoreflect.MessageDescriptor); !ok {
+		return nil, fmt.Errorf("%s is not a message: %w", name, protoregistry.NotFound)
+	}
+
+	seen := make(map[protoreflect.FieldNumber]struct{})
+
+	for _, file := range reg.servedFiles() {
+		walkExtensions(file, func(ext protoreflect.ExtensionDescriptor) {
+			if ext.ContainingMessage().FullName() == name {
+				seen[ext.Number()] = struct{}{}
+			}
+		})
+	}
+
+	protoregistry.GlobalTypes.RangeExtensionsByMessage(name, func(ext protoreflect.ExtensionType) bool {
+		seen[ext.TypeDescriptor().Number()] = struct{}{}
+
+		return true
+	})
+
+	result := make([]protoreflect.FieldNumber, 0, len(seen))
+	for number := range seen {
+		result = append(result, number)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i] < result[j]
+	})
+
+	return result, nil
+}
+
+// ListServices returns the full names of all services declared in served
+// files.
+func (reg *Registry) ListServices() []protoreflect.FullName {
+	var result []protoreflect.FullName
+
+	for _, file := range reg.servedFiles() {
+		services := file.Services()
+
+		for i := 0; i < services.Len(); i++ {
+			result = append(result, services.Get(i).FullName())
+		}
+	}
+
+	return result
+}
+
+// servedFiles returns all files that are currently served.
+func (reg *Registry) servedFiles() linker.Files {
+	reg.l.RLock()
+	defer reg.l.RUnlock()
+
+	return reg.files
+}
+
+// walkExtensions calls fn for all extensions declared in desc and its
+// nested messages.
+func walkExtensions(desc interface {
+	Extensions() protoreflect.ExtensionDescriptors
+	Messages() protoreflect.MessageDescriptors
+}, fn func(protoreflect.ExtensionDescriptor)) {
+	extensions := desc.Extensions()
+	for i := 0; i < extensions.Len(); i++ {
+		fn(extensions.Get(i))
+	}
+
+	messages := desc.Messages()
+	for i := 0; i < messages.Len(); i++ {
+		walkExtensions(messages.Get(i), fn)
+	}
+}
+
+// Status returns the status of the most recent source refreshes.
+func (reg *Registry) Status() Status {
+	reg.l.RLock()
+	defer reg.l.RUnlock()
+
+	return reg.status
+}
+
+// Ready reports whether the registry has a compiled snapshot to serve
+// requests from.
+func (reg *Registry) Ready() bool {
+	reg.l.RLock()
+	defer reg.l.RUnlock()
+
+	return reg.resolver != nil
+}
+
+// SourceInfo describes a configured source and its current state.
+type SourceInfo struct {
+	config.Source
+
+	// Status holds the status of the most recent refreshes of the source.
+	Status Status
+
+	// Files is the number of files in the currently served version of the
+	// source.
+	Files int
+
+	// Hash is a hash over all file descriptors of the currently served
+	// version of the source.
+	Hash string
+
+	// BreakingChanges holds the breaking changes detected the last time a
+	// new version of the source has been compiled, at BreakingChangesAt.
+	BreakingChanges   []BreakingChange
+	BreakingChangesAt time.Time
+
+	// Uploaded is set if the source has been uploaded using Upload.
+	Uploaded bool
+
+	// Revision is the git commit of the currently served version of the
+	// source. Only set for git sources.
+	Revision string
+}
+
+// Sources returns information about all configured sources.
+func (reg *Registry) Sources() []SourceInfo {
+	reg.l.RLock()
+	defer reg.l.RUnlock()
+
+	result := make([]SourceInfo, len(reg.sources))
+	for idx, src := range reg.sources {
+		result[idx] = SourceInfo{
+			Source: src.Source,
+			Status: src.status,
+			Files:  len(src.files),
+			Hash:   src.hash,
+
+			BreakingChanges:   src.breaking,
+			BreakingChangesAt: src.breakingAt,
+
+			Uploaded: src.upload != "",
+			Revision: src.revision,
+		}
+	}
+
+	return result
+}
+
+// refreshRequest is sent to the polling loop to refresh sources
+// immediately.
+type refreshRequest struct {
+	sources []int
+	done    chan struct{}
+}
+
+// Refresh immediately refreshes the sources with the given names, or all
+// sources if no names are given, and waits for the refresh to complete.
+func (reg *Registry) Refresh(ctx context.Context, names ...string) error {
+	select {
+	case <-reg.started:
+	default:
+		return ErrPollingNotStarted
+	}
+
+	req := refreshRequest{
+		done: make(chan struct{}),
+	}
+
+	if len(names) == 0 {
+		reg.l.RLock()
+		for idx := range reg.sources {
+			req.sources = append(req.sources, idx)
+		}
+		reg.l.RUnlock()
+	}
+
+	for _, name := range names {
+		idx := reg.sourceIndex(name)
+		if idx < 0 {
+			return fmt.Errorf("%w: %s", ErrUnknownSource, name)
+		}
+
+		req.sources = append(req.sources, idx)
+	}
+
+	select {
+	case reg.refresh <- req:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	select {
+	case <-req.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// sourceIndex returns the index of the source with the given name or -1.
+func (reg *Registry) sourceIndex(name string) int {
+	reg.l.RLock()
+	defer reg.l.RUnlock()
+
+	return reg.sourceIndexLocked(name)
+}
+
+// sourceIndexLocked is like sourceIndex but callers must hold reg.l.
+func (reg *Registry) sourceIndexLocked(name string) int {
+	for idx, src := range reg.sources {
+		if src.Name == name {
+			return idx
+		}
+	}
+
+	return -1
+}
+
 func (reg *Registry) getResolver() linker.Resolver {
 	reg.l.RLock()
 	defer reg.l.RUnlock()
 
+	if reg.resolver == nil {
+		return protoresolve.NewGlobalResolver()
+	}
+
 	return protoresolve.NewCombinedResolver(
 		reg.resolver,
 		protoresolve.NewGlobalResolver(),
@@ -113,78 +448,508 @@ func (reg *Registry) StartPolling(ctx context.Context) error {
 		close(reg.started)
 	}
 
+	// Load the last known snapshot so we can serve requests before
+	// all sources have been fetched.
+	reg.loadLastSnapshot()
+	reg.loadUploads()
+	reg.loadCache(ctx)
+
 	go func() {
-		ticker := time.NewTicker(reg.interval)
-		defer ticker.Stop()
+		timer := time.NewTimer(0)
+		defer timer.Stop()
 
 		for {
-			slog.Info(("updating protobuf sources"))
-			reg.updateSources(ctx)
+			var (
+				due  []int
+				done chan struct{}
+			)
 
 			select {
 			case <-ctx.Done():
 				return
 
-			case <-ticker.C:
+			case <-timer.C:
+				due = reg.dueSources(time.Now())
+
+			case req := <-reg.refresh:
+				due, done = req.sources, req.done
+
+			case req := <-reg.uploads:
+				req.result <- reg.applyUpload(ctx, req.upload)
+
+				continue
 			}
+
+			slog.Info("updating protobuf sources", "sources", len(due))
+			reg.updateSources(ctx, due)
+
+			now := time.Now()
+			for _, idx := range due {
+				reg.sources[idx].nextRefresh = now.Add(time.Duration(reg.sources[idx].RefreshInterval))
+			}
+
+			if done != nil {
+				close(done)
+			}
+
+			timer.Reset(time.Until(reg.nextRefresh()))
 		}
 	}()
 
 	return nil
 }
 
-// TODO(ppacher): use context.Context
-func (reg *Registry) updateSources(_ context.Context) {
+// dueSources returns the indexes of all sources that must be refreshed at
+// now.
+func (reg *Registry) dueSources(now time.Time) []int {
+	var due []int
+
+	for idx, src := range reg.sources {
+		if !src.nextRefresh.After(now) {
+			due = append(due, idx)
+		}
+	}
+
+	return due
+}
+
+// nextRefresh returns the time when the next source must be refreshed.
+func (reg *Registry) nextRefresh() time.Time {
+	var next time.Time
+
+	for _, src := range reg.sources {
+		if next.IsZero() || src.nextRefresh.Before(next) {
+			next = src.nextRefresh
+		}
+	}
+
+	return next
+}
+
+// updateSources fetches and compiles the sources at the given indexes.
+// Sources that import files from updated sources are linked again so they
+// refer to the updated files.
+func (reg *Registry) updateSources(ctx context.Context, due []int) {
+	started := time.Now()
+
 	var (
-		files       []string
-		importPaths []string
+		fetched   = make([]*fetchedSource, len(reg.sources))
+		errs      = make([]error, len(reg.sources))
+		refreshed = make([]bool, len(reg.sources))
+		unchanged = make([]bool, len(reg.sources))
+		revisions = make([]string, len(reg.sources))
 	)
 
-	for idx, arg := range reg.sources {
-		tmpdir, err := os.MkdirTemp("", fmt.Sprintf("pbtypes-%d-", idx))
+	for _, idx := range due {
+		src := reg.sources[idx]
+		refreshed[idx] = true
+
+		res, err := src.fetch(ctx, idx)
+		if err == nil {
+			defer os.RemoveAll(res.dir)
+
+			// sources that failed to compile are always compiled again
+			// since their dependencies may have changed.
+			res.hash = res.contentHash()
+			if res.hash != "" && res.hash == src.contentHash && src.status.LastError == nil {
+				// a new git commit may not change any file
+				revisions[idx] = res.revision
+				err = errNotModified
+			}
+		}
+
+		if errors.Is(err, errNotModified) {
+			slog.Info("protobuf source not modified, skipping compilation", "source", src.Name)
+			refreshed[idx] = false
+			unchanged[idx] = true
+
+			continue
+		}
 
 		if err != nil {
-			log.Fatal(err.Error())
+			slog.Error("failed to fetch protobuf source, keeping previous snapshot", "source", src.Name, "error", err)
+			errs[idx] = err
+
+			continue
 		}
-		defer os.RemoveAll(tmpdir)
 
-		entry := slog.With("source", arg, "destination", tmpdir)
+		fetched[idx] = res
+	}
+
+	reg.checkBufDependencies(fetched)
 
-		entry.Info("downloading proto file")
-		if err := getter.Get(tmpdir, arg); err != nil {
-			entry.Error("failed to download proto files", "error", err)
+	// nothing to compile and nothing to swap if all sources are unchanged
+	if !slices.Contains(refreshed, true) {
+		reg.markUnchanged(unchanged, revisions, started)
 
-			return
+		return
+	}
+
+	current := reg.sourceFiles()
+	addDependents(fetched, current)
+
+	compiled := reg.compileFetched(ctx, fetched, errs, current)
+
+	for idx, src := range reg.sources {
+		if !refreshed[idx] || fetched[idx] == nil || errs[idx] != nil {
+			continue
 		}
 
-		importPaths = append(importPaths, tmpdir)
+		if err := reg.storeCache(src, fetched[idx], current[idx], started); err != nil {
+			slog.Error("failed to cache protobuf source", "source", src.Name, "error", err)
+		}
+	}
 
-		// find all proto files in tmpdir
-		fs.WalkDir(os.DirFS(tmpdir), ".", func(path string, d fs.DirEntry, err error) error {
-			if filepath.Ext(path) == ".proto" {
-				files = append(files, path)
+	reg.l.Lock()
+
+	for idx, src := range reg.sources {
+		switch {
+		case refreshed[idx]:
+			src.status.LastRefresh = started
+			src.status.LastError = errs[idx]
+
+			if errs[idx] == nil {
+				src.status.LastSuccess = started
+				src.revision = fetched[idx].revision
+				src.contentHash = fetched[idx].hash
+				src.setFiles(current[idx])
+				src.provenance = src.newProvenance(current[idx], fetched[idx].origins, fetched[idx].revision, fetched[idx].fetched)
 			}
 
-			return nil
-		})
+		case fetched[idx] != nil:
+			// the source has been linked again against updated
+			// dependencies.
+			if errs[idx] == nil {
+				src.setFiles(current[idx])
+			} else {
+				src.status.LastError = errs[idx]
+			}
+		}
 	}
 
-	slog.Info("compiling protobuf sources", "paths", importPaths, "files", len(files))
+	reg.markUnchangedLocked(unchanged, revisions, started)
+
+	reg.status.LastRefresh = started
+	reg.status.LastError = reg.sourceErrors()
+
+	if compiled == 0 {
+		reg.l.Unlock()
 
-	compiler := protocompile.Compiler{
-		Resolver: protocompile.WithStandardImports(&protocompile.SourceResolver{
-			ImportPaths: importPaths,
-		}),
+		return
 	}
 
-	compiledFiles, err := compiler.Compile(context.Background(), files...)
-	if err != nil {
-		log.Fatal(err.Error())
+	reg.status.LastSuccess = started
+	prev, snap := reg.activate()
+	reg.l.Unlock()
+
+	reg.recordChangelog(prev, snap)
+}
+
+// markUnchanged updates the status of sources that have been refreshed at
+// started without any changes. revisions holds the new git commits of
+// sources whose files did not change.
+func (reg *Registry) markUnchanged(unchanged []bool, revisions []string, started time.Time) {
+	reg.l.Lock()
+	defer reg.l.Unlock()
+
+	reg.markUnchangedLocked(unchanged, revisions, started)
+	reg.status.LastRefresh = started
+}
+
+// markUnchangedLocked is like markUnchanged but callers must hold reg.l.
+func (reg *Registry) markUnchangedLocked(unchanged []bool, revisions []string, started time.Time) {
+	for idx, src := range reg.sources {
+		if !unchanged[idx] {
+			continue
+		}
+
+		src.status.LastRefresh = started
+
+		if revisions[idx] != "" && revisions[idx] != src.revision {
+			src.revision = revisions[idx]
+
+			// the files are unchanged but have been checked out at a
+			// new commit.
+			provenance := make(map[string]Provenance, len(src.provenance))
+			for path, prov := range src.provenance {
+				prov.Revision = src.revision
+				provenance[path] = prov
+			}
+
+			src.provenance = provenance
+		}
+	}
+}
+
+// activate builds the resolver and the symbol index from the files of all
+// served sources and the files of import-only sources they depend on,
+// notifies subscribers about changed files and records a new snapshot.
+// Files that conflict with files of other sources are resolved according to
+// the conflict policy. It returns the previous and the new snapshot, see
+// recordSnapshot. Callers must hold reg.l.
+func (reg *Registry) activate() (prev, snap *Snapshot) {
+	var (
+		all        linker.Files
+		seen       = make(map[string]struct{})
+		importOnly = make(map[string]linker.File)
+		provenance = make(map[string]Provenance)
+		candidates = make(map[string]Provenance)
+	)
+
+	for _, provided := range reg.resolveConflicts() {
+		var (
+			src  = reg.sources[provided.source]
+			file = provided.file
+		)
+
+		if src.ImportOnly {
+			importOnly[file.Path()] = file
+			candidates[file.Path()] = src.provenance[file.Path()]
+
+			continue
+		}
+
+		seen[file.Path()] = struct{}{}
+		all = append(all, file)
+		provenance[file.Path()] = src.provenance[file.Path()]
+	}
+
+	// add all files of import-only sources that are reachable from
+	// served files.
+	queue := append(linker.Files(nil), all...)
+	for len(queue) > 0 {
+		imports := queue[0].Imports()
+		queue = queue[1:]
+
+		for i := 0; i < imports.Len(); i++ {
+			path := imports.Get(i).Path()
+
+			if _, ok := seen[path]; ok {
+				continue
+			}
+
+			if file, ok := importOnly[path]; ok {
+				seen[path] = struct{}{}
+				all = append(all, file)
+				queue = append(queue, file)
+				provenance[path] = candidates[path]
+			}
+		}
+	}
+
+	reg.files = all
+	reg.resolver = all.AsResolver()
+	reg.index = buildIndex(all)
+
+	reg.publishChanges(all)
+	return reg.recordSnapshot(all, provenance)
+}
+
+// sourceErrors joins the last errors of all sources. Callers must hold
+// reg.l.
+func (reg *Registry) sourceErrors() error {
+	var errs []error
+
+	for _, src := range reg.sources {
+		if src.status.LastError != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", src.Name, src.status.LastError))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// compileFetched compiles all fetched sources in dependency order and
+// stores the result in current. Sources that failed to fetch or compile, as
+// recorded in errs, keep their previous files so sources depending on them
+// can still be compiled. It returns the number of sources that have been
+// compiled successfully.
+func (reg *Registry) compileFetched(ctx context.Context, fetched []*fetchedSource, errs []error, current []linker.Files) int {
+	deps := sourceDependencies(fetched, current, reg.precedence())
+	order, cyclic := sortSources(deps)
+
+	for _, idx := range cyclic {
+		if fetched[idx] != nil {
+			slog.Error("failed to compile protobuf source, keeping previous snapshot", "source", reg.sources[idx].Name, "error", ErrImportCycle)
+			errs[idx] = ErrImportCycle
+		}
+	}
+
+	compiled := 0
+	for _, idx := range order {
+		if fetched[idx] == nil {
+			continue
+		}
+
+		depFiles := make([]linker.Files, len(deps[idx]))
+		for i, dep := range deps[idx] {
+			depFiles[i] = current[dep]
+		}
+
+		slog.Info("compiling protobuf source", "source", reg.sources[idx].Name, "files", len(fetched[idx].files))
+
+		files, err := fetched[idx].compile(ctx, depFiles)
+		if err != nil {
+			slog.Error("failed to compile protobuf source, keeping previous snapshot", "source", reg.sources[idx].Name, "error", err)
+			errs[idx] = fmt.Errorf("failed to compile protobuf sources: %w", err)
+
+			continue
+		}
+
+		// sources that are only linked again did not change
+		if !fetched[idx].relink {
+			changes := breakingChanges(current[idx], files)
+			refuse := reg.refuseBreaking && hasWireBreaking(changes)
+			reg.recordBreakingChanges(idx, changes, refuse)
+
+			if refuse {
+				errs[idx] = fmt.Errorf("%w: %s", ErrBreakingChange, changes[0])
+
+				continue
+			}
+		}
+
+		if reg.conflictPolicy == config.ConflictFail {
+			conflicts := findConflicts(reg.sources, idx, files, current)
+			reg.setRefusedConflicts(idx, conflicts)
+
+			if len(conflicts) > 0 {
+				errs[idx] = fmt.Errorf("%w: %s", ErrConflict, conflicts[0])
+
+				continue
+			}
+		}
+
+		current[idx] = files
+		compiled++
+	}
+
+	return compiled
+}
+
+// recordBreakingChanges logs and records the breaking changes detected for
+// the source at idx. Changes recorded for a previous version are cleared if
+// changes is empty.
+func (reg *Registry) recordBreakingChanges(idx int, changes []BreakingChange, refused bool) {
+	src := reg.sources[idx]
+
+	for _, change := range changes {
+		slog.Warn("detected breaking change", "source", src.Name, "kind", change.Kind, "element", change.Element, "wire", change.Wire, "refused", refused, "change", change.Description)
 	}
 
 	reg.l.Lock()
 	defer reg.l.Unlock()
 
-	reg.files = compiledFiles
-	reg.resolver = compiledFiles.AsResolver()
+	src.breaking = changes
+	src.breakingAt = time.Time{}
+
+	if len(changes) > 0 {
+		src.breakingAt = time.Now()
+	}
+}
+
+// sourceFiles returns the currently active files of each source.
+func (reg *Registry) sourceFiles() []linker.Files {
+	reg.l.RLock()
+	defer reg.l.RUnlock()
+
+	result := make([]linker.Files, len(reg.sources))
+	for idx, src := range reg.sources {
+		result[idx] = src.files
+	}
+
+	return result
+}
+
+// addDependents adds all sources that import files from fetched sources to
+// fetched so they are linked again using their current files.
+func addDependents(fetched []*fetchedSource, current []linker.Files) {
+	for changed := true; changed; {
+		changed = false
+
+		for idx, files := range current {
+			if fetched[idx] != nil || len(files) == 0 {
+				continue
+			}
+
+			if importsFrom(files, fetched) {
+				fetched[idx] = newProtoSource(filesToProtos(files))
+				fetched[idx].relink = true
+				changed = true
+			}
+		}
+	}
+}
+
+// importsFrom reports whether any of files imports a file from one of the
+// fetched sources.
+func importsFrom(files linker.Files, fetched []*fetchedSource) bool {
+	for _, file := range files {
+		imports := file.Imports()
+
+		for i := 0; i < imports.Len(); i++ {
+			path := imports.Get(i).Path()
+
+			// skip imports of files that are part of the source itself
+			if files.FindFileByPath(path) != nil {
+				continue
+			}
+
+			for _, src := range fetched {
+				if src != nil && src.contains(path) {
+					return true
+				}
+			}
+		}
+	}
+
+	return false
+}
+
+// sourceDependencies returns the indexes of all sources each fetched source
+// imports files from. Imported files are looked up in the fetched sources
+// first and in the previously compiled files of sources that could not be
+// fetched.
+func sourceDependencies(fetched []*fetchedSource, current []linker.Files, precedence []int) [][]int {
+	deps := make([][]int, len(fetched))
+
+	for idx, src := range fetched {
+		if src == nil {
+			continue
+		}
+
+		seen := make(map[int]struct{})
+
+		for _, imp := range src.imports {
+			if src.contains(imp) {
+				continue
+			}
+
+			for _, other := range precedence {
+				if other == idx {
+					continue
+				}
+
+				found := false
+				if fetched[other] != nil {
+					found = fetched[other].contains(imp)
+				} else {
+					found = current[other].FindFileByPath(imp) != nil
+				}
+
+				if !found {
+					continue
+				}
+
+				if _, ok := seen[other]; !ok {
+					seen[other] = struct{}{}
+					deps[idx] = append(deps[idx], other)
+				}
+
+				break
+			}
+		}
+	}
+
+	return deps
 }
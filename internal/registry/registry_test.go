@@ -0,0 +1,143 @@
+package registry
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/tierklinik-dobersberg/pbtype-server/internal/config"
+)
+
+// startPolling validates cfg and starts polling a new registry for it
+// until the test is done.
+func startPolling(t *testing.T, cfg config.Config) (*Registry, context.Context) {
+	t.Helper()
+
+	if err := cfg.Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	reg := New(cfg)
+	if err := reg.StartPolling(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() {
+		cancel()
+		reg.writes.wait()
+	})
+
+	return reg, ctx
+}
+
+func TestImportOnlySources(t *testing.T) {
+	var (
+		app  = t.TempDir()
+		deps = t.TempDir()
+	)
+
+	writeFiles(t, deps, map[string]string{
+		"dep/v1/direct.proto":     `syntax = "proto3"; package dep.v1; import "dep/v1/transitive.proto"; message Direct { Transitive t = 1; }`,
+		"dep/v1/transitive.proto": `syntax = "proto3"; package dep.v1; message Transitive {}`,
+		"dep/v1/unused.proto":     `syntax = "proto3"; package dep.v1; message Unused {}`,
+	})
+
+	cfg := config.Config{
+		RefreshInterval: config.Duration(time.Hour),
+		Sources: []config.Source{
+			{Name: "app", URL: app},
+			{Name: "deps", URL: deps, ImportOnly: true},
+		},
+	}
+
+	reg, ctx := startPolling(t, cfg)
+
+	served := func(t *testing.T, want map[string]bool) {
+		t.Helper()
+
+		if err := reg.Refresh(ctx); err != nil {
+			t.Fatal(err)
+		}
+
+		for path, ok := range want {
+			if _, err := reg.FileByFilename(path); (err == nil) != ok {
+				t.Errorf("%s: expected served to be %t, got error %v", path, ok, err)
+			}
+		}
+	}
+
+	t.Run("imported", func(t *testing.T) {
+		writeFiles(t, app, map[string]string{
+			"app/v1/app.proto": `syntax = "proto3"; package app.v1; import "dep/v1/direct.proto"; message App { dep.v1.Direct d = 1; }`,
+		})
+
+		// direct and transitive imports are served, other files are not
+		served(t, map[string]bool{
+			"app/v1/app.proto":        true,
+			"dep/v1/direct.proto":     true,
+			"dep/v1/transitive.proto": true,
+			"dep/v1/unused.proto":     false,
+		})
+	})
+
+	t.Run("no longer imported", func(t *testing.T) {
+		writeFiles(t, app, map[string]string{
+			"app/v1/app.proto": `syntax = "proto3"; package app.v1; message App {}`,
+		})
+
+		served(t, map[string]bool{
+			"app/v1/app.proto":        true,
+			"dep/v1/direct.proto":     false,
+			"dep/v1/transitive.proto": false,
+			"dep/v1/unused.proto":     false,
+		})
+	})
+}
+
+func TestRefreshKeepsLastSnapshot(t *testing.T) {
+	dir := t.TempDir()
+
+	writeFiles(t, dir, map[string]string{
+		"acme/v1/a.proto": `syntax = "proto3"; package acme.v1; message A {}`,
+	})
+
+	cfg := config.Config{
+		RefreshInterval: config.Duration(time.Hour),
+		Sources:         []config.Source{{Name: "local", URL: dir}},
+	}
+
+	reg, ctx := startPolling(t, cfg)
+
+	if err := reg.Refresh(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	good := reg.Status()
+	if good.LastError != nil || good.LastSuccess.IsZero() {
+		t.Fatalf("unexpected status after the first refresh %+v", good)
+	}
+
+	writeFiles(t, dir, map[string]string{
+		"acme/v1/a.proto": `syntax = "proto3"; package acme.v1; message A {`,
+	})
+
+	if err := reg.Refresh(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	// the failure is reported but the last snapshot is still served
+	status := reg.Status()
+	if status.LastError == nil || !status.LastSuccess.Equal(good.LastSuccess) || !status.LastRefresh.After(good.LastRefresh) {
+		t.Errorf("unexpected status after a failed refresh %+v", status)
+	}
+
+	if _, err := reg.FileContainingSymbol("acme.v1.A"); err != nil {
+		t.Errorf("expected the last snapshot to be served: %s", err)
+	}
+
+	if !reg.Ready() {
+		t.Errorf("expected the registry to stay ready")
+	}
+}
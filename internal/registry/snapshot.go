@@ -0,0 +1,637 @@
+package registry
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bufbuild/protocompile/linker"
+	"github.com/tierklinik-dobersberg/pbtype-server/internal/config"
+	"github.com/tierklinik-dobersberg/pbtype-server/pkg/protoresolve"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// ErrUnknownSnapshot is returned if a requested snapshot does not exist or
+// is no longer retained.
+var ErrUnknownSnapshot = errors.New("unknown snapshot")
+
+// loadedSnapshotsSize is the number of snapshots loaded from the archive
+// that are kept in memory.
+const loadedSnapshotsSize = 8
+
+// SnapshotInfo describes a snapshot of the served files.
+type SnapshotInfo struct {
+	// ID is a sequence number that is incremented for each new snapshot.
+	ID uint64 `json:"id"`
+
+	// Hash is a hash over the paths and hashes of all files in the
+	// snapshot.
+	Hash string `json:"hash"`
+
+	// Created is the time the snapshot has been activated.
+	Created time.Time `json:"created"`
+
+	// Sources holds the revision of each source that went into the
+	// snapshot.
+	Sources []SourceRevision `json:"sources"`
+
+	// Provenance holds the provenance of each file that has been provided
+	// by a source, by path.
+	Provenance map[string]Provenance `json:"provenance,omitempty"`
+
+	// InMemory is set if the snapshot can be resolved without loading it
+	// from the archive.
+	InMemory bool `json:"-"`
+}
+
+// SourceRevision identifies the version of a source that went into a
+// snapshot.
+type SourceRevision struct {
+	// Name is the name of the source and URL its URL without credentials.
+	Name string `json:"name"`
+	URL  string `json:"url"`
+
+	// Hash is a hash over all file descriptors of the source.
+	Hash string `json:"hash"`
+
+	// Revision is the git commit of the source. Only set for git sources.
+	Revision string `json:"revision,omitempty"`
+}
+
+// Snapshot is an immutable set of files activated by the registry.
+type Snapshot struct {
+	SnapshotInfo
+
+	files    linker.Files
+	resolver linker.Resolver
+
+	// index is built on first use, see getIndex.
+	indexOnce sync.Once
+	index     *index
+}
+
+func (snap *Snapshot) FileContainingSymbol(name protoreflect.FullName) (protoreflect.FileDescriptor, error) {
+	return fileContainingSymbol(snap.getResolver(), name)
+}
+
+func (snap *Snapshot) FileByFilename(name string) (protoreflect.FileDescriptor, error) {
+	return fileByFilename(snap.getResolver(), name)
+}
+
+func (snap *Snapshot) FileContaingURL(url string) (protoreflect.FileDescriptor, error) {
+	return fileContainingURL(snap.getResolver(), url)
+}
+
+// getIndex returns the index of the files of the snapshot.
+func (snap *Snapshot) getIndex() *index {
+	snap.indexOnce.Do(func() {
+		snap.index = buildIndex(snap.files)
+	})
+
+	return snap.index
+}
+
+func (snap *Snapshot) getResolver() linker.Resolver {
+	return protoresolve.NewCombinedResolver(
+		snap.resolver,
+		protoresolve.NewGlobalResolver(),
+	)
+}
+
+// Snapshot returns the snapshot identified by ref, which is either the ID
+// or a unique prefix of the hash of a snapshot. If ref is empty, the current
+// snapshot is returned. Snapshots that are no longer kept in memory are
+// loaded from the archive.
+func (reg *Registry) Snapshot(ctx context.Context, ref string) (*Snapshot, error) {
+	if snap, err := reg.memorySnapshot(ref); err == nil || ref == "" {
+		return snap, err
+	}
+
+	if reg.snapshotArchive == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownSnapshot, ref)
+	}
+
+	if snap := reg.loaded.find(ref); snap != nil {
+		return snap, nil
+	}
+
+	info, err := findSnapshot(reg.archived.list(), ref)
+	if err != nil {
+		return nil, err
+	}
+
+	if snap := reg.loaded.find(strconv.FormatUint(info.ID, 10)); snap != nil {
+		return snap, nil
+	}
+
+	snap, err := reg.loadArchivedSnapshot(ctx, info.ID)
+	if errors.Is(err, os.ErrNotExist) {
+		// the snapshot has been removed from the archive in the meantime
+		return nil, fmt.Errorf("%w: %s", ErrUnknownSnapshot, ref)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return reg.loaded.add(snap), nil
+}
+
+// snapshotCache holds the most recently used snapshots loaded from the
+// archive so requests pinned to them do not link them again.
+type snapshotCache struct {
+	l sync.Mutex
+
+	// snapshots is ordered by last use, most recently used last.
+	snapshots []*Snapshot
+}
+
+// find returns the cached snapshot identified by ref, which must be its ID
+// or its full hash since a hash prefix can only be resolved using the
+// whole archive. It returns nil if no snapshot matches.
+func (cache *snapshotCache) find(ref string) *Snapshot {
+	cache.l.Lock()
+	defer cache.l.Unlock()
+
+	for idx, snap := range cache.snapshots {
+		if strconv.FormatUint(snap.ID, 10) == ref || snap.Hash == strings.ToLower(ref) {
+			cache.snapshots = append(slices.Delete(cache.snapshots, idx, idx+1), snap)
+
+			return snap
+		}
+	}
+
+	return nil
+}
+
+// add adds snap to the cache, evicting the least recently used snapshot if
+// the cache is full. If the snapshot has been loaded concurrently, the
+// cached one is returned.
+func (cache *snapshotCache) add(snap *Snapshot) *Snapshot {
+	cache.l.Lock()
+	defer cache.l.Unlock()
+
+	for _, cached := range cache.snapshots {
+		if cached.ID == snap.ID {
+			return cached
+		}
+	}
+
+	cache.snapshots = append(cache.snapshots, snap)
+	if n := len(cache.snapshots); n > loadedSnapshotsSize {
+		cache.snapshots = cache.snapshots[n-loadedSnapshotsSize:]
+	}
+
+	return snap
+}
+
+// archiveIndex lists the archived snapshots so they can be found without
+// reading the whole archive. The provenance of the snapshots is not kept in
+// the index. It has its own lock.
+type archiveIndex struct {
+	l sync.Mutex
+
+	// infos is ordered by ID.
+	infos []SnapshotInfo
+}
+
+// list returns the archived snapshots, ordered by ID.
+func (archive *archiveIndex) list() []SnapshotInfo {
+	archive.l.Lock()
+	defer archive.l.Unlock()
+
+	return slices.Clone(archive.infos)
+}
+
+// add adds info to the index and removes the oldest snapshots exceeding
+// limit. It returns the removed snapshots.
+func (archive *archiveIndex) add(info SnapshotInfo, limit int) []SnapshotInfo {
+	archive.l.Lock()
+	defer archive.l.Unlock()
+
+	info.Provenance = nil
+	archive.infos = append(archive.infos, info)
+
+	var removed []SnapshotInfo
+	if n := len(archive.infos); n > limit {
+		removed = slices.Clone(archive.infos[:n-limit])
+		archive.infos = slices.Delete(archive.infos, 0, n-limit)
+	}
+
+	return removed
+}
+
+// Snapshots returns information about all retained snapshots, ordered by
+// ID. Archived snapshots are returned without their provenance.
+func (reg *Registry) Snapshots() ([]SnapshotInfo, error) {
+	var (
+		result []SnapshotInfo
+		seen   = make(map[uint64]struct{})
+	)
+
+	reg.l.RLock()
+	for _, snap := range reg.snapshots {
+		result = append(result, snap.SnapshotInfo)
+		seen[snap.ID] = struct{}{}
+	}
+	reg.l.RUnlock()
+
+	for _, info := range reg.archived.list() {
+		if _, ok := seen[info.ID]; !ok {
+			result = append(result, info)
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].ID < result[j].ID
+	})
+
+	return result, nil
+}
+
+func (reg *Registry) memorySnapshot(ref string) (*Snapshot, error) {
+	reg.l.RLock()
+	defer reg.l.RUnlock()
+
+	if len(reg.snapshots) == 0 {
+		return nil, fmt.Errorf("%w: no snapshot activated yet", ErrUnknownSnapshot)
+	}
+
+	if ref == "" {
+		return reg.snapshots[len(reg.snapshots)-1], nil
+	}
+
+	infos := make([]SnapshotInfo, len(reg.snapshots))
+	for idx, snap := range reg.snapshots {
+		infos[idx] = snap.SnapshotInfo
+	}
+
+	info, err := findSnapshot(infos, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, snap := range reg.snapshots {
+		if snap.ID == info.ID {
+			return snap, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: %s", ErrUnknownSnapshot, ref)
+}
+
+// findSnapshot returns the snapshot in infos identified by ref.
+func findSnapshot(infos []SnapshotInfo, ref string) (SnapshotInfo, error) {
+	if id, err := strconv.ParseUint(ref, 10, 64); err == nil {
+		for _, info := range infos {
+			if info.ID == id {
+				return info, nil
+			}
+		}
+	}
+
+	var matches []SnapshotInfo
+	for _, info := range infos {
+		if strings.HasPrefix(info.Hash, strings.ToLower(ref)) {
+			matches = append(matches, info)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return SnapshotInfo{}, fmt.Errorf("%w: %s", ErrUnknownSnapshot, ref)
+	case 1:
+		return matches[0], nil
+	}
+
+	// the same content might have been activated more than once.
+	for _, info := range matches[1:] {
+		if info.Hash != matches[0].Hash {
+			return SnapshotInfo{}, fmt.Errorf("%w: ambiguous hash prefix %s", ErrUnknownSnapshot, ref)
+		}
+	}
+
+	return matches[len(matches)-1], nil
+}
+
+// recordSnapshot creates a new snapshot of files, provided as described by
+// provenance, unless they equal the current snapshot. It returns the
+// replaced and the new snapshot so the changes can be recorded in the
+// changelog once reg.l has been released; both are nil if no snapshot has
+// been created. Callers must hold reg.l and must have updated
+// reg.fileHashes.
+func (reg *Registry) recordSnapshot(files linker.Files, provenance map[string]Provenance) (prev, snap *Snapshot) {
+	hash := snapshotHash(reg.fileHashes)
+
+	if n := len(reg.snapshots); n > 0 {
+		prev = reg.snapshots[n-1]
+	}
+
+	if prev != nil && prev.Hash == hash {
+		return nil, nil
+	}
+
+	snap = &Snapshot{
+		SnapshotInfo: SnapshotInfo{
+			ID:         reg.lastSnapshot.ID + 1,
+			Hash:       hash,
+			Created:    time.Now(),
+			InMemory:   true,
+			Provenance: provenance,
+		},
+		files:    files,
+		resolver: files.AsResolver(),
+	}
+
+	for _, src := range reg.sources {
+		if len(src.files) > 0 {
+			snap.Sources = append(snap.Sources, SourceRevision{
+				Name:     src.Name,
+				URL:      config.RedactURL(src.URL),
+				Hash:     src.hash,
+				Revision: src.revision,
+			})
+		}
+	}
+
+	// a snapshot loaded from the cache on startup matches the last
+	// snapshot before the restart.
+	restored := len(reg.snapshots) == 0 && reg.lastSnapshot.Hash == hash
+	if restored {
+		snap.ID = reg.lastSnapshot.ID
+		snap.Created = reg.lastSnapshot.Created
+	}
+
+	reg.lastSnapshot = snap.SnapshotInfo
+	reg.snapshots = append(reg.snapshots, snap)
+
+	if n := len(reg.snapshots); n > reg.snapshotHistory {
+		reg.snapshots = reg.snapshots[n-reg.snapshotHistory:]
+	}
+
+	slog.Info("activated new snapshot", "id", snap.ID, "hash", snap.Hash, "files", len(files))
+
+	if !restored && reg.cacheDir != "" {
+		info := snap.SnapshotInfo
+
+		reg.writes.enqueue(func() {
+			reg.storeLastSnapshot(info)
+		})
+	}
+
+	if !restored && reg.snapshotArchive > 0 {
+		reg.writes.enqueue(func() {
+			reg.archiveSnapshot(snap)
+		})
+	}
+
+	return prev, snap
+}
+
+// snapshotHash returns a hex encoded SHA-256 hash over the paths and hashes
+// of all files, ordered by path.
+func snapshotHash(fileHashes map[string]string) string {
+	paths := make([]string, 0, len(fileHashes))
+	for path := range fileHashes {
+		paths = append(paths, path)
+	}
+
+	sort.Strings(paths)
+
+	hash := sha256.New()
+	for _, path := range paths {
+		fmt.Fprintf(hash, "%s\x00%s\n", path, fileHashes[path])
+	}
+
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// archiveDir returns the directory used to archive snapshots.
+func (reg *Registry) archiveDir() string {
+	return filepath.Join(reg.cacheDir, "snapshots")
+}
+
+// archiveSnapshot writes snap to the archive and removes the oldest
+// archived snapshots exceeding the configured limit.
+func (reg *Registry) archiveSnapshot(snap *Snapshot) {
+	set := &descriptorpb.FileDescriptorSet{
+		File: filesToProtos(snap.files),
+	}
+
+	blob, err := proto.Marshal(set)
+	if err != nil {
+		slog.Error("failed to marshal snapshot", "id", snap.ID, "error", err)
+
+		return
+	}
+
+	info := snap.SnapshotInfo
+	info.InMemory = false
+
+	metaBlob, err := json.MarshalIndent(info, "", "  ")
+	if err != nil {
+		slog.Error("failed to marshal snapshot metadata", "id", snap.ID, "error", err)
+
+		return
+	}
+
+	dir := reg.archiveDir()
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		slog.Error("failed to create snapshot archive", "error", err)
+
+		return
+	}
+
+	base := filepath.Join(dir, strconv.FormatUint(snap.ID, 10))
+
+	// the archive is indexed by the metadata of the snapshots, so the
+	// descriptors are written first and an interrupted write is never
+	// listed.
+	if err := writeFileAtomic(base+".binpb", blob); err != nil {
+		slog.Error("failed to archive snapshot", "id", snap.ID, "error", err)
+
+		return
+	}
+
+	if err := writeFileAtomic(base+".json", metaBlob); err != nil {
+		slog.Error("failed to archive snapshot", "id", snap.ID, "error", err)
+
+		return
+	}
+
+	for _, removed := range reg.archived.add(info, reg.snapshotArchive) {
+		base := filepath.Join(dir, strconv.FormatUint(removed.ID, 10))
+
+		os.Remove(base + ".json")
+		os.Remove(base + ".binpb")
+	}
+}
+
+// loadArchive builds the index of the snapshot archive. Snapshots exceeding
+// the configured limit are removed once the next snapshot is archived.
+func (reg *Registry) loadArchive() {
+	if reg.snapshotArchive == 0 {
+		return
+	}
+
+	infos, err := reg.readArchive()
+	if err != nil {
+		slog.Error("failed to read snapshot archive", "error", err)
+
+		return
+	}
+
+	for idx := range infos {
+		infos[idx].Provenance = nil
+	}
+
+	reg.archived.l.Lock()
+	reg.archived.infos = infos
+	reg.archived.l.Unlock()
+}
+
+// readArchive returns the metadata of all archived snapshots, ordered by
+// ID.
+func (reg *Registry) readArchive() ([]SnapshotInfo, error) {
+	entries, err := os.ReadDir(reg.archiveDir())
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	var result []SnapshotInfo
+	for _, entry := range entries {
+		if filepath.Ext(entry.Name()) != ".json" {
+			continue
+		}
+
+		blob, err := os.ReadFile(filepath.Join(reg.archiveDir(), entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		var info SnapshotInfo
+		if err := json.Unmarshal(blob, &info); err != nil {
+			return nil, fmt.Errorf("failed to parse snapshot metadata %s: %w", entry.Name(), err)
+		}
+
+		result = append(result, info)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].ID < result[j].ID
+	})
+
+	return result, nil
+}
+
+// loadArchivedSnapshot reads and links the archived snapshot with the given
+// ID.
+func (reg *Registry) loadArchivedSnapshot(ctx context.Context, id uint64) (*Snapshot, error) {
+	base := filepath.Join(reg.archiveDir(), strconv.FormatUint(id, 10))
+
+	metaBlob, err := os.ReadFile(base + ".json")
+	if err != nil {
+		return nil, err
+	}
+
+	var info SnapshotInfo
+	if err := json.Unmarshal(metaBlob, &info); err != nil {
+		return nil, fmt.Errorf("failed to parse snapshot metadata %d: %w", id, err)
+	}
+
+	blob, err := os.ReadFile(base + ".binpb")
+	if err != nil {
+		return nil, err
+	}
+
+	var set descriptorpb.FileDescriptorSet
+	if err := proto.Unmarshal(blob, &set); err != nil {
+		return nil, fmt.Errorf("failed to parse snapshot %d: %w", info.ID, err)
+	}
+
+	files, err := newProtoSource(set.File).compile(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to link snapshot %d: %w", info.ID, err)
+	}
+
+	return &Snapshot{
+		SnapshotInfo: info,
+		files:        files,
+		resolver:     files.AsResolver(),
+	}, nil
+}
+
+// lastSnapshotPath returns the path of the file holding the most recently
+// activated snapshot. It is kept outside of the archive so snapshot IDs are
+// persisted even if snapshots are not archived.
+func (reg *Registry) lastSnapshotPath() string {
+	return filepath.Join(reg.cacheDir, "snapshot.json")
+}
+
+// storeLastSnapshot persists info as the most recently activated snapshot.
+func (reg *Registry) storeLastSnapshot(info SnapshotInfo) {
+	// the provenance is only needed to resolve archived snapshots
+	info.Provenance = nil
+
+	blob, err := json.MarshalIndent(info, "", "  ")
+	if err != nil {
+		slog.Error("failed to marshal snapshot metadata", "id", info.ID, "error", err)
+
+		return
+	}
+
+	if err := os.MkdirAll(reg.cacheDir, 0o755); err != nil {
+		slog.Error("failed to create cache directory", "error", err)
+
+		return
+	}
+
+	if err := writeFileAtomic(reg.lastSnapshotPath(), blob); err != nil {
+		slog.Error("failed to store last snapshot", "id", info.ID, "error", err)
+	}
+}
+
+// loadLastSnapshot restores the most recently activated snapshot from the
+// cache directory, so snapshot and changelog IDs keep increasing across
+// restarts.
+func (reg *Registry) loadLastSnapshot() {
+	if reg.cacheDir == "" {
+		return
+	}
+
+	blob, err := os.ReadFile(reg.lastSnapshotPath())
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			slog.Error("failed to read last snapshot", "error", err)
+		}
+
+		return
+	}
+
+	var info SnapshotInfo
+	if err := json.Unmarshal(blob, &info); err != nil {
+		slog.Error("failed to parse last snapshot", "error", err)
+
+		return
+	}
+
+	reg.lastSnapshot = info
+}
@@ -0,0 +1,140 @@
+package registry
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/bufbuild/protocompile/linker"
+	"github.com/tierklinik-dobersberg/pbtype-server/internal/config"
+)
+
+// activate records files as a new snapshot of reg.
+func activate(t *testing.T, reg *Registry, files linker.Files) *Snapshot {
+	t.Helper()
+
+	reg.l.Lock()
+	defer reg.l.Unlock()
+
+	reg.publishChanges(files)
+	_, snap := reg.recordSnapshot(files, nil)
+
+	return snap
+}
+
+func TestSnapshotArchive(t *testing.T) {
+	const header = `syntax = "proto3"; package test.v1; `
+
+	var (
+		cfg = config.Config{
+			CacheDir:        t.TempDir(),
+			SnapshotHistory: 1,
+			SnapshotArchive: 2,
+		}
+		versions = []linker.Files{
+			compileFiles(t, map[string]string{"a.proto": header + `message A {}`}),
+			compileFiles(t, map[string]string{"a.proto": header + `message A { string name = 1; }`}),
+			compileFiles(t, map[string]string{"b.proto": header + `message B {}`}),
+		}
+		ctx = context.Background()
+	)
+
+	reg := New(cfg)
+	for _, files := range versions {
+		activate(t, reg, files)
+	}
+
+	reg.writes.wait()
+
+	infos, err := reg.Snapshots()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the first snapshot has been evicted from memory and the archive
+	if len(infos) != 2 || infos[0].ID != 2 || infos[0].InMemory || infos[1].ID != 3 || !infos[1].InMemory {
+		t.Fatalf("unexpected snapshots %+v", infos)
+	}
+
+	if _, err := os.Stat(filepath.Join(reg.archiveDir(), "1.binpb")); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("expected the first snapshot to be removed from the archive, got %v", err)
+	}
+
+	archived, err := reg.Snapshot(ctx, infos[0].Hash[:8])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := archived.FileContainingSymbol("test.v1.A"); err != nil {
+		t.Errorf("failed to resolve test.v1.A in the archived snapshot: %s", err)
+	}
+
+	if again, err := reg.Snapshot(ctx, "2"); err != nil || again != archived {
+		t.Errorf("expected the archived snapshot to be loaded once, got %v", err)
+	}
+
+	t.Run("restart", func(t *testing.T) {
+		restarted := New(cfg)
+		t.Cleanup(restarted.writes.wait)
+
+		restarted.loadLastSnapshot()
+
+		// the archive is indexed on startup, so pinned requests only read
+		// the requested snapshot
+		if err := os.WriteFile(filepath.Join(restarted.archiveDir(), "3.json"), []byte("{"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+
+		if snap, err := restarted.Snapshot(ctx, infos[0].Hash); err != nil || snap.ID != 2 {
+			t.Errorf("expected archived snapshot 2, got %v", err)
+		}
+
+		if _, err := restarted.Snapshot(ctx, "999999999"); !errors.Is(err, ErrUnknownSnapshot) {
+			t.Errorf("expected ErrUnknownSnapshot, got %v", err)
+		}
+
+		if restarted.lastSnapshot.ID != 3 || restarted.lastSnapshot.Hash != infos[1].Hash {
+			t.Fatalf("unexpected last snapshot %+v", restarted.lastSnapshot)
+		}
+
+		// the files served before the restart keep their snapshot
+		if snap := activate(t, restarted, versions[2]); snap.ID != 3 || !snap.Created.Equal(infos[1].Created) {
+			t.Errorf("expected the last snapshot to be restored, got %+v", snap.SnapshotInfo)
+		}
+
+		if snap := activate(t, restarted, versions[0]); snap.ID != 4 {
+			t.Errorf("expected snapshot 4, got %d", snap.ID)
+		}
+	})
+}
+
+func TestSnapshotCache(t *testing.T) {
+	var cache snapshotCache
+
+	for id := uint64(1); id <= loadedSnapshotsSize; id++ {
+		cache.add(&Snapshot{SnapshotInfo: SnapshotInfo{ID: id, Hash: "hash" + strconv.FormatUint(id, 10)}})
+	}
+
+	first := cache.find("1")
+	if first == nil {
+		t.Fatal("expected snapshot 1 to be cached")
+	}
+
+	if cached := cache.add(&Snapshot{SnapshotInfo: SnapshotInfo{ID: 1}}); cached != first {
+		t.Errorf("expected the cached snapshot to be returned")
+	}
+
+	// snapshot 1 has been used most recently, so 2 is evicted
+	cache.add(&Snapshot{SnapshotInfo: SnapshotInfo{ID: loadedSnapshotsSize + 1}})
+
+	if cache.find("hash1") == nil {
+		t.Errorf("expected snapshot 1 to be cached")
+	}
+
+	if cache.find("2") != nil {
+		t.Errorf("expected snapshot 2 to be evicted")
+	}
+}
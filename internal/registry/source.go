@@ -0,0 +1,605 @@
+package registry
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/bufbuild/protocompile"
+	"github.com/bufbuild/protocompile/ast"
+	"github.com/bufbuild/protocompile/linker"
+	"github.com/bufbuild/protocompile/parser"
+	"github.com/bufbuild/protocompile/reporter"
+	"github.com/hashicorp/go-getter"
+	"github.com/tierklinik-dobersberg/pbtype-server/internal/config"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// ErrImportCycle is returned when two or more sources import files from each
+// other.
+var ErrImportCycle = errors.New("import cycle between sources")
+
+// source is a single protobuf source that is downloaded and compiled as
+// its own unit.
+type source struct {
+	config.Source
+
+	// nextRefresh is only accessed by the polling loop.
+	nextRefresh time.Time
+
+	// files holds the files of the last successful compilation, hash
+	// a hash over them and status the outcome of the last refresh. All
+	// are guarded by Registry.l.
+	files  linker.Files
+	hash   string
+	status Status
+
+	// breaking holds the breaking changes detected the last time a new
+	// version of the source has been compiled, at breakingAt. Guarded by
+	// Registry.l.
+	breaking   []BreakingChange
+	breakingAt time.Time
+
+	// upload is the path, without extension, of the stored files if the
+	// source has been uploaded instead of being configured.
+	upload string
+
+	// bufModules holds the names of the buf modules found the last time
+	// the source has been downloaded. Only accessed by the polling loop.
+	bufModules []string
+
+	// git is set if the source is checked out using a persistent git
+	// working copy. revision holds the commit of the served files and is
+	// guarded by Registry.l.
+	git      *gitRepository
+	revision string
+
+	// contentHash is the hash over the fetched files of the last
+	// successful compilation. Only accessed by the polling loop.
+	contentHash string
+
+	// provenance holds the provenance of all files, by path. Guarded by
+	// Registry.l.
+	provenance map[string]Provenance
+
+	// conflicts holds the conflicts that caused the last new version of
+	// the source to be refused. Guarded by Registry.l.
+	conflicts []Conflict
+}
+
+// setFiles replaces the files of the source and updates its hash.
+func (src *source) setFiles(files linker.Files) {
+	src.files = files
+	src.hash = hashFiles(files)
+}
+
+// fetchedSource holds the result of downloading a source or loading it from
+// the cache.
+type fetchedSource struct {
+	// dir is the directory the source has been downloaded to and roots
+	// the import paths within dir. There is one import path per buf
+	// module or just the import root if the source does not contain buf
+	// modules.
+	dir   string
+	roots []string
+	files []string
+	paths map[string]struct{}
+
+	// protos holds already compiled file descriptors that are linked
+	// instead of being compiled from source files in dir. It is set for
+	// cached sources and for sources that contain descriptor sets.
+	protos map[string]*descriptorpb.FileDescriptorProto
+
+	// sources is set if the source is compiled from source files held in
+	// memory instead of source files in dir.
+	sources map[string]string
+
+	// imports holds all files that are imported by files of this source.
+	imports []string
+
+	// bufDeps holds the buf modules the source depends on according to
+	// its buf.yaml and buf.lock files.
+	bufDeps []string
+
+	// revision is the git commit the source has been checked out at.
+	revision string
+
+	// hash is a hash over the content of all files, see contentHash.
+	hash string
+
+	// origins maps the paths of files to their path within the downloaded
+	// source if they differ and fetched is the time the source has been
+	// fetched.
+	origins map[string]string
+	fetched time.Time
+
+	// relink is set if the source has not been fetched but is only linked
+	// again against updated dependencies.
+	relink bool
+}
+
+func (f *fetchedSource) contains(path string) bool {
+	_, ok := f.paths[path]
+
+	return ok
+}
+
+// fetch downloads the source into a new temporary directory and searches
+// for all protobuf files. The caller is responsible to remove the returned
+// directory. Uploaded sources are read from the upload directory instead.
+// Git sources are checked out in their working copy, which is kept, and
+// errNotModified is returned if the checked out commit is already served.
+func (src *source) fetch(ctx context.Context, idx int) (*fetchedSource, error) {
+	if src.upload != "" {
+		return readUpload(src.upload)
+	}
+
+	var (
+		tmpdir   string
+		dest     string
+		base     string
+		revision string
+		err      error
+	)
+
+	if src.git != nil {
+		slog.Info("updating git working copy", "source", src.Name, "workdir", src.git.workdir)
+
+		// only the polling loop modifies the revision and the status.
+		// Sources that failed to compile are always compiled again.
+		current := src.revision
+		if src.status.LastError != nil {
+			current = ""
+		}
+
+		revision, err = src.git.checkout(ctx, current)
+		if err != nil {
+			if errors.Is(err, errNotModified) {
+				return nil, err
+			}
+
+			return nil, fmt.Errorf("failed to check out git repository: %w", err)
+		}
+
+		// original paths are relative to the repository
+		base = src.git.workdir
+		dest = filepath.Join(base, filepath.FromSlash(src.git.subdir))
+	} else {
+		tmpdir, err = os.MkdirTemp("", fmt.Sprintf("pbtypes-%d-", idx))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create temporary directory: %w", err)
+		}
+
+		// local directories are symlinked to the destination which must not
+		// exist yet.
+		dest = filepath.Join(tmpdir, "src")
+
+		slog.Info("downloading proto file", "source", src.Name, "destination", dest)
+
+		// GetAny also supports URLs pointing to a single descriptor set
+		if err := getter.GetAny(dest, src.URL); err != nil {
+			os.RemoveAll(tmpdir)
+
+			return nil, fmt.Errorf("failed to download proto files: %w", err)
+		}
+
+		base = dest
+	}
+
+	entry := slog.With("source", src.Name, "destination", dest)
+
+	root := filepath.Join(dest, filepath.FromSlash(src.ImportRoot))
+	if stat, err := os.Stat(root); err != nil || !stat.IsDir() {
+		os.RemoveAll(tmpdir)
+
+		return nil, fmt.Errorf("import root %q does not exist or is not a directory", src.ImportRoot)
+	}
+
+	modules := []bufModule{{dir: root}}
+
+	layout, err := readBufLayout(root)
+	if err != nil {
+		os.RemoveAll(tmpdir)
+
+		return nil, fmt.Errorf("invalid buf configuration: %w", err)
+	}
+
+	src.bufModules = nil
+
+	if layout != nil {
+		entry.Info("using buf module layout", "modules", len(layout.modules), "names", layout.names)
+
+		modules = layout.modules
+		src.bufModules = layout.names
+	}
+
+	// tmpdir is empty for git sources whose working copy must be kept
+	result := &fetchedSource{
+		dir:      tmpdir,
+		paths:    make(map[string]struct{}),
+		revision: revision,
+		fetched:  time.Now(),
+	}
+
+	if layout != nil {
+		result.bufDeps = layout.deps
+	}
+
+	seen := make(map[string]struct{})
+
+	addImports := func(imports []string) {
+		for _, imp := range imports {
+			if _, ok := seen[imp]; !ok {
+				seen[imp] = struct{}{}
+				result.imports = append(result.imports, imp)
+			}
+		}
+	}
+
+	// find all proto files and descriptor sets below the module roots
+	for _, mod := range modules {
+		result.roots = append(result.roots, mod.dir)
+
+		fs.WalkDir(os.DirFS(mod.dir), ".", func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return nil
+			}
+
+			if mod.excluded(path, d.IsDir()) {
+				if d.IsDir() {
+					return fs.SkipDir
+				}
+
+				return nil
+			}
+
+			if d.IsDir() {
+				return nil
+			}
+
+			if isDescriptorSet(path) {
+				if !src.Matches(path) {
+					return nil
+				}
+
+				// .pb files are not necessarily descriptor sets so files
+				// that cannot be read are skipped instead of failing the
+				// source.
+				if err := result.addDescriptorSet(src.Source, base, filepath.Join(mod.dir, path)); err != nil {
+					entry.Warn("skipping invalid descriptor set", "path", path, "error", err)
+				}
+
+				return nil
+			}
+
+			if filepath.Ext(path) != ".proto" || !src.Matches(path) || result.contains(path) {
+				return nil
+			}
+
+			result.files = append(result.files, path)
+			result.paths[path] = struct{}{}
+			result.origin(path, base, filepath.Join(mod.dir, path))
+
+			addImports(parseImports(filepath.Join(mod.dir, path)))
+
+			return nil
+		})
+	}
+
+	for _, fd := range result.protos {
+		addImports(fd.GetDependency())
+	}
+
+	return result, nil
+}
+
+// descriptorSetExtensions holds the file extensions of serialized
+// FileDescriptorSets, like the output of protoc --descriptor_set_out or buf
+// build.
+var descriptorSetExtensions = []string{".binpb", ".protoset", ".pb"}
+
+func isDescriptorSet(path string) bool {
+	return slices.Contains(descriptorSetExtensions, filepath.Ext(path))
+}
+
+// addDescriptorSet adds all files of the serialized FileDescriptorSet at
+// path, within the downloaded source at base, that are selected by the
+// include and exclude patterns of src. Files of the standard imports are
+// skipped and files that have already been added by another descriptor set
+// are ignored. Buf images are supported as well since they are wire
+// compatible with FileDescriptorSets.
+func (f *fetchedSource) addDescriptorSet(src config.Source, base string, path string) error {
+	blob, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	var set descriptorpb.FileDescriptorSet
+	if err := proto.Unmarshal(blob, &set); err != nil {
+		return err
+	}
+
+	if f.protos == nil {
+		f.protos = make(map[string]*descriptorpb.FileDescriptorProto)
+	}
+
+	for _, fd := range set.File {
+		name := fd.GetName()
+
+		if isStandardImport(name) || !src.Matches(name) {
+			continue
+		}
+
+		if _, ok := f.paths[name]; ok {
+			continue
+		}
+
+		f.protos[name] = fd
+		f.files = append(f.files, name)
+		f.paths[name] = struct{}{}
+		f.origin(name, base, path)
+	}
+
+	return nil
+}
+
+// isStandardImport reports whether the file at path is one of the standard
+// imports, like google/protobuf/descriptor.proto, which are always provided
+// by the compiler.
+func isStandardImport(path string) bool {
+	return strings.HasPrefix(path, "google/protobuf/")
+}
+
+// newProtoSource returns a fetchedSource that links the given file descriptor
+// protos instead of compiling source files.
+func newProtoSource(files []*descriptorpb.FileDescriptorProto) *fetchedSource {
+	result := &fetchedSource{
+		paths:  make(map[string]struct{}),
+		protos: make(map[string]*descriptorpb.FileDescriptorProto),
+	}
+
+	seen := make(map[string]struct{})
+
+	for _, fd := range files {
+		result.files = append(result.files, fd.GetName())
+		result.paths[fd.GetName()] = struct{}{}
+		result.protos[fd.GetName()] = fd
+
+		for _, imp := range fd.GetDependency() {
+			if _, ok := seen[imp]; !ok {
+				seen[imp] = struct{}{}
+				result.imports = append(result.imports, imp)
+			}
+		}
+	}
+
+	return result
+}
+
+// filesToProtos converts all files to file descriptor protos.
+func filesToProtos(files linker.Files) []*descriptorpb.FileDescriptorProto {
+	result := make([]*descriptorpb.FileDescriptorProto, len(files))
+
+	for idx, file := range files {
+		result[idx] = protodesc.ToFileDescriptorProto(file)
+	}
+
+	return result
+}
+
+// hashFiles returns a hex encoded SHA-256 hash over the deterministically
+// serialized descriptors of all files, ordered by path.
+func hashFiles(files linker.Files) string {
+	sorted := slices.Clone(files)
+
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Path() < sorted[j].Path()
+	})
+
+	hash := sha256.New()
+	for _, file := range sorted {
+		hash.Write(marshalFile(file))
+	}
+
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// hashFile returns a hex encoded SHA-256 hash over the deterministically
+// serialized descriptor of file.
+func hashFile(file protoreflect.FileDescriptor) string {
+	sum := sha256.Sum256(marshalFile(file))
+
+	return hex.EncodeToString(sum[:])
+}
+
+// marshalFile deterministically serializes the descriptor proto of file.
+func marshalFile(file protoreflect.FileDescriptor) []byte {
+	// marshaling a linked file descriptor proto does not fail
+	blob, _ := proto.MarshalOptions{Deterministic: true}.Marshal(protodesc.ToFileDescriptorProto(file))
+
+	return blob
+}
+
+// contentHash returns a hex encoded SHA-256 hash over the paths and the
+// content of all files of the source. It returns an empty string if a file
+// cannot be read.
+func (f *fetchedSource) contentHash() string {
+	files := slices.Clone(f.files)
+	sort.Strings(files)
+
+	hash := sha256.New()
+	for _, path := range files {
+		hash.Write([]byte(path))
+		hash.Write([]byte{0})
+
+		content, err := f.content(path)
+		if err != nil {
+			return ""
+		}
+
+		fmt.Fprintf(hash, "%d:", len(content))
+		hash.Write(content)
+	}
+
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// content returns the content of the file at path the same way compile
+// resolves it.
+func (f *fetchedSource) content(path string) ([]byte, error) {
+	for _, root := range f.roots {
+		blob, err := os.ReadFile(filepath.Join(root, filepath.FromSlash(path)))
+		if err == nil {
+			return blob, nil
+		}
+
+		if !errors.Is(err, os.ErrNotExist) {
+			return nil, err
+		}
+	}
+
+	if content, ok := f.sources[path]; ok {
+		return []byte(content), nil
+	}
+
+	if fd, ok := f.protos[path]; ok {
+		return proto.MarshalOptions{Deterministic: true}.Marshal(fd)
+	}
+
+	return nil, fmt.Errorf("%s: %w", path, os.ErrNotExist)
+}
+
+// parseImports returns the import paths of the protobuf file at path.
+// Syntax errors are ignored here since they will be reported once the
+// file gets compiled.
+func parseImports(path string) []string {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil
+	}
+	defer f.Close()
+
+	node, _ := parser.Parse(path, f, reporter.NewHandler(nil))
+	if node == nil {
+		return nil
+	}
+
+	var result []string
+	for _, decl := range node.Decls {
+		if imp, ok := decl.(*ast.ImportNode); ok {
+			result = append(result, imp.Name.AsString())
+		}
+	}
+
+	return result
+}
+
+// compile compiles a fetched source. Imports that are not part of the
+// source itself are resolved using deps.
+func (f *fetchedSource) compile(ctx context.Context, deps []linker.Files) (linker.Files, error) {
+	// source files take precedence over precompiled files with the same
+	// path.
+	var own protocompile.CompositeResolver
+
+	if len(f.roots) > 0 {
+		own = append(own, &protocompile.SourceResolver{
+			ImportPaths: f.roots,
+		})
+	}
+
+	if f.sources != nil {
+		own = append(own, &protocompile.SourceResolver{
+			Accessor: protocompile.SourceAccessorFromMap(f.sources),
+		})
+	}
+
+	if f.protos != nil {
+		// precompiled files are only linked and validated.
+		own = append(own, protocompile.ResolverFunc(func(path string) (protocompile.SearchResult, error) {
+			if fd, ok := f.protos[path]; ok {
+				return protocompile.SearchResult{Proto: fd}, nil
+			}
+
+			return protocompile.SearchResult{}, protoregistry.NotFound
+		}))
+	}
+
+	compiler := protocompile.Compiler{
+		Resolver: protocompile.WithStandardImports(protocompile.CompositeResolver{
+			own,
+			protocompile.ResolverFunc(func(path string) (protocompile.SearchResult, error) {
+				for _, files := range deps {
+					if file := files.FindFileByPath(path); file != nil {
+						return protocompile.SearchResult{Desc: file}, nil
+					}
+				}
+
+				return protocompile.SearchResult{}, protoregistry.NotFound
+			}),
+		}),
+	}
+
+	return compiler.Compile(ctx, f.files...)
+}
+
+// sortSources returns the indexes of all sources in the order they must be
+// compiled. deps[i] holds the indexes of all sources that source i depends on.
+// Sources that are part of an import cycle are returned in cyclic.
+func sortSources(deps [][]int) (order []int, cyclic []int) {
+	indegree := make([]int, len(deps))
+	dependents := make([][]int, len(deps))
+
+	for idx, list := range deps {
+		indegree[idx] = len(list)
+
+		for _, dep := range list {
+			dependents[dep] = append(dependents[dep], idx)
+		}
+	}
+
+	var queue []int
+	for idx, count := range indegree {
+		if count == 0 {
+			queue = append(queue, idx)
+		}
+	}
+
+	for len(queue) > 0 {
+		next := queue[0]
+		queue = queue[1:]
+
+		order = append(order, next)
+
+		for _, dependent := range dependents[next] {
+			indegree[dependent]--
+
+			if indegree[dependent] == 0 {
+				queue = append(queue, dependent)
+			}
+		}
+
+		sort.Ints(queue)
+	}
+
+	for idx, count := range indegree {
+		if count > 0 {
+			cyclic = append(cyclic, idx)
+		}
+	}
+
+	return order, cyclic
+}
@@ -0,0 +1,237 @@
+package registry
+
+import (
+	"context"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/bufbuild/protocompile"
+	"github.com/bufbuild/protocompile/linker"
+	"github.com/tierklinik-dobersberg/pbtype-server/internal/config"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+func TestSortSources(t *testing.T) {
+	cases := []struct {
+		name   string
+		deps   [][]int
+		order  []int
+		cyclic []int
+	}{
+		{
+			name:  "independent",
+			deps:  [][]int{nil, nil, nil},
+			order: []int{0, 1, 2},
+		},
+		{
+			name:  "chain",
+			deps:  [][]int{{1}, {2}, nil},
+			order: []int{2, 1, 0},
+		},
+		{
+			name:  "diamond",
+			deps:  [][]int{{1, 2}, {3}, {3}, nil},
+			order: []int{3, 1, 2, 0},
+		},
+		{
+			name:   "cycle",
+			deps:   [][]int{{1}, {0}, nil, {0}},
+			order:  []int{2},
+			cyclic: []int{0, 1, 3},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			order, cyclic := sortSources(c.deps)
+
+			if !slices.Equal(order, c.order) {
+				t.Errorf("order: got %v, want %v", order, c.order)
+			}
+
+			if !slices.Equal(cyclic, c.cyclic) {
+				t.Errorf("cyclic: got %v, want %v", cyclic, c.cyclic)
+			}
+		})
+	}
+}
+
+func TestSourceDependencies(t *testing.T) {
+	fetched := func(files []string, imports ...string) *fetchedSource {
+		paths := make(map[string]struct{}, len(files))
+		for _, file := range files {
+			paths[file] = struct{}{}
+		}
+
+		return &fetchedSource{files: files, paths: paths, imports: imports}
+	}
+
+	current := compileFiles(t, map[string]string{
+		"cached/v1/cached.proto": `syntax = "proto3"; package cached.v1; message Cached {}`,
+	})
+
+	cases := []struct {
+		name       string
+		fetched    []*fetchedSource
+		current    []linker.Files
+		precedence []int
+		want       [][]int
+	}{
+		{
+			name: "imports",
+			fetched: []*fetchedSource{
+				fetched([]string{"a.proto"}, "b.proto", "c.proto"),
+				fetched([]string{"b.proto"}, "c.proto"),
+				fetched([]string{"c.proto"}),
+			},
+			precedence: []int{0, 1, 2},
+			want:       [][]int{{1, 2}, {2}, nil},
+		},
+		{
+			name: "own files",
+			fetched: []*fetchedSource{
+				fetched([]string{"a.proto", "b.proto"}, "b.proto"),
+			},
+			precedence: []int{0},
+			want:       [][]int{nil},
+		},
+		{
+			name: "precedence",
+			fetched: []*fetchedSource{
+				fetched([]string{"a.proto"}, "shared.proto"),
+				fetched([]string{"shared.proto"}),
+				fetched([]string{"shared.proto"}),
+			},
+			precedence: []int{0, 2, 1},
+			want:       [][]int{{2}, nil, nil},
+		},
+		{
+			name: "not fetched",
+			fetched: []*fetchedSource{
+				fetched([]string{"a.proto"}, "cached/v1/cached.proto", "missing.proto"),
+				nil,
+			},
+			current:    []linker.Files{nil, current},
+			precedence: []int{0, 1},
+			want:       [][]int{{1}, nil},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got := sourceDependencies(c.fetched, c.current, c.precedence)
+
+			if !slices.EqualFunc(got, c.want, slices.Equal) {
+				t.Errorf("got %v, want %v", got, c.want)
+			}
+		})
+	}
+}
+
+func TestFetchDescriptorSets(t *testing.T) {
+	set, err := proto.Marshal(&descriptorpb.FileDescriptorSet{
+		File: filesToProtos(compileFiles(t, map[string]string{
+			"acme/v1/a.proto":  `syntax = "proto3"; package acme.v1; message A {}`,
+			"other/v1/o.proto": `syntax = "proto3"; package other.v1; message O {}`,
+		})),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir := t.TempDir()
+
+	writeFiles(t, dir, map[string]string{
+		"image.binpb":        string(set),
+		"vendor/other.binpb": string(set),
+		"corrupt.pb":         "not a descriptor set",
+		"local/v1/l.proto":   `syntax = "proto3"; package local.v1; message L {}`,
+	})
+
+	cases := []struct {
+		name string
+		src  config.Source
+		want []string
+	}{
+		{
+			name: "all",
+			src:  config.Source{URL: dir},
+			want: []string{"acme/v1/a.proto", "local/v1/l.proto", "other/v1/o.proto"},
+		},
+		{
+			name: "single file",
+			src:  config.Source{URL: filepath.Join(dir, "image.binpb")},
+			want: []string{"acme/v1/a.proto", "other/v1/o.proto"},
+		},
+		{
+			name: "include",
+			src:  config.Source{URL: dir, Include: []string{"image.binpb", "acme/**"}},
+			want: []string{"acme/v1/a.proto"},
+		},
+		{
+			name: "exclude",
+			src:  config.Source{URL: dir, Exclude: []string{"**/*.binpb"}},
+			want: []string{"local/v1/l.proto"},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			src := &source{Source: c.src}
+
+			// the corrupt set is skipped instead of failing the source
+			result, err := src.fetch(context.Background(), 0)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			defer os.RemoveAll(result.dir)
+
+			if got := slices.Sorted(maps.Keys(result.paths)); !slices.Equal(got, c.want) {
+				t.Errorf("got files %v, want %v", got, c.want)
+			}
+		})
+	}
+}
+
+// compileFiles compiles the given files, by path, and returns them in the
+// order of their paths.
+func compileFiles(t *testing.T, files map[string]string) linker.Files {
+	t.Helper()
+
+	paths := slices.Sorted(maps.Keys(files))
+
+	compiler := protocompile.Compiler{
+		Resolver: protocompile.WithStandardImports(&protocompile.SourceResolver{
+			Accessor: protocompile.SourceAccessorFromMap(files),
+		}),
+	}
+
+	result, err := compiler.Compile(context.Background(), paths...)
+	if err != nil {
+		t.Fatalf("failed to compile %v: %s", paths, err)
+	}
+
+	return result
+}
+
+// writeFiles creates the given files, by path relative to dir.
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+
+	for name, content := range files {
+		file := filepath.Join(dir, filepath.FromSlash(name))
+
+		if err := os.MkdirAll(filepath.Dir(file), 0o755); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := os.WriteFile(file, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,237 @@
+package registry
+
+import (
+	"path"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// maxSuggestQuery is the maximum length of names that suggestions are
+// searched for. Longer names are not compared to avoid costly edit
+// distance computations.
+const maxSuggestQuery = 256
+
+// SuggestSymbols returns up to limit symbols of the served files with a
+// name similar to name, see Snapshot.SuggestSymbols.
+func (reg *Registry) SuggestSymbols(name protoreflect.FullName, limit int) []Symbol {
+	return suggestSymbols(reg.getIndex(), name, limit)
+}
+
+// SuggestFiles returns up to limit paths of served files similar to
+// filename, see Snapshot.SuggestFiles.
+func (reg *Registry) SuggestFiles(filename string, limit int) []string {
+	return suggestFiles(reg.getIndex(), filename, limit)
+}
+
+// PackageOf returns the package that name most likely belongs to. If name
+// starts with a known package, the longest one is returned. Otherwise the
+// parent of name is returned.
+func (reg *Registry) PackageOf(name protoreflect.FullName) protoreflect.FullName {
+	return packageOf(reg.getIndex(), name)
+}
+
+// SuggestSymbols returns up to limit symbols with a name similar to name,
+// ordered by edit distance. Symbols of the same package are preferred if
+// the distance is equal. Nothing is suggested for names longer than
+// maxSuggestQuery.
+func (snap *Snapshot) SuggestSymbols(name protoreflect.FullName, limit int) []Symbol {
+	return suggestSymbols(snap.getIndex(), name, limit)
+}
+
+// SuggestFiles returns up to limit paths of files of the snapshot similar
+// to filename, ordered by edit distance. Nothing is suggested for paths
+// longer than maxSuggestQuery.
+func (snap *Snapshot) SuggestFiles(filename string, limit int) []string {
+	return suggestFiles(snap.getIndex(), filename, limit)
+}
+
+// PackageOf is like Registry.PackageOf but uses the packages of the
+// snapshot.
+func (snap *Snapshot) PackageOf(name protoreflect.FullName) protoreflect.FullName {
+	return packageOf(snap.getIndex(), name)
+}
+
+func suggestSymbols(idx *index, name protoreflect.FullName, limit int) []Symbol {
+	if len(name) > maxSuggestQuery {
+		return nil
+	}
+
+	var (
+		pkg     = packageOf(idx, name)
+		query   = strings.ToLower(string(name))
+		simple  = strings.ToLower(string(name.Name()))
+		maxDist = maxDistance(simple)
+		result  []scored[Symbol]
+	)
+
+	for _, sym := range idx.symbols {
+		dist := distanceWithin(query, strings.ToLower(string(sym.Name)), maxDist)
+
+		// also match the simple name so symbols that have been requested
+		// with the wrong package are found as well.
+		if d := distanceWithin(simple, strings.ToLower(string(sym.Name.Name())), maxDist) + 1; d < dist {
+			dist = d
+		}
+
+		if dist > maxDist {
+			continue
+		}
+
+		// lower distances score higher, same-package symbols win ties.
+		score := -dist * 2
+		if sym.Package == pkg {
+			score++
+		}
+
+		result = append(result, scored[Symbol]{sym, score})
+	}
+
+	return limitResults(sortScored(result), limit)
+}
+
+func suggestFiles(idx *index, filename string, limit int) []string {
+	if len(filename) > maxSuggestQuery {
+		return nil
+	}
+
+	var (
+		query   = strings.ToLower(filename)
+		maxDist = maxDistance(path.Base(query))
+		result  []scored[string]
+	)
+
+	for _, file := range idx.files {
+		dist := distanceWithin(query, strings.ToLower(file), maxDist)
+		if dist > maxDist {
+			continue
+		}
+
+		result = append(result, scored[string]{file, -dist})
+	}
+
+	return limitResults(sortScored(result), limit)
+}
+
+func packageOf(idx *index, name protoreflect.FullName) protoreflect.FullName {
+	for pkg := name.Parent(); pkg != ""; pkg = pkg.Parent() {
+		i := sort.Search(len(idx.packages), func(i int) bool {
+			return idx.packages[i] >= pkg
+		})
+
+		if i < len(idx.packages) && idx.packages[i] == pkg {
+			return pkg
+		}
+	}
+
+	return name.Parent()
+}
+
+// SourcesOfPackage returns the names of all sources that contain files of
+// pkg. If no source contains pkg, the sources that contain the packages
+// sharing the longest common prefix with pkg are returned instead.
+func (reg *Registry) SourcesOfPackage(pkg protoreflect.FullName) []string {
+	reg.l.RLock()
+	defer reg.l.RUnlock()
+
+	var (
+		result []string
+		best   int
+	)
+
+	for _, src := range reg.sources {
+		var common int
+
+		for _, file := range src.files {
+			if n := commonSegments(pkg, file.Package()); n > common {
+				common = n
+			}
+		}
+
+		switch {
+		case common == 0 || common < best:
+			continue
+		case common > best:
+			best = common
+			result = nil
+		}
+
+		result = append(result, src.Name)
+	}
+
+	return result
+}
+
+// commonSegments returns the number of leading name segments a and b have
+// in common.
+func commonSegments(a, b protoreflect.FullName) int {
+	var (
+		as    = strings.Split(string(a), ".")
+		bs    = strings.Split(string(b), ".")
+		count int
+	)
+
+	for count < len(as) && count < len(bs) && as[count] == bs[count] && as[count] != "" {
+		count++
+	}
+
+	return count
+}
+
+// maxDistance returns the maximum edit distance for a suggestion to still
+// be considered similar to name.
+func maxDistance(name string) int {
+	return max(2, len(name)/3)
+}
+
+func limitResults[T any](values []T, limit int) []T {
+	if limit > 0 && len(values) > limit {
+		return values[:limit]
+	}
+
+	return values
+}
+
+// distanceWithin returns the edit distance between a and b. If their
+// lengths differ by more than maxDist, the distance exceeds maxDist anyway
+// and maxDist+1 is returned without comparing them.
+func distanceWithin(a, b string, maxDist int) int {
+	if diff := utf8.RuneCountInString(a) - utf8.RuneCountInString(b); diff > maxDist || -diff > maxDist {
+		return maxDist + 1
+	}
+
+	return editDistance(a, b)
+}
+
+// editDistance returns the Levenshtein distance between a and b.
+func editDistance(a, b string) int {
+	var (
+		ar   = []rune(a)
+		br   = []rune(b)
+		prev = make([]int, len(br)+1)
+		cur  = make([]int, len(br)+1)
+	)
+
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ar); i++ {
+		cur[0] = i
+
+		for j := 1; j <= len(br); j++ {
+			cost := 1
+			if ar[i-1] == br[j-1] {
+				cost = 0
+			}
+
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+
+		prev, cur = cur, prev
+	}
+
+	return prev[len(br)]
+}
@@ -0,0 +1,94 @@
+package registry
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/tierklinik-dobersberg/pbtype-server/internal/config"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+func TestSuggestSymbols(t *testing.T) {
+	var (
+		reg = New(config.Config{})
+		old = activate(t, reg, compileFiles(t, map[string]string{
+			"acme/v1/a.proto": `syntax = "proto3"; package acme.v1; message Alpha {} message Beta {}`,
+		}))
+		current = compileFiles(t, map[string]string{
+			"acme/v1/a.proto":  `syntax = "proto3"; package acme.v1; message Beta {}`,
+			"other/v1/o.proto": `syntax = "proto3"; package other.v1; message Alpha {}`,
+		})
+	)
+
+	reg.index = buildIndex(current)
+
+	names := func(symbols []Symbol) []protoreflect.FullName {
+		var result []protoreflect.FullName
+		for _, sym := range symbols {
+			result = append(result, sym.Name)
+		}
+
+		return result
+	}
+
+	// symbols requested with the wrong package are found by their name
+	if got := names(reg.SuggestSymbols("acme.v1.Alpha", 5)); len(got) != 1 || got[0] != "other.v1.Alpha" {
+		t.Errorf("unexpected suggestions %v", got)
+	}
+
+	// pinned snapshots suggest their own symbols
+	if got := names(old.SuggestSymbols("acme.v1.Alpah", 5)); len(got) != 1 || got[0] != "acme.v1.Alpha" {
+		t.Errorf("unexpected suggestions of the pinned snapshot %v", got)
+	}
+
+	if got := old.PackageOf("acme.v1.Alpah"); got != "acme.v1" {
+		t.Errorf("unexpected package %s", got)
+	}
+
+	long := protoreflect.FullName("acme.v1." + strings.Repeat("A", maxSuggestQuery))
+	if got := reg.SuggestSymbols(long, 5); got != nil {
+		t.Errorf("expected no suggestions for overly long names, got %v", got)
+	}
+}
+
+func TestSuggestFiles(t *testing.T) {
+	reg := New(config.Config{})
+	snap := activate(t, reg, compileFiles(t, map[string]string{
+		"acme/v1/a.proto": `syntax = "proto3"; package acme.v1;`,
+		"acme/v1/b.proto": `syntax = "proto3"; package acme.v1;`,
+	}))
+
+	if got := snap.SuggestFiles("acme/v1/c.proto", 1); len(got) != 1 || got[0] != "acme/v1/a.proto" {
+		t.Errorf("unexpected suggestions %v", got)
+	}
+
+	if got := snap.SuggestFiles(strings.Repeat("a", maxSuggestQuery+1), 5); got != nil {
+		t.Errorf("expected no suggestions for overly long paths, got %v", got)
+	}
+
+	// the registry has no files yet
+	if got := reg.SuggestFiles("acme/v1/c.proto", 5); len(got) != 0 {
+		t.Errorf("unexpected suggestions %v", got)
+	}
+}
+
+func TestDistanceWithin(t *testing.T) {
+	cases := []struct {
+		a, b    string
+		maxDist int
+		want    int
+	}{
+		{a: "alpha", b: "alpah", maxDist: 2, want: 2},
+		{a: "alpha", b: "alphabet", maxDist: 3, want: 3},
+
+		// lengths differ too much to be compared at all
+		{a: "alpha", b: "alphabetical", maxDist: 2, want: 3},
+		{a: "a", b: "äöüß", maxDist: 2, want: 3},
+	}
+
+	for _, c := range cases {
+		if got := distanceWithin(c.a, c.b, c.maxDist); got != c.want {
+			t.Errorf("distanceWithin(%q, %q, %d) = %d, want %d", c.a, c.b, c.maxDist, got, c.want)
+		}
+	}
+}
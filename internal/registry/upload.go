@@ -0,0 +1,434 @@
+package registry
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/bufbuild/protocompile/linker"
+	"github.com/tierklinik-dobersberg/pbtype-server/internal/config"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+var (
+	ErrUploadsDisabled = errors.New("uploads are disabled")
+	ErrInvalidUpload   = errors.New("invalid upload")
+)
+
+// uploadScheme is used to build the URL of uploaded sources.
+const uploadScheme = "upload://"
+
+// Upload holds the content of an uploaded source. Either Descriptors or
+// Sources must be set.
+type Upload struct {
+	// Name is the name of the source. Uploading a source with the same
+	// name replaces the previous upload.
+	Name string
+
+	// ImportOnly marks the source as only being used to satisfy imports.
+	ImportOnly bool
+
+	// Descriptors holds already compiled files.
+	Descriptors []*descriptorpb.FileDescriptorProto
+
+	// Sources holds the content of .proto files by import path.
+	Sources map[string][]byte
+}
+
+// uploadRequest is sent to the polling loop to add or replace an uploaded
+// source.
+type uploadRequest struct {
+	upload Upload
+	result chan error
+}
+
+// uploadMetadata is stored next to the serialized FileDescriptorSet of each
+// uploaded source.
+type uploadMetadata struct {
+	Name       string    `json:"name"`
+	ImportOnly bool      `json:"importOnly"`
+	Uploaded   time.Time `json:"uploaded"`
+	Files      []string  `json:"files"`
+}
+
+// Upload validates upload by compiling it against the files of all other
+// sources. On success, the compiled files are stored in the upload
+// directory and served as a source named upload.Name until they are
+// replaced by another upload. Uploaded sources are loaded again on startup.
+func (reg *Registry) Upload(ctx context.Context, upload Upload) (SourceInfo, error) {
+	if reg.uploadDir == "" {
+		return SourceInfo{}, ErrUploadsDisabled
+	}
+
+	select {
+	case <-reg.started:
+	default:
+		return SourceInfo{}, ErrPollingNotStarted
+	}
+
+	req := uploadRequest{
+		upload: upload,
+		result: make(chan error, 1),
+	}
+
+	select {
+	case reg.uploads <- req:
+	case <-ctx.Done():
+		return SourceInfo{}, ctx.Err()
+	}
+
+	select {
+	case err := <-req.result:
+		if err != nil {
+			return SourceInfo{}, err
+		}
+	case <-ctx.Done():
+		return SourceInfo{}, ctx.Err()
+	}
+
+	for _, info := range reg.Sources() {
+		if info.Name == upload.Name {
+			return info, nil
+		}
+	}
+
+	return SourceInfo{}, fmt.Errorf("%w: %s", ErrUnknownSource, upload.Name)
+}
+
+// applyUpload validates, stores and activates an uploaded source. The
+// upload is only stored once it compiled without breaking changes or
+// conflicts. If it still fails to activate, the previous upload of the
+// source is restored. It must only be called from the polling loop.
+func (reg *Registry) applyUpload(ctx context.Context, upload Upload) error {
+	if upload.Name == "" {
+		return fmt.Errorf("%w: missing name", ErrInvalidUpload)
+	}
+
+	idx := reg.sourceIndex(upload.Name)
+	if idx >= 0 && reg.sources[idx].upload == "" {
+		return fmt.Errorf("%w: source %q is configured and cannot be replaced", ErrInvalidUpload, upload.Name)
+	}
+
+	fetched, err := fetchUpload(upload)
+	if err != nil {
+		return err
+	}
+
+	if len(fetched.files) == 0 {
+		return fmt.Errorf("%w: no files", ErrInvalidUpload)
+	}
+
+	var (
+		base    = filepath.Join(reg.uploadDir, cacheKey(upload.Name))
+		current = reg.sourceFiles()
+		sources = reg.sources
+		deps    []linker.Files
+	)
+
+	for other, files := range current {
+		if other != idx {
+			deps = append(deps, files)
+		}
+	}
+
+	files, err := fetched.compile(ctx, deps)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidUpload, err)
+	}
+
+	if idx >= 0 && reg.refuseBreaking {
+		if changes := breakingChanges(current[idx], files); hasWireBreaking(changes) {
+			return fmt.Errorf("%w: %s", ErrBreakingChange, changes[0])
+		}
+	}
+
+	// a new source is only added once it has been validated
+	target := idx
+	if target < 0 {
+		sources = append(slices.Clip(sources), reg.uploadedSource(base, upload.Name, upload.ImportOnly))
+		target = len(sources) - 1
+	}
+
+	// uploads never replace files or symbols of other sources, regardless
+	// of the conflict policy.
+	if conflicts := findConflicts(sources, target, files, current); len(conflicts) > 0 {
+		return fmt.Errorf("%w: %s", ErrConflict, conflicts[0])
+	}
+
+	previous, err := readStoredUpload(base)
+	if err != nil {
+		return err
+	}
+
+	if err := storeUpload(base, upload, files); err != nil {
+		return err
+	}
+
+	var saved source
+
+	reg.l.Lock()
+	if idx < 0 {
+		reg.sources = sources
+	} else {
+		saved = *reg.sources[idx]
+	}
+	reg.sources[target].ImportOnly = upload.ImportOnly
+	reg.l.Unlock()
+
+	slog.Info("activating uploaded protobuf source", "source", upload.Name, "files", len(files))
+	reg.updateSources(ctx, []int{target})
+
+	reg.l.Lock()
+	defer reg.l.Unlock()
+
+	src := reg.sources[target]
+	if src.status.LastError == nil {
+		src.nextRefresh = time.Now().Add(time.Duration(src.RefreshInterval))
+
+		return nil
+	}
+
+	err = src.status.LastError
+
+	slog.Error("failed to activate uploaded protobuf source, restoring previous upload", "source", upload.Name, "error", err)
+
+	if restoreErr := previous.restore(base); restoreErr != nil {
+		slog.Error("failed to restore previous upload", "source", upload.Name, "error", restoreErr)
+	}
+
+	// the failed version has not been served, so the sources keep their
+	// files.
+	if idx < 0 {
+		reg.sources = reg.sources[:target]
+	} else {
+		*src = saved
+	}
+
+	reg.status.LastError = reg.sourceErrors()
+
+	return err
+}
+
+// storedUpload holds the raw content of an upload stored in the upload
+// directory.
+type storedUpload struct {
+	descriptors []byte
+	metadata    []byte
+}
+
+// readStoredUpload returns the upload stored at base or nil if there is
+// none.
+func readStoredUpload(base string) (*storedUpload, error) {
+	metadata, err := os.ReadFile(base + ".json")
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to read previous upload: %w", err)
+	}
+
+	descriptors, err := os.ReadFile(base + ".binpb")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read previous upload: %w", err)
+	}
+
+	return &storedUpload{
+		descriptors: descriptors,
+		metadata:    metadata,
+	}, nil
+}
+
+// restore writes the upload back to base. If stored is nil, the upload at
+// base is removed instead.
+func (stored *storedUpload) restore(base string) error {
+	if stored == nil {
+		return errors.Join(
+			os.Remove(base+".json"),
+			os.Remove(base+".binpb"),
+		)
+	}
+
+	if err := writeFileAtomic(base+".binpb", stored.descriptors); err != nil {
+		return err
+	}
+
+	return writeFileAtomic(base+".json", stored.metadata)
+}
+
+// fetchUpload returns a fetchedSource for the content of upload. Like for
+// descriptor sets of other sources, files of the standard imports are
+// skipped.
+func fetchUpload(upload Upload) (*fetchedSource, error) {
+	if len(upload.Sources) == 0 {
+		var descriptors []*descriptorpb.FileDescriptorProto
+		for _, fd := range upload.Descriptors {
+			if !isStandardImport(fd.GetName()) {
+				descriptors = append(descriptors, fd)
+			}
+		}
+
+		return newProtoSource(descriptors), nil
+	}
+
+	result := &fetchedSource{
+		paths:   make(map[string]struct{}),
+		sources: make(map[string]string, len(upload.Sources)),
+	}
+
+	for name, content := range upload.Sources {
+		if name != path.Clean(name) || path.IsAbs(name) || strings.HasPrefix(name, "../") || path.Ext(name) != ".proto" {
+			return nil, fmt.Errorf("%w: invalid file name %q", ErrInvalidUpload, name)
+		}
+
+		if isStandardImport(name) {
+			continue
+		}
+
+		result.files = append(result.files, name)
+		result.paths[name] = struct{}{}
+		result.sources[name] = string(content)
+	}
+
+	return result, nil
+}
+
+// storeUpload writes the compiled files of upload to base.binpb and its
+// metadata to base.json.
+func storeUpload(base string, upload Upload, files linker.Files) error {
+	meta := uploadMetadata{
+		Name:       upload.Name,
+		ImportOnly: upload.ImportOnly,
+		Uploaded:   time.Now(),
+	}
+
+	for _, file := range files {
+		meta.Files = append(meta.Files, file.Path())
+	}
+
+	blob, err := proto.Marshal(&descriptorpb.FileDescriptorSet{
+		File: filesToProtos(files),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal file descriptor set: %w", err)
+	}
+
+	metaBlob, err := json.MarshalIndent(meta, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal upload metadata: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(base), 0o755); err != nil {
+		return fmt.Errorf("failed to create upload directory: %w", err)
+	}
+
+	// loadUploads discovers uploads by their metadata file, so it is
+	// written last to never load the name of an upload without its
+	// descriptors.
+	if err := writeFileAtomic(base+".binpb", blob); err != nil {
+		return err
+	}
+
+	return writeFileAtomic(base+".json", metaBlob)
+}
+
+// readUpload reads the uploaded source stored at base.
+func readUpload(base string) (*fetchedSource, error) {
+	metaBlob, err := os.ReadFile(base + ".json")
+	if err != nil {
+		return nil, err
+	}
+
+	var meta uploadMetadata
+	if err := json.Unmarshal(metaBlob, &meta); err != nil {
+		return nil, fmt.Errorf("failed to parse upload metadata: %w", err)
+	}
+
+	blob, err := os.ReadFile(base + ".binpb")
+	if err != nil {
+		return nil, err
+	}
+
+	var set descriptorpb.FileDescriptorSet
+	if err := proto.Unmarshal(blob, &set); err != nil {
+		return nil, fmt.Errorf("failed to parse uploaded file descriptor set: %w", err)
+	}
+
+	result := newProtoSource(set.File)
+	result.fetched = meta.Uploaded
+
+	return result, nil
+}
+
+// uploadedSource returns a new source for the upload stored at base.
+func (reg *Registry) uploadedSource(base string, name string, importOnly bool) *source {
+	return &source{
+		Source: config.Source{
+			Name:            name,
+			URL:             uploadScheme + name,
+			ImportOnly:      importOnly,
+			RefreshInterval: config.Duration(reg.refreshInterval),
+		},
+		upload: base,
+	}
+}
+
+// loadUploads adds a source for each upload stored in the upload
+// directory. It is a no-op if uploads are disabled.
+func (reg *Registry) loadUploads() {
+	if reg.uploadDir == "" {
+		return
+	}
+
+	entries, err := os.ReadDir(reg.uploadDir)
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			slog.Error("failed to read upload directory", "error", err)
+		}
+
+		return
+	}
+
+	reg.l.Lock()
+	defer reg.l.Unlock()
+
+	for _, entry := range entries {
+		if filepath.Ext(entry.Name()) != ".json" {
+			continue
+		}
+
+		blob, err := os.ReadFile(filepath.Join(reg.uploadDir, entry.Name()))
+		if err != nil {
+			slog.Error("failed to read upload metadata", "file", entry.Name(), "error", err)
+
+			continue
+		}
+
+		var meta uploadMetadata
+		if err := json.Unmarshal(blob, &meta); err != nil {
+			slog.Error("failed to parse upload metadata", "file", entry.Name(), "error", err)
+
+			continue
+		}
+
+		if reg.sourceIndexLocked(meta.Name) >= 0 {
+			slog.Error("ignoring uploaded source that conflicts with a configured source", "source", meta.Name)
+
+			continue
+		}
+
+		base := filepath.Join(reg.uploadDir, strings.TrimSuffix(entry.Name(), ".json"))
+		reg.sources = append(reg.sources, reg.uploadedSource(base, meta.Name, meta.ImportOnly))
+
+		slog.Info("loaded uploaded protobuf source", "source", meta.Name, "uploaded", meta.Uploaded, "files", len(meta.Files))
+	}
+}
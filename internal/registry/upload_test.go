@@ -0,0 +1,232 @@
+package registry
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/tierklinik-dobersberg/pbtype-server/internal/config"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+func TestRefreshAndUpload(t *testing.T) {
+	const (
+		a        = `syntax = "proto3"; package acme.v1; message A { string name = 1; int32 age = 2; }`
+		aRemoved = `syntax = "proto3"; package acme.v1; message A { string name = 1; }`
+		u        = `syntax = "proto3"; package up.v1; import "acme/v1/a.proto"; message U { acme.v1.A a = 1; }`
+	)
+
+	var (
+		protos    = t.TempDir()
+		cacheDir  = t.TempDir()
+		uploadDir = t.TempDir()
+	)
+
+	writeFiles(t, protos, map[string]string{
+		"buf.yaml":                "version: v2\nmodules:\n  - path: proto\n",
+		"proto/acme/v1/a.proto":   a,
+		"examples/buf.yaml":       "version: v2\n",
+		"examples/ex/v1/ex.proto": `syntax = "proto3"; package ex.v1; message Ex {}`,
+	})
+
+	cfg := config.Config{
+		RefreshInterval: config.Duration(time.Hour),
+		CacheDir:        cacheDir,
+		UploadDir:       uploadDir,
+		Sources: []config.Source{
+			{Name: "local", URL: protos},
+		},
+	}
+
+	if err := cfg.Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	start := func(t *testing.T) *Registry {
+		t.Helper()
+
+		ctx, cancel := context.WithCancel(context.Background())
+
+		reg := New(cfg)
+		if err := reg.StartPolling(ctx); err != nil {
+			t.Fatal(err)
+		}
+
+		t.Cleanup(func() {
+			cancel()
+			reg.writes.wait()
+		})
+
+		return reg
+	}
+
+	source := func(reg *Registry, name string) (SourceInfo, bool) {
+		for _, info := range reg.Sources() {
+			if info.Name == name {
+				return info, true
+			}
+		}
+
+		return SourceInfo{}, false
+	}
+
+	ctx := context.Background()
+	reg := start(t)
+
+	refresh := func(t *testing.T, content string) SourceInfo {
+		t.Helper()
+
+		writeFiles(t, protos, map[string]string{"proto/acme/v1/a.proto": content})
+
+		if err := reg.Refresh(ctx, "local"); err != nil {
+			t.Fatal(err)
+		}
+
+		info, _ := source(reg, "local")
+		if info.Status.LastError != nil {
+			t.Fatalf("failed to refresh: %s", info.Status.LastError)
+		}
+
+		return info
+	}
+
+	t.Run("refresh", func(t *testing.T) {
+		info := refresh(t, a)
+
+		// only the module below the buf.yaml of the import root is compiled
+		if info.Files != 1 {
+			t.Errorf("expected 1 file, got %d", info.Files)
+		}
+
+		if _, err := reg.FileContainingSymbol("acme.v1.A"); err != nil {
+			t.Errorf("failed to resolve acme.v1.A: %s", err)
+		}
+	})
+
+	t.Run("breaking changes", func(t *testing.T) {
+		info := refresh(t, aRemoved)
+		if len(info.BreakingChanges) != 1 || info.BreakingChanges[0].Kind != FieldRemoved || info.BreakingChangesAt.IsZero() {
+			t.Errorf("expected a removed field, got %+v", info.BreakingChanges)
+		}
+
+		info = refresh(t, a)
+		if len(info.BreakingChanges) != 0 || !info.BreakingChangesAt.IsZero() {
+			t.Errorf("expected the breaking changes to be cleared, got %+v", info.BreakingChanges)
+		}
+	})
+
+	t.Run("upload", func(t *testing.T) {
+		info, err := reg.Upload(ctx, Upload{
+			Name:    "up",
+			Sources: map[string][]byte{"up/v1/u.proto": []byte(u)},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !info.Uploaded || info.Files != 1 {
+			t.Errorf("unexpected source %+v", info)
+		}
+
+		if _, err := reg.FileContainingSymbol("up.v1.U"); err != nil {
+			t.Errorf("failed to resolve up.v1.U: %s", err)
+		}
+	})
+
+	t.Run("conflicting replacement", func(t *testing.T) {
+		base := filepath.Join(uploadDir, cacheKey("up"))
+
+		before, err := os.ReadFile(base + ".binpb")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = reg.Upload(ctx, Upload{
+			Name: "up",
+			Sources: map[string][]byte{
+				"up/v1/u.proto":   []byte(`syntax = "proto3"; package up.v1; message U {}`),
+				"up/v1/dup.proto": []byte(`syntax = "proto3"; package acme.v1; message A {}`),
+			},
+		})
+		if !errors.Is(err, ErrConflict) {
+			t.Fatalf("expected a conflict, got %v", err)
+		}
+
+		after, err := os.ReadFile(base + ".binpb")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if string(before) != string(after) {
+			t.Errorf("the stored upload has been replaced")
+		}
+
+		if info, _ := source(reg, "up"); info.Files != 1 {
+			t.Errorf("expected the previous upload to be served, got %+v", info)
+		}
+	})
+
+	t.Run("conflicting upload", func(t *testing.T) {
+		_, err := reg.Upload(ctx, Upload{
+			Name:    "other",
+			Sources: map[string][]byte{"acme/v1/a.proto": []byte(a)},
+		})
+		if !errors.Is(err, ErrConflict) {
+			t.Fatalf("expected a conflict, got %v", err)
+		}
+
+		if _, ok := source(reg, "other"); ok {
+			t.Errorf("the refused upload has been added")
+		}
+
+		if _, err := os.Stat(filepath.Join(uploadDir, cacheKey("other")+".json")); !os.IsNotExist(err) {
+			t.Errorf("the refused upload has been stored: %v", err)
+		}
+	})
+
+	t.Run("descriptor set", func(t *testing.T) {
+		files := compileFiles(t, map[string]string{
+			"ts/v1/ts.proto": `syntax = "proto3"; package ts.v1; import "google/protobuf/timestamp.proto"; message T { google.protobuf.Timestamp at = 1; }`,
+		})
+
+		info, err := reg.Upload(ctx, Upload{
+			Name: "ts",
+			Descriptors: []*descriptorpb.FileDescriptorProto{
+				protodesc.ToFileDescriptorProto(files[0].Imports().Get(0).FileDescriptor),
+				protodesc.ToFileDescriptorProto(files[0]),
+			},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// standard imports are not served as part of the upload
+		if info.Files != 1 {
+			t.Errorf("expected 1 file, got %d", info.Files)
+		}
+	})
+
+	t.Run("restart", func(t *testing.T) {
+		reg.writes.wait()
+
+		restarted := start(t)
+
+		if err := restarted.Refresh(ctx); err != nil {
+			t.Fatal(err)
+		}
+
+		for _, name := range []string{"local", "up", "ts"} {
+			if info, ok := source(restarted, name); !ok || info.Files != 1 {
+				t.Errorf("source %s: unexpected state %+v", name, info)
+			}
+		}
+
+		if _, err := restarted.FileContainingSymbol("up.v1.U"); err != nil {
+			t.Errorf("failed to resolve up.v1.U: %s", err)
+		}
+	})
+}
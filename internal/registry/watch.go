@@ -0,0 +1,138 @@
+package registry
+
+import (
+	"log/slog"
+	"sort"
+	"time"
+
+	"github.com/bufbuild/protocompile/linker"
+)
+
+// ChangeType describes how a file changed between two snapshots.
+type ChangeType int
+
+const (
+	FileAdded ChangeType = iota + 1
+	FileRemoved
+	FileModified
+)
+
+// FileChange describes a single file that changed between two snapshots.
+type FileChange struct {
+	Path string
+	Type ChangeType
+
+	// Hash is the hash of the file in the new snapshot and PreviousHash
+	// the hash in the old one. Either is empty if the file has been added
+	// or removed.
+	Hash         string
+	PreviousHash string
+}
+
+// ChangeEvent is sent to subscribers each time a snapshot with changed
+// files is activated.
+type ChangeEvent struct {
+	Time    time.Time
+	Changes []FileChange
+}
+
+// subscriberBuffer is the number of events buffered for each subscriber.
+// Subscribers that fall behind are disconnected.
+const subscriberBuffer = 16
+
+// Subscribe returns a channel that receives an event each time the set of
+// served files changes, together with the hashes of all currently served
+// files. The channel is closed if the subscriber does not keep up with
+// events or once cancel is called.
+func (reg *Registry) Subscribe() (hashes map[string]string, events <-chan ChangeEvent, cancel func()) {
+	reg.l.Lock()
+	defer reg.l.Unlock()
+
+	ch := make(chan ChangeEvent, subscriberBuffer)
+
+	if reg.subscribers == nil {
+		reg.subscribers = make(map[chan ChangeEvent]struct{})
+	}
+	reg.subscribers[ch] = struct{}{}
+
+	hashes = make(map[string]string, len(reg.fileHashes))
+	for path, hash := range reg.fileHashes {
+		hashes[path] = hash
+	}
+
+	cancel = func() {
+		reg.l.Lock()
+		defer reg.l.Unlock()
+
+		reg.unsubscribe(ch)
+	}
+
+	return hashes, ch, cancel
+}
+
+// unsubscribe removes and closes ch. Callers must hold reg.l.
+func (reg *Registry) unsubscribe(ch chan ChangeEvent) {
+	if _, ok := reg.subscribers[ch]; ok {
+		delete(reg.subscribers, ch)
+		close(ch)
+	}
+}
+
+// publishChanges compares files against the previously activated files and
+// notifies all subscribers about changes. Callers must hold reg.l.
+func (reg *Registry) publishChanges(files linker.Files) {
+	hashes := make(map[string]string, len(files))
+	for _, file := range files {
+		hashes[file.Path()] = hashFile(file)
+	}
+
+	changes := diffHashes(reg.fileHashes, hashes)
+	reg.fileHashes = hashes
+
+	if len(changes) == 0 {
+		return
+	}
+
+	event := ChangeEvent{
+		Time:    time.Now(),
+		Changes: changes,
+	}
+
+	for ch := range reg.subscribers {
+		select {
+		case ch <- event:
+		default:
+			slog.Warn("dropping slow change subscriber")
+			reg.unsubscribe(ch)
+		}
+	}
+}
+
+// diffHashes returns all files that have been added, removed or modified
+// between old and new, ordered by path.
+func diffHashes(old, new map[string]string) []FileChange {
+	var changes []FileChange
+
+	for path, hash := range new {
+		prev, ok := old[path]
+
+		switch {
+		case !ok:
+			changes = append(changes, FileChange{Path: path, Type: FileAdded, Hash: hash})
+		case prev != hash:
+			changes = append(changes, FileChange{Path: path, Type: FileModified, Hash: hash, PreviousHash: prev})
+		}
+	}
+
+	for path, prev := range old {
+		if _, ok := new[path]; !ok {
+			changes = append(changes, FileChange{Path: path, Type: FileRemoved, PreviousHash: prev})
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Path < changes[j].Path
+	})
+
+	return changes
+}
@@ -0,0 +1,261 @@
+package service
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/bufbuild/connect-go"
+	pbtypev1 "github.com/tierklinik-dobersberg/pbtype-server/gen/go/tkd/pbtype/v1"
+	"github.com/tierklinik-dobersberg/pbtype-server/gen/go/tkd/pbtype/v1/pbtypev1connect"
+	"github.com/tierklinik-dobersberg/pbtype-server/internal/config"
+	"github.com/tierklinik-dobersberg/pbtype-server/internal/convert"
+	"github.com/tierklinik-dobersberg/pbtype-server/internal/registry"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// errAdminDisabled is returned by all RPCs except UploadSource if no admin
+// token is configured.
+var errAdminDisabled = errors.New("the admin service is disabled")
+
+type AdminServer struct {
+	registry *registry.Registry
+
+	// adminToken is the bearer token required by all RPCs except
+	// UploadSource, which are disabled if it is empty.
+	adminToken string
+
+	// uploadToken is the bearer token required by UploadSource. Uploads
+	// are disabled if empty.
+	uploadToken string
+
+	pbtypev1connect.UnimplementedAdminServiceHandler
+}
+
+func NewAdminServer(registry *registry.Registry, adminToken string, uploadToken string) *AdminServer {
+	return &AdminServer{
+		registry:    registry,
+		adminToken:  adminToken,
+		uploadToken: uploadToken,
+	}
+}
+
+// authorize ensures header carries token as a bearer token.
+func authorize(header http.Header, token string) error {
+	value, ok := strings.CutPrefix(header.Get("Authorization"), "Bearer ")
+	if !ok || subtle.ConstantTimeCompare([]byte(value), []byte(token)) != 1 {
+		return connect.NewError(connect.CodeUnauthenticated, errors.New("invalid or missing token"))
+	}
+
+	return nil
+}
+
+// authorizeAdmin ensures header carries the admin token.
+func (srv *AdminServer) authorizeAdmin(header http.Header) error {
+	if srv.adminToken == "" {
+		return connect.NewError(connect.CodeUnimplemented, errAdminDisabled)
+	}
+
+	return authorize(header, srv.adminToken)
+}
+
+func (srv *AdminServer) ListSources(ctx context.Context, req *connect.Request[pbtypev1.ListSourcesRequest]) (*connect.Response[pbtypev1.ListSourcesResponse], error) {
+	if err := srv.authorizeAdmin(req.Header()); err != nil {
+		return nil, err
+	}
+
+	res := &pbtypev1.ListSourcesResponse{}
+
+	for _, info := range srv.registry.Sources() {
+		res.Sources = append(res.Sources, sourceStatusProto(info))
+	}
+
+	return connect.NewResponse(res), nil
+}
+
+func (srv *AdminServer) RefreshSources(ctx context.Context, req *connect.Request[pbtypev1.RefreshSourcesRequest]) (*connect.Response[pbtypev1.RefreshSourcesResponse], error) {
+	if err := srv.authorizeAdmin(req.Header()); err != nil {
+		return nil, err
+	}
+
+	if err := srv.registry.Refresh(ctx, req.Msg.Names...); err != nil {
+		if errors.Is(err, registry.ErrUnknownSource) {
+			return nil, connect.NewError(connect.CodeNotFound, err)
+		}
+
+		return nil, err
+	}
+
+	refreshed := make(map[string]struct{}, len(req.Msg.Names))
+	for _, name := range req.Msg.Names {
+		refreshed[name] = struct{}{}
+	}
+
+	res := &pbtypev1.RefreshSourcesResponse{}
+	for _, info := range srv.registry.Sources() {
+		if _, ok := refreshed[info.Name]; ok || len(refreshed) == 0 {
+			res.Sources = append(res.Sources, sourceStatusProto(info))
+		}
+	}
+
+	return connect.NewResponse(res), nil
+}
+
+func (srv *AdminServer) ListSnapshots(ctx context.Context, req *connect.Request[pbtypev1.ListSnapshotsRequest]) (*connect.Response[pbtypev1.ListSnapshotsResponse], error) {
+	if err := srv.authorizeAdmin(req.Header()); err != nil {
+		return nil, err
+	}
+
+	snapshots, err := srv.registry.Snapshots()
+	if err != nil {
+		return nil, err
+	}
+
+	res := &pbtypev1.ListSnapshotsResponse{}
+	for _, info := range snapshots {
+		snap := &pbtypev1.SnapshotInfo{
+			Id:       info.ID,
+			Hash:     info.Hash,
+			Created:  timestamppb.New(info.Created),
+			InMemory: info.InMemory,
+		}
+
+		for _, src := range info.Sources {
+			snap.Sources = append(snap.Sources, &pbtypev1.SourceRevision{
+				Name:        src.Name,
+				Url:         src.URL,
+				ContentHash: src.Hash,
+				Revision:    src.Revision,
+			})
+		}
+
+		res.Snapshots = append(res.Snapshots, snap)
+	}
+
+	return connect.NewResponse(res), nil
+}
+
+func (srv *AdminServer) UploadSource(ctx context.Context, req *connect.Request[pbtypev1.UploadSourceRequest]) (*connect.Response[pbtypev1.UploadSourceResponse], error) {
+	if srv.uploadToken == "" {
+		return nil, connect.NewError(connect.CodeUnimplemented, registry.ErrUploadsDisabled)
+	}
+
+	if err := authorize(req.Header(), srv.uploadToken); err != nil {
+		return nil, err
+	}
+
+	upload := registry.Upload{
+		Name:       req.Msg.Name,
+		ImportOnly: req.Msg.ImportOnly,
+	}
+
+	switch v := req.Msg.Content.(type) {
+	case *pbtypev1.UploadSourceRequest_FileDescriptorSet:
+		var set descriptorpb.FileDescriptorSet
+		if err := proto.Unmarshal(v.FileDescriptorSet, &set); err != nil {
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid file descriptor set: %w", err))
+		}
+
+		upload.Descriptors = set.File
+
+	case *pbtypev1.UploadSourceRequest_ProtoBundle:
+		upload.Sources = make(map[string][]byte, len(v.ProtoBundle.Files))
+
+		for _, file := range v.ProtoBundle.Files {
+			if _, ok := upload.Sources[file.Path]; ok {
+				return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("duplicate file %q", file.Path))
+			}
+
+			upload.Sources[file.Path] = []byte(file.Content)
+		}
+
+	default:
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("missing file descriptor set or proto bundle"))
+	}
+
+	info, err := srv.registry.Upload(ctx, upload)
+	switch {
+	case errors.Is(err, registry.ErrInvalidUpload):
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	case errors.Is(err, registry.ErrBreakingChange), errors.Is(err, registry.ErrConflict):
+		return nil, connect.NewError(connect.CodeFailedPrecondition, err)
+	case errors.Is(err, registry.ErrUploadsDisabled):
+		return nil, connect.NewError(connect.CodeUnimplemented, err)
+	case err != nil:
+		return nil, err
+	}
+
+	return connect.NewResponse(&pbtypev1.UploadSourceResponse{
+		Source: sourceStatusProto(info),
+	}), nil
+}
+
+func (srv *AdminServer) ListConflicts(ctx context.Context, req *connect.Request[pbtypev1.ListConflictsRequest]) (*connect.Response[pbtypev1.ListConflictsResponse], error) {
+	if err := srv.authorizeAdmin(req.Header()); err != nil {
+		return nil, err
+	}
+
+	res := &pbtypev1.ListConflictsResponse{
+		Policy: srv.registry.ConflictPolicy(),
+	}
+
+	for _, c := range srv.registry.Conflicts() {
+		res.Conflicts = append(res.Conflicts, &pbtypev1.Conflict{
+			Kind:        convert.ConflictKind(c.Kind),
+			Name:        c.Name,
+			Source:      c.Source,
+			File:        c.File,
+			OtherSource: c.OtherSource,
+			OtherFile:   c.OtherFile,
+			Refused:     c.Refused,
+		})
+	}
+
+	return connect.NewResponse(res), nil
+}
+
+func sourceStatusProto(info registry.SourceInfo) *pbtypev1.SourceStatus {
+	res := &pbtypev1.SourceStatus{
+		Name:        info.Name,
+		Url:         config.RedactURL(info.URL),
+		ImportOnly:  info.ImportOnly,
+		FileCount:   int32(info.Files),
+		ContentHash: info.Hash,
+		Uploaded:    info.Uploaded,
+		Revision:    info.Revision,
+	}
+
+	if !info.Status.LastRefresh.IsZero() {
+		res.LastFetch = timestamppb.New(info.Status.LastRefresh)
+	}
+
+	if !info.Status.LastSuccess.IsZero() {
+		res.LastSuccess = timestamppb.New(info.Status.LastSuccess)
+	}
+
+	if info.Status.LastError != nil {
+		// download errors may quote the URL
+		res.LastError = strings.ReplaceAll(info.Status.LastError.Error(), info.URL, res.Url)
+	}
+
+	if !info.BreakingChangesAt.IsZero() {
+		res.BreakingChangesDetected = timestamppb.New(info.BreakingChangesAt)
+	}
+
+	for _, change := range info.BreakingChanges {
+		res.BreakingChanges = append(res.BreakingChanges, &pbtypev1.BreakingChange{
+			Kind:         convert.BreakingChangeKind(change.Kind),
+			Element:      string(change.Element),
+			FileName:     change.File,
+			WireBreaking: change.Wire,
+			Description:  change.Description,
+		})
+	}
+
+	return res
+}
@@ -0,0 +1,201 @@
+package service
+
+import (
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	pbtypev1 "github.com/tierklinik-dobersberg/pbtype-server/gen/go/tkd/pbtype/v1"
+	"github.com/tierklinik-dobersberg/pbtype-server/internal/convert"
+	"github.com/tierklinik-dobersberg/pbtype-server/internal/registry"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// ChangelogFeed serves the changelog of the registry as an Atom feed and as
+// plain JSON. Both can be limited to packages using the "package" query
+// parameter.
+type ChangelogFeed struct {
+	registry *registry.Registry
+}
+
+func NewChangelogFeed(registry *registry.Registry) *ChangelogFeed {
+	return &ChangelogFeed{
+		registry: registry,
+	}
+}
+
+type atomFeed struct {
+	XMLName xml.Name    `xml:"http://www.w3.org/2005/Atom feed"`
+	ID      string      `xml:"id"`
+	Title   string      `xml:"title"`
+	Updated string      `xml:"updated"`
+	Author  atomAuthor  `xml:"author"`
+	Links   []atomLink  `xml:"link"`
+	Entries []atomEntry `xml:"entry"`
+}
+
+type atomAuthor struct {
+	Name string `xml:"name"`
+}
+
+type atomLink struct {
+	Rel  string `xml:"rel,attr,omitempty"`
+	Type string `xml:"type,attr,omitempty"`
+	Href string `xml:"href,attr"`
+}
+
+type atomEntry struct {
+	ID      string      `xml:"id"`
+	Title   string      `xml:"title"`
+	Updated string      `xml:"updated"`
+	Content atomContent `xml:"content"`
+}
+
+type atomContent struct {
+	Type string `xml:"type,attr"`
+	Body string `xml:",chardata"`
+}
+
+// Atom serves the changelog as an Atom feed.
+func (feed *ChangelogFeed) Atom(w http.ResponseWriter, r *http.Request) {
+	var (
+		entries = filterChangelog(feed.registry.Changelog(), r.URL.Query()["package"])
+		self    = requestURL(r)
+	)
+
+	res := atomFeed{
+		ID:     changelogFeedID(r.URL.Query()["package"]),
+		Title:  "pbtype-server changelog",
+		Author: atomAuthor{Name: "pbtype-server"},
+		Links: []atomLink{
+			{Rel: "self", Type: "application/atom+xml", Href: self},
+		},
+	}
+
+	// an empty feed is considered to be updated when the server started
+	updated := feed.registry.Status().LastSuccess
+	if len(entries) > 0 {
+		updated = entries[0].Time
+	}
+	res.Updated = updated.UTC().Format(time.RFC3339)
+
+	for _, entry := range entries {
+		res.Entries = append(res.Entries, atomEntry{
+			ID:      changelogEntryID(entry),
+			Title:   entry.Title(),
+			Updated: entry.Time.UTC().Format(time.RFC3339),
+			Content: atomContent{
+				Type: "text",
+				Body: changelogText(entry),
+			},
+		})
+	}
+
+	blob, err := xml.MarshalIndent(res, "", "  ")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/atom+xml; charset=utf-8")
+	w.Write([]byte(xml.Header))
+	w.Write(blob)
+}
+
+// JSON serves the changelog using the JSON encoding of a
+// tkd.pbtype.v1.GetChangelogResponse.
+func (feed *ChangelogFeed) JSON(w http.ResponseWriter, r *http.Request) {
+	res := &pbtypev1.GetChangelogResponse{}
+
+	for _, entry := range filterChangelog(feed.registry.Changelog(), r.URL.Query()["package"]) {
+		res.Entries = append(res.Entries, convert.ChangelogEntry(entry))
+	}
+
+	blob, err := protojson.Marshal(res)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(blob)
+}
+
+// changelogFeedID returns the ID of the Atom feed limited to packages. Like
+// entry IDs, it does not depend on the URL the feed is requested from.
+func changelogFeedID(packages []string) string {
+	if len(packages) == 0 {
+		return "urn:pbtype:changelog"
+	}
+
+	packages = slices.Clone(packages)
+	slices.Sort(packages)
+
+	return "urn:pbtype:changelog:" + strings.Join(slices.Compact(packages), ",")
+}
+
+// changelogEntryID returns the permanent ID of entry in Atom feeds.
+func changelogEntryID(entry registry.ChangelogEntry) string {
+	return fmt.Sprintf("urn:pbtype:snapshot:%d-%s", entry.SnapshotID, entry.SnapshotHash)
+}
+
+// requestURL returns the absolute URL of r, taking reverse proxies into
+// account.
+func requestURL(r *http.Request) string {
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+
+	if proto := r.Header.Get("X-Forwarded-Proto"); proto != "" {
+		scheme = proto
+	}
+
+	return fmt.Sprintf("%s://%s%s", scheme, r.Host, r.URL.RequestURI())
+}
+
+// changelogText returns a plain text description of all changes of entry.
+func changelogText(entry registry.ChangelogEntry) string {
+	var b strings.Builder
+
+	for idx, pkg := range entry.Packages {
+		if idx > 0 {
+			b.WriteString("\n")
+		}
+
+		name := pkg.Package
+		if name == "" {
+			name = "(no package)"
+		}
+
+		fmt.Fprintf(&b, "%s %s (%s)\n", pkg.Change, name, pkg.Summary())
+
+		for _, change := range pkg.Entries {
+			fmt.Fprintf(&b, "  - %s\n", change)
+		}
+	}
+
+	return b.String()
+}
+
+// filterChangelog returns all entries that change one of packages or one of
+// their sub-packages. If packages is empty, entries is returned unchanged.
+func filterChangelog(entries []registry.ChangelogEntry, packages []string) []registry.ChangelogEntry {
+	if len(packages) == 0 {
+		return entries
+	}
+
+	var result []registry.ChangelogEntry
+	for _, entry := range entries {
+		if filtered := entry.Filter(packages); len(filtered.Packages) > 0 {
+			result = append(result, filtered)
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,576 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strconv"
+
+	"github.com/bufbuild/connect-go"
+	pbtypev1 "github.com/tierklinik-dobersberg/pbtype-server/gen/go/tkd/pbtype/v1"
+	"github.com/tierklinik-dobersberg/pbtype-server/gen/go/tkd/pbtype/v1/pbtypev1connect"
+	"github.com/tierklinik-dobersberg/pbtype-server/internal/convert"
+	"github.com/tierklinik-dobersberg/pbtype-server/internal/registry"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type DescriptorServer struct {
+	registry *registry.Registry
+
+	pbtypev1connect.UnimplementedDescriptorServiceHandler
+}
+
+func NewDescriptorServer(registry *registry.Registry) *DescriptorServer {
+	return &DescriptorServer{
+		registry: registry,
+	}
+}
+
+func (srv *DescriptorServer) Resolve(ctx context.Context, req *connect.Request[pbtypev1.ResolveRequest]) (*connect.Response[pbtypev1.ResolveResponse], error) {
+	item := &pbtypev1.ResolveItem{}
+
+	switch v := req.Msg.Kind.(type) {
+	case *pbtypev1.ResolveRequest_FileByFilename:
+		item.Kind = &pbtypev1.ResolveItem_FileByFilename{FileByFilename: v.FileByFilename}
+
+	case *pbtypev1.ResolveRequest_FileContainingSymbol:
+		item.Kind = &pbtypev1.ResolveItem_FileContainingSymbol{FileContainingSymbol: v.FileContainingSymbol}
+
+	case *pbtypev1.ResolveRequest_FileContainingUrl:
+		item.Kind = &pbtypev1.ResolveItem_FileContainingUrl{FileContainingUrl: v.FileContainingUrl}
+	}
+
+	files, snap, err := snapshot(ctx, srv.registry, req.Msg.Snapshot)
+	if err != nil {
+		return nil, err
+	}
+
+	desc, err := srv.lookup(files, item, true)
+	if err != nil {
+		return nil, err
+	}
+
+	result := []protoreflect.FileDescriptor{desc}
+	if req.Msg.IncludeDependencies {
+		// the resolved file is always included, even if the client
+		// claims to know it.
+		var known []string
+		for _, path := range req.Msg.KnownFiles {
+			if path != desc.Path() {
+				known = append(known, path)
+			}
+		}
+
+		result = dependencyOrder(result, known)
+	}
+
+	blobs, err := marshalFiles(result)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &pbtypev1.ResolveResponse{
+		FileDescriptorProtos: blobs,
+	}
+
+	if snap != nil {
+		res.SnapshotId = snap.ID
+		res.SnapshotHash = snap.Hash
+		res.Provenance = filesProvenance(snap, result)
+	}
+
+	return connect.NewResponse(res), nil
+}
+
+const (
+	// maxBatchItems is the maximum number of items of a
+	// BatchResolveRequest.
+	maxBatchItems = 500
+
+	// maxSuggestedItems is the maximum number of items of a
+	// BatchResolveRequest that are checked for similar symbols or files
+	// if they cannot be found.
+	maxSuggestedItems = 10
+)
+
+func (srv *DescriptorServer) BatchResolve(ctx context.Context, req *connect.Request[pbtypev1.BatchResolveRequest]) (*connect.Response[pbtypev1.BatchResolveResponse], error) {
+	if n := len(req.Msg.Items); n > maxBatchItems {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("too many items: %d, at most %d are allowed", n, maxBatchItems))
+	}
+
+	resolver, snap, err := snapshot(ctx, srv.registry, req.Msg.Snapshot)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		res   = &pbtypev1.BatchResolveResponse{}
+		files []protoreflect.FileDescriptor
+	)
+
+	if snap != nil {
+		res.SnapshotId = snap.ID
+		res.SnapshotHash = snap.Hash
+	}
+
+	var failed int
+	for _, item := range req.Msg.Items {
+		status := &pbtypev1.ResolveItemStatus{
+			Item: item,
+		}
+
+		desc, err := srv.lookup(resolver, item, failed < maxSuggestedItems)
+		if err != nil {
+			status.Error = err.Error()
+			status.Code = int32(connect.CodeOf(err))
+			status.NotFound = notFoundDetail(err)
+			failed++
+		} else {
+			status.FileName = desc.Path()
+			files = append(files, desc)
+		}
+
+		res.Items = append(res.Items, status)
+	}
+
+	if req.Msg.IncludeDependencies {
+		files = dependencyOrder(files, req.Msg.KnownFiles)
+	} else {
+		files = withoutKnown(files, req.Msg.KnownFiles)
+	}
+
+	blobs, err := marshalFiles(files)
+	if err != nil {
+		return nil, err
+	}
+
+	res.FileDescriptorProtos = blobs
+
+	if snap != nil {
+		res.Provenance = filesProvenance(snap, files)
+	}
+
+	return connect.NewResponse(res), nil
+}
+
+func (srv *DescriptorServer) ListPackages(ctx context.Context, req *connect.Request[pbtypev1.ListPackagesRequest]) (*connect.Response[pbtypev1.ListPackagesResponse], error) {
+	packages := srv.registry.ListPackages(req.Msg.Query, req.Msg.Match == pbtypev1.MatchMode_MATCH_MODE_FUZZY)
+
+	start, end, next, err := paginate(len(packages), req.Msg.PageSize, req.Msg.PageToken)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &pbtypev1.ListPackagesResponse{
+		NextPageToken: next,
+	}
+
+	for _, pkg := range packages[start:end] {
+		res.Packages = append(res.Packages, string(pkg))
+	}
+
+	return connect.NewResponse(res), nil
+}
+
+func (srv *DescriptorServer) ListSymbols(ctx context.Context, req *connect.Request[pbtypev1.ListSymbolsRequest]) (*connect.Response[pbtypev1.ListSymbolsResponse], error) {
+	query := registry.SymbolQuery{
+		Package: protoreflect.FullName(req.Msg.Package),
+		Query:   req.Msg.Query,
+		Fuzzy:   req.Msg.Match == pbtypev1.MatchMode_MATCH_MODE_FUZZY,
+	}
+
+	for _, kind := range req.Msg.Kinds {
+		if kind, ok := convert.RegistrySymbolKind(kind); ok {
+			query.Kinds = append(query.Kinds, kind)
+		}
+	}
+
+	symbols := srv.registry.ListSymbols(query)
+
+	start, end, next, err := paginate(len(symbols), req.Msg.PageSize, req.Msg.PageToken)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &pbtypev1.ListSymbolsResponse{
+		NextPageToken: next,
+	}
+
+	for _, sym := range symbols[start:end] {
+		res.Symbols = append(res.Symbols, symbolProto(sym))
+	}
+
+	return connect.NewResponse(res), nil
+}
+
+func (srv *DescriptorServer) Watch(ctx context.Context, req *connect.Request[pbtypev1.WatchRequest], stream *connect.ServerStream[pbtypev1.WatchResponse]) error {
+	hashes, events, cancel := srv.registry.Subscribe()
+	defer cancel()
+
+	initial := &pbtypev1.WatchResponse{
+		Time:    timestamppb.Now(),
+		Initial: true,
+	}
+
+	for path, hash := range hashes {
+		initial.Changes = append(initial.Changes, &pbtypev1.FileChange{
+			FileName: path,
+			Type:     pbtypev1.FileChangeType_FILE_CHANGE_TYPE_ADDED,
+			Hash:     hash,
+		})
+	}
+
+	sort.Slice(initial.Changes, func(i, j int) bool {
+		return initial.Changes[i].FileName < initial.Changes[j].FileName
+	})
+
+	if err := stream.Send(initial); err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+
+		case event, ok := <-events:
+			if !ok {
+				return connect.NewError(connect.CodeResourceExhausted, errors.New("subscriber did not keep up with changes"))
+			}
+
+			res := &pbtypev1.WatchResponse{
+				Time: timestamppb.New(event.Time),
+			}
+
+			for _, change := range event.Changes {
+				res.Changes = append(res.Changes, &pbtypev1.FileChange{
+					FileName:     change.Path,
+					Type:         convert.FileChangeType(change.Type),
+					Hash:         change.Hash,
+					PreviousHash: change.PreviousHash,
+				})
+			}
+
+			if err := stream.Send(res); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (srv *DescriptorServer) Diff(ctx context.Context, req *connect.Request[pbtypev1.DiffRequest]) (*connect.Response[pbtypev1.DiffResponse], error) {
+	var (
+		res     = &pbtypev1.DiffResponse{}
+		from    *registry.Snapshot
+		entries []registry.DiffEntry
+		err     error
+	)
+
+	switch v := req.Msg.To.(type) {
+	case *pbtypev1.DiffRequest_ToSnapshot:
+		var to *registry.Snapshot
+
+		from, to, entries, err = srv.registry.DiffSnapshots(ctx, req.Msg.FromSnapshot, v.ToSnapshot)
+		if err == nil {
+			res.ToSnapshotId = to.ID
+			res.ToSnapshotHash = to.Hash
+		}
+
+	case *pbtypev1.DiffRequest_FileDescriptorSet:
+		var set descriptorpb.FileDescriptorSet
+		if err := proto.Unmarshal(v.FileDescriptorSet, &set); err != nil {
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid file descriptor set: %w", err))
+		}
+
+		from, entries, err = srv.registry.DiffFiles(ctx, req.Msg.FromSnapshot, set.File)
+
+	default:
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("no snapshot or file descriptor set to compare against"))
+	}
+
+	switch {
+	case errors.Is(err, registry.ErrUnknownSnapshot):
+		return nil, connect.NewError(connect.CodeNotFound, err)
+	case errors.Is(err, registry.ErrInvalidDescriptors):
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	case err != nil:
+		return nil, err
+	}
+
+	res.FromSnapshotId = from.ID
+	res.FromSnapshotHash = from.Hash
+
+	for _, entry := range entries {
+		res.Entries = append(res.Entries, convert.DiffEntry(entry))
+	}
+
+	return connect.NewResponse(res), nil
+}
+
+func (srv *DescriptorServer) GetChangelog(ctx context.Context, req *connect.Request[pbtypev1.GetChangelogRequest]) (*connect.Response[pbtypev1.GetChangelogResponse], error) {
+	entries := filterChangelog(srv.registry.Changelog(), req.Msg.Packages)
+
+	start, end, next, err := paginate(len(entries), req.Msg.PageSize, req.Msg.PageToken)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &pbtypev1.GetChangelogResponse{
+		NextPageToken: next,
+	}
+
+	for _, entry := range entries[start:end] {
+		res.Entries = append(res.Entries, convert.ChangelogEntry(entry))
+	}
+
+	return connect.NewResponse(res), nil
+}
+
+func (srv *DescriptorServer) GetProvenance(ctx context.Context, req *connect.Request[pbtypev1.GetProvenanceRequest]) (*connect.Response[pbtypev1.GetProvenanceResponse], error) {
+	snap, err := srv.registry.Snapshot(ctx, req.Msg.Snapshot)
+	if err != nil {
+		if errors.Is(err, registry.ErrUnknownSnapshot) {
+			return nil, connect.NewError(connect.CodeNotFound, err)
+		}
+
+		return nil, err
+	}
+
+	files := req.Msg.FileNames
+	if len(files) == 0 {
+		files = snap.Files()
+	}
+
+	res := &pbtypev1.GetProvenanceResponse{
+		SnapshotId:   snap.ID,
+		SnapshotHash: snap.Hash,
+	}
+
+	for _, path := range files {
+		prov, ok := snap.FileProvenance(path)
+		if !ok {
+			return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("file %q is not provided by any source in snapshot %d", path, snap.ID))
+		}
+
+		res.Files = append(res.Files, provenanceProto(path, prov))
+	}
+
+	return connect.NewResponse(res), nil
+}
+
+// fileResolver is implemented by registry.Registry and registry.Snapshot.
+type fileResolver interface {
+	FileByFilename(name string) (protoreflect.FileDescriptor, error)
+	FileContainingSymbol(name protoreflect.FullName) (protoreflect.FileDescriptor, error)
+	FileContaingURL(url string) (protoreflect.FileDescriptor, error)
+
+	SuggestFiles(filename string, limit int) []string
+	SuggestSymbols(name protoreflect.FullName, limit int) []registry.Symbol
+	PackageOf(name protoreflect.FullName) protoreflect.FullName
+}
+
+// snapshot returns the snapshot of reg identified by ref. If ref is empty
+// and no snapshot has been activated yet, the registry itself is returned.
+func snapshot(ctx context.Context, reg *registry.Registry, ref string) (fileResolver, *registry.Snapshot, error) {
+	snap, err := reg.Snapshot(ctx, ref)
+	switch {
+	case err == nil:
+		return snap, snap, nil
+
+	case errors.Is(err, registry.ErrUnknownSnapshot):
+		if ref == "" {
+			return reg, nil, nil
+		}
+
+		return nil, nil, connect.NewError(connect.CodeNotFound, err)
+
+	default:
+		return nil, nil, err
+	}
+}
+
+// lookup resolves the file descriptor for item using files. If suggest is
+// set, NotFound errors suggest similar symbols or files of files.
+func (srv *DescriptorServer) lookup(files fileResolver, item *pbtypev1.ResolveItem, suggest bool) (protoreflect.FileDescriptor, error) {
+	var (
+		desc protoreflect.FileDescriptor
+		err  error
+	)
+
+	switch v := item.Kind.(type) {
+	case *pbtypev1.ResolveItem_FileByFilename:
+		slog.Info("resolving proto type", "filename", v.FileByFilename)
+		desc, err = files.FileByFilename(v.FileByFilename)
+
+	case *pbtypev1.ResolveItem_FileContainingSymbol:
+		slog.Info("resolving proto type", "symbol", v.FileContainingSymbol)
+		desc, err = files.FileContainingSymbol(protoreflect.FullName(v.FileContainingSymbol))
+
+	case *pbtypev1.ResolveItem_FileContainingUrl:
+		slog.Info("resolving proto type", "url", v.FileContainingUrl)
+		desc, err = files.FileContaingURL(v.FileContainingUrl)
+
+	default:
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("no message kind specified"))
+	}
+
+	if err != nil {
+		if !errors.Is(err, protoregistry.NotFound) {
+			return nil, err
+		}
+
+		if !suggest {
+			return nil, connect.NewError(connect.CodeNotFound, err)
+		}
+
+		return nil, notFoundError(srv.registry, files, err, item.GetFileByFilename(), item.GetFileContainingSymbol(), item.GetFileContainingUrl())
+	}
+
+	return desc, nil
+}
+
+// filesProvenance returns the provenance of all files that have been
+// provided by a source of snap.
+func filesProvenance(snap *registry.Snapshot, files []protoreflect.FileDescriptor) []*pbtypev1.FileProvenance {
+	var result []*pbtypev1.FileProvenance
+
+	for _, file := range files {
+		if prov, ok := snap.FileProvenance(file.Path()); ok {
+			result = append(result, provenanceProto(file.Path(), prov))
+		}
+	}
+
+	return result
+}
+
+func provenanceProto(path string, prov registry.Provenance) *pbtypev1.FileProvenance {
+	res := &pbtypev1.FileProvenance{
+		FileName:     path,
+		Source:       prov.Source,
+		Url:          prov.URL,
+		Revision:     prov.Revision,
+		OriginalPath: prov.Path,
+	}
+
+	if !prov.Fetched.IsZero() {
+		res.Fetched = timestamppb.New(prov.Fetched)
+	}
+
+	return res
+}
+
+// dependencyOrder returns files and all of their transitive dependencies,
+// without duplicates and ordered so each file follows its dependencies.
+// Files in known, and their dependencies, are skipped.
+func dependencyOrder(files []protoreflect.FileDescriptor, known []string) []protoreflect.FileDescriptor {
+	var (
+		result []protoreflect.FileDescriptor
+		seen   = make(map[string]struct{}, len(known))
+		visit  func(file protoreflect.FileDescriptor)
+	)
+
+	for _, path := range known {
+		seen[path] = struct{}{}
+	}
+
+	visit = func(file protoreflect.FileDescriptor) {
+		if _, ok := seen[file.Path()]; ok {
+			return
+		}
+		seen[file.Path()] = struct{}{}
+
+		imports := file.Imports()
+		for i := 0; i < imports.Len(); i++ {
+			visit(imports.Get(i).FileDescriptor)
+		}
+
+		result = append(result, file)
+	}
+
+	for _, file := range files {
+		visit(file)
+	}
+
+	return result
+}
+
+// withoutKnown returns files without duplicates and without files in
+// known.
+func withoutKnown(files []protoreflect.FileDescriptor, known []string) []protoreflect.FileDescriptor {
+	var (
+		result []protoreflect.FileDescriptor
+		seen   = make(map[string]struct{}, len(known))
+	)
+
+	for _, path := range known {
+		seen[path] = struct{}{}
+	}
+
+	for _, file := range files {
+		if _, ok := seen[file.Path()]; ok {
+			continue
+		}
+		seen[file.Path()] = struct{}{}
+
+		result = append(result, file)
+	}
+
+	return result
+}
+
+// marshalFiles serializes the file descriptor protos of files.
+func marshalFiles(files []protoreflect.FileDescriptor) ([][]byte, error) {
+	result := make([][]byte, len(files))
+
+	for idx, file := range files {
+		blob, err := proto.Marshal(protodesc.ToFileDescriptorProto(file))
+		if err != nil {
+			return nil, err
+		}
+
+		result[idx] = blob
+	}
+
+	return result, nil
+}
+
+const (
+	defaultPageSize = 100
+	maxPageSize     = 1000
+)
+
+// paginate returns the range of results to return for a page of size
+// pageSize starting at the offset encoded in token, and the token of the
+// next page.
+func paginate(total int, pageSize int32, token string) (start, end int, next string, err error) {
+	if token != "" {
+		start, err = strconv.Atoi(token)
+		if err != nil || start < 0 {
+			return 0, 0, "", connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid page token %q", token))
+		}
+	}
+
+	size := int(pageSize)
+	switch {
+	case size <= 0:
+		size = defaultPageSize
+	case size > maxPageSize:
+		size = maxPageSize
+	}
+
+	start = min(start, total)
+	end = min(start+size, total)
+
+	if end < total {
+		next = strconv.Itoa(end)
+	}
+
+	return start, end, next, nil
+}
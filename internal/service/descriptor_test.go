@@ -0,0 +1,230 @@
+package service
+
+import (
+	"context"
+	"slices"
+	"strconv"
+	"testing"
+
+	"github.com/bufbuild/connect-go"
+	pbtypev1 "github.com/tierklinik-dobersberg/pbtype-server/gen/go/tkd/pbtype/v1"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+func TestListSymbols(t *testing.T) {
+	srv := NewDescriptorServer(startRegistry(t, map[string]string{
+		"acme/v1/a.proto":  `syntax = "proto3"; package acme.v1; message Alpha {} message Beta {} enum Kind { KIND_UNSPECIFIED = 0; }`,
+		"other/v1/o.proto": `syntax = "proto3"; package other.v1; service Other {}`,
+	}))
+
+	ctx := context.Background()
+
+	packages, err := srv.ListPackages(ctx, connect.NewRequest(&pbtypev1.ListPackagesRequest{Query: "acme"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := packages.Msg.Packages; len(got) != 1 || got[0] != "acme.v1" {
+		t.Errorf("unexpected packages %v", got)
+	}
+
+	// page through all messages of acme.v1
+	var (
+		names []string
+		token string
+	)
+
+	for {
+		res, err := srv.ListSymbols(ctx, connect.NewRequest(&pbtypev1.ListSymbolsRequest{
+			Package:   "acme.v1",
+			Kinds:     []pbtypev1.SymbolKind{pbtypev1.SymbolKind_SYMBOL_KIND_MESSAGE},
+			PageSize:  1,
+			PageToken: token,
+		}))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, sym := range res.Msg.Symbols {
+			if sym.Kind != pbtypev1.SymbolKind_SYMBOL_KIND_MESSAGE || sym.FileName != "acme/v1/a.proto" {
+				t.Errorf("unexpected symbol %v", sym)
+			}
+
+			names = append(names, sym.Name)
+		}
+
+		if token = res.Msg.NextPageToken; token == "" {
+			break
+		}
+	}
+
+	if len(names) != 2 || names[0] != "acme.v1.Alpha" || names[1] != "acme.v1.Beta" {
+		t.Errorf("unexpected messages %v", names)
+	}
+}
+
+func TestResolveSnapshot(t *testing.T) {
+	reg := startRegistry(t, map[string]string{
+		"acme/v1/a.proto": `syntax = "proto3"; package acme.v1; import "acme/v1/b.proto"; message A { B b = 1; }`,
+		"acme/v1/b.proto": `syntax = "proto3"; package acme.v1; message B {}`,
+	})
+
+	var (
+		srv = NewDescriptorServer(reg)
+		ctx = context.Background()
+	)
+
+	current, err := reg.Snapshot(ctx, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, ref := range []string{strconv.FormatUint(current.ID, 10), current.Hash[:12]} {
+		res, err := srv.Resolve(ctx, connect.NewRequest(&pbtypev1.ResolveRequest{
+			Kind:                &pbtypev1.ResolveRequest_FileContainingSymbol{FileContainingSymbol: "acme.v1.A"},
+			IncludeDependencies: true,
+			Snapshot:            ref,
+		}))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if res.Msg.SnapshotId != current.ID || res.Msg.SnapshotHash != current.Hash {
+			t.Errorf("%s: unexpected snapshot %d (%s)", ref, res.Msg.SnapshotId, res.Msg.SnapshotHash)
+		}
+
+		// dependencies are sent first
+		if len(res.Msg.FileDescriptorProtos) != 2 || len(res.Msg.Provenance) != 2 || res.Msg.Provenance[0].FileName != "acme/v1/b.proto" {
+			t.Errorf("%s: unexpected files %v", ref, res.Msg.Provenance)
+		}
+	}
+
+	_, err = srv.Resolve(ctx, connect.NewRequest(&pbtypev1.ResolveRequest{
+		Kind:     &pbtypev1.ResolveRequest_FileContainingSymbol{FileContainingSymbol: "acme.v1.A"},
+		Snapshot: "999999999",
+	}))
+	if connect.CodeOf(err) != connect.CodeNotFound {
+		t.Errorf("expected an unknown snapshot to be reported as not found, got %v", err)
+	}
+}
+
+func TestBatchResolve(t *testing.T) {
+	srv := NewDescriptorServer(startRegistry(t, map[string]string{
+		"acme/v1/a.proto": `syntax = "proto3"; package acme.v1; import "acme/v1/b.proto"; message A { B b = 1; }`,
+		"acme/v1/b.proto": `syntax = "proto3"; package acme.v1; message B {}`,
+		"acme/v1/c.proto": `syntax = "proto3"; package acme.v1; message C {}`,
+	}))
+
+	ctx := context.Background()
+
+	res, err := srv.BatchResolve(ctx, connect.NewRequest(&pbtypev1.BatchResolveRequest{
+		Items: []*pbtypev1.ResolveItem{
+			{Kind: &pbtypev1.ResolveItem_FileContainingSymbol{FileContainingSymbol: "acme.v1.A"}},
+			{Kind: &pbtypev1.ResolveItem_FileContainingUrl{FileContainingUrl: "type.googleapis.com/acme.v1.C"}},
+			{Kind: &pbtypev1.ResolveItem_FileContainingSymbol{FileContainingSymbol: "acme.v1.D"}},
+		},
+		IncludeDependencies: true,
+		KnownFiles:          []string{"acme/v1/b.proto"},
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	items := res.Msg.Items
+	if len(items) != 3 || items[0].FileName != "acme/v1/a.proto" || items[1].FileName != "acme/v1/c.proto" || items[0].Code != 0 {
+		t.Fatalf("unexpected items %v", items)
+	}
+
+	if items[2].Code != int32(connect.CodeNotFound) || items[2].Error == "" || len(items[2].NotFound.GetSuggestedSymbols()) == 0 {
+		t.Errorf("expected the unknown symbol to be reported with suggestions, got %v", items[2])
+	}
+
+	// the known dependency is skipped
+	if len(res.Msg.FileDescriptorProtos) != 2 {
+		t.Errorf("expected 2 files, got %d", len(res.Msg.FileDescriptorProtos))
+	}
+
+	// only the first items that cannot be found get suggestions
+	missing := make([]*pbtypev1.ResolveItem, maxSuggestedItems+1)
+	for idx := range missing {
+		missing[idx] = &pbtypev1.ResolveItem{Kind: &pbtypev1.ResolveItem_FileContainingSymbol{FileContainingSymbol: "acme.v1.D"}}
+	}
+
+	res, err = srv.BatchResolve(ctx, connect.NewRequest(&pbtypev1.BatchResolveRequest{Items: missing}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if last := res.Msg.Items[maxSuggestedItems]; last.Code != int32(connect.CodeNotFound) || last.NotFound != nil {
+		t.Errorf("expected no suggestions beyond %d items, got %v", maxSuggestedItems, last)
+	}
+
+	_, err = srv.BatchResolve(ctx, connect.NewRequest(&pbtypev1.BatchResolveRequest{
+		Items: make([]*pbtypev1.ResolveItem, maxBatchItems+1),
+	}))
+	if connect.CodeOf(err) != connect.CodeInvalidArgument {
+		t.Errorf("expected too many items to be rejected, got %v", err)
+	}
+}
+
+func TestDependencyOrder(t *testing.T) {
+	reg := startRegistry(t, map[string]string{
+		"acme/v1/a.proto": `syntax = "proto3"; package acme.v1; import "acme/v1/b.proto"; message A { B b = 1; }`,
+		"acme/v1/b.proto": `syntax = "proto3"; package acme.v1; import "acme/v1/c.proto"; message B { C c = 1; }`,
+		"acme/v1/c.proto": `syntax = "proto3"; package acme.v1; message C {}`,
+		"acme/v1/d.proto": `syntax = "proto3"; package acme.v1; message D {}`,
+	})
+
+	file := func(path string) protoreflect.FileDescriptor {
+		desc, err := reg.FileByFilename(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return desc
+	}
+
+	paths := func(files []protoreflect.FileDescriptor) []string {
+		var result []string
+		for _, file := range files {
+			result = append(result, file.Path())
+		}
+
+		return result
+	}
+
+	var (
+		a = file("acme/v1/a.proto")
+		b = file("acme/v1/b.proto")
+		d = file("acme/v1/d.proto")
+	)
+
+	cases := []struct {
+		name string
+		got  []protoreflect.FileDescriptor
+		want []string
+	}{
+		{
+			name: "dependencies first",
+			got:  dependencyOrder([]protoreflect.FileDescriptor{a, d, b}, nil),
+			want: []string{"acme/v1/c.proto", "acme/v1/b.proto", "acme/v1/a.proto", "acme/v1/d.proto"},
+		},
+		{
+			// known files are skipped together with their dependencies
+			name: "known",
+			got:  dependencyOrder([]protoreflect.FileDescriptor{a}, []string{"acme/v1/b.proto"}),
+			want: []string{"acme/v1/a.proto"},
+		},
+		{
+			name: "without known",
+			got:  withoutKnown([]protoreflect.FileDescriptor{a, d, a, b}, []string{"acme/v1/d.proto"}),
+			want: []string{"acme/v1/a.proto", "acme/v1/b.proto"},
+		},
+	}
+
+	for _, c := range cases {
+		if got := paths(c.got); !slices.Equal(got, c.want) {
+			t.Errorf("%s: got %v, want %v", c.name, got, c.want)
+		}
+	}
+}
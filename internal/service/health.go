@@ -0,0 +1,136 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/bufbuild/connect-go"
+	"github.com/tierklinik-dobersberg/apis/gen/go/tkd/typeserver/v1/typeserverv1connect"
+	"github.com/tierklinik-dobersberg/pbtype-server/internal/registry"
+	"google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// healthWatchInterval defines how often the registry state is checked for
+// changes while a client is watching the health status.
+const healthWatchInterval = 5 * time.Second
+
+// HealthServer reports the state of the registry using plain HTTP endpoints
+// and the standard gRPC health checking protocol.
+type HealthServer struct {
+	registry *registry.Registry
+}
+
+func NewHealthServer(registry *registry.Registry) *HealthServer {
+	return &HealthServer{
+		registry: registry,
+	}
+}
+
+// Handler returns the path and the http.Handler that serves the gRPC health
+// service.
+func (srv *HealthServer) Handler() (string, http.Handler) {
+	check := connect.NewUnaryHandler(grpc_health_v1.Health_Check_FullMethodName, srv.Check)
+	watch := connect.NewServerStreamHandler(grpc_health_v1.Health_Watch_FullMethodName, srv.Watch)
+
+	return "/" + grpc_health_v1.Health_ServiceDesc.ServiceName + "/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case grpc_health_v1.Health_Check_FullMethodName:
+			check.ServeHTTP(w, r)
+		case grpc_health_v1.Health_Watch_FullMethodName:
+			watch.ServeHTTP(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+func (srv *HealthServer) Check(ctx context.Context, req *connect.Request[grpc_health_v1.HealthCheckRequest]) (*connect.Response[grpc_health_v1.HealthCheckResponse], error) {
+	status, err := srv.servingStatus(req.Msg.Service)
+	if err != nil {
+		return nil, err
+	}
+
+	return connect.NewResponse(&grpc_health_v1.HealthCheckResponse{
+		Status: status,
+	}), nil
+}
+
+func (srv *HealthServer) Watch(ctx context.Context, req *connect.Request[grpc_health_v1.HealthCheckRequest], stream *connect.ServerStream[grpc_health_v1.HealthCheckResponse]) error {
+	ticker := time.NewTicker(healthWatchInterval)
+	defer ticker.Stop()
+
+	last := grpc_health_v1.HealthCheckResponse_UNKNOWN
+
+	for {
+		// As defined by the health checking protocol, unknown services are
+		// reported as SERVICE_UNKNOWN instead of failing the stream.
+		status, err := srv.servingStatus(req.Msg.Service)
+		if err != nil {
+			status = grpc_health_v1.HealthCheckResponse_SERVICE_UNKNOWN
+		}
+
+		if status != last {
+			if err := stream.Send(&grpc_health_v1.HealthCheckResponse{Status: status}); err != nil {
+				return err
+			}
+
+			last = status
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// Liveness always reports the server as alive.
+func (srv *HealthServer) Liveness(w http.ResponseWriter, _ *http.Request) {
+	fmt.Fprintln(w, "ok")
+}
+
+// Readiness reports the server as ready once the registry has a compiled
+// snapshot. If the last refresh failed, the server is still ready but
+// reported as degraded along with the names of the failed sources. The
+// errors themselves are only available using the admin API since they may
+// quote source URLs.
+func (srv *HealthServer) Readiness(w http.ResponseWriter, _ *http.Request) {
+	if !srv.registry.Ready() {
+		http.Error(w, "not ready: no compiled snapshot available", http.StatusServiceUnavailable)
+
+		return
+	}
+
+	if srv.registry.Status().LastError != nil {
+		var failed []string
+		for _, src := range srv.registry.Sources() {
+			if src.Status.LastError != nil {
+				failed = append(failed, src.Name)
+			}
+		}
+
+		fmt.Fprintf(w, "degraded: failed sources: %s\n", strings.Join(failed, ", "))
+
+		return
+	}
+
+	fmt.Fprintln(w, "ok")
+}
+
+func (srv *HealthServer) servingStatus(service string) (grpc_health_v1.HealthCheckResponse_ServingStatus, error) {
+	switch service {
+	case "", typeserverv1connect.TypeResolverServiceName:
+	default:
+		return grpc_health_v1.HealthCheckResponse_SERVICE_UNKNOWN, connect.NewError(connect.CodeNotFound, fmt.Errorf("unknown service %q", service))
+	}
+
+	if !srv.registry.Ready() {
+		return grpc_health_v1.HealthCheckResponse_NOT_SERVING, nil
+	}
+
+	return grpc_health_v1.HealthCheckResponse_SERVING, nil
+}
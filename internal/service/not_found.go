@@ -0,0 +1,83 @@
+package service
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/bufbuild/connect-go"
+	pbtypev1 "github.com/tierklinik-dobersberg/pbtype-server/gen/go/tkd/pbtype/v1"
+	"github.com/tierklinik-dobersberg/pbtype-server/internal/convert"
+	"github.com/tierklinik-dobersberg/pbtype-server/internal/registry"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// maxSuggestions is the maximum number of suggested symbols or files
+// attached to NotFound errors.
+const maxSuggestions = 5
+
+// notFoundError returns a NotFound error for the requested file name,
+// symbol or type URL with a pbtypev1.NotFoundDetail that suggests similar
+// symbols or files of files, which is either reg or a pinned snapshot.
+func notFoundError(reg *registry.Registry, files fileResolver, err error, filename, symbol, url string) *connect.Error {
+	cerr := connect.NewError(connect.CodeNotFound, err)
+
+	detail := &pbtypev1.NotFoundDetail{}
+
+	switch {
+	case filename != "":
+		detail.Name = filename
+		detail.SuggestedFiles = files.SuggestFiles(filename, maxSuggestions)
+
+	default:
+		detail.Name = symbol
+
+		if url != "" {
+			detail.Name = url
+			symbol = url[strings.LastIndex(url, "/")+1:]
+		}
+
+		name := protoreflect.FullName(symbol)
+		pkg := files.PackageOf(name)
+
+		detail.Package = string(pkg)
+		detail.Sources = reg.SourcesOfPackage(pkg)
+
+		for _, sym := range files.SuggestSymbols(name, maxSuggestions) {
+			detail.SuggestedSymbols = append(detail.SuggestedSymbols, symbolProto(sym))
+		}
+	}
+
+	if d, err := connect.NewErrorDetail(detail); err == nil {
+		cerr.AddDetail(d)
+	}
+
+	return cerr
+}
+
+// notFoundDetail returns the pbtypev1.NotFoundDetail attached to err by
+// notFoundError, if any.
+func notFoundDetail(err error) *pbtypev1.NotFoundDetail {
+	var cerr *connect.Error
+	if !errors.As(err, &cerr) {
+		return nil
+	}
+
+	for _, d := range cerr.Details() {
+		if value, err := d.Value(); err == nil {
+			if detail, ok := value.(*pbtypev1.NotFoundDetail); ok {
+				return detail
+			}
+		}
+	}
+
+	return nil
+}
+
+func symbolProto(sym registry.Symbol) *pbtypev1.Symbol {
+	return &pbtypev1.Symbol{
+		Name:     string(sym.Name),
+		Kind:     convert.SymbolKind(sym.Kind),
+		FileName: sym.File,
+		Package:  string(sym.Package),
+	}
+}
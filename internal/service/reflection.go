@@ -0,0 +1,249 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+
+	"github.com/bufbuild/connect-go"
+	"github.com/tierklinik-dobersberg/pbtype-server/internal/registry"
+	"google.golang.org/grpc/codes"
+	reflectionv1 "google.golang.org/grpc/reflection/grpc_reflection_v1"
+	reflectionv1alpha "google.golang.org/grpc/reflection/grpc_reflection_v1alpha"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+// ReflectionServer implements the gRPC server reflection protocol, both
+// grpc.reflection.v1 and grpc.reflection.v1alpha, using the files of the
+// registry.
+type ReflectionServer struct {
+	registry *registry.Registry
+}
+
+func NewReflectionServer(registry *registry.Registry) *ReflectionServer {
+	return &ReflectionServer{
+		registry: registry,
+	}
+}
+
+// Handler returns the path and the http.Handler for grpc.reflection.v1.
+func (srv *ReflectionServer) Handler() (string, http.Handler) {
+	return reflectionv1.ServerReflection_ServerReflectionInfo_FullMethodName, connect.NewBidiStreamHandler(
+		reflectionv1.ServerReflection_ServerReflectionInfo_FullMethodName,
+		srv.ServerReflectionInfo,
+	)
+}
+
+// HandlerV1Alpha returns the path and the http.Handler for
+// grpc.reflection.v1alpha.
+func (srv *ReflectionServer) HandlerV1Alpha() (string, http.Handler) {
+	return reflectionv1alpha.ServerReflection_ServerReflectionInfo_FullMethodName, connect.NewBidiStreamHandler(
+		reflectionv1alpha.ServerReflection_ServerReflectionInfo_FullMethodName,
+		srv.ServerReflectionInfoV1Alpha,
+	)
+}
+
+func (srv *ReflectionServer) ServerReflectionInfo(ctx context.Context, stream *connect.BidiStream[reflectionv1.ServerReflectionRequest, reflectionv1.ServerReflectionResponse]) error {
+	return srv.serve(stream.Receive, stream.Send)
+}
+
+// ServerReflectionInfoV1Alpha serves grpc.reflection.v1alpha. Since the
+// messages of v1alpha and v1 are wire compatible, requests and responses
+// are converted by re-encoding them.
+func (srv *ReflectionServer) ServerReflectionInfoV1Alpha(ctx context.Context, stream *connect.BidiStream[reflectionv1alpha.ServerReflectionRequest, reflectionv1alpha.ServerReflectionResponse]) error {
+	return srv.serve(
+		func() (*reflectionv1.ServerReflectionRequest, error) {
+			req, err := stream.Receive()
+			if err != nil {
+				return nil, err
+			}
+
+			converted := new(reflectionv1.ServerReflectionRequest)
+			if err := convertMessage(req, converted); err != nil {
+				return nil, err
+			}
+
+			return converted, nil
+		},
+		func(res *reflectionv1.ServerReflectionResponse) error {
+			converted := new(reflectionv1alpha.ServerReflectionResponse)
+			if err := convertMessage(res, converted); err != nil {
+				return err
+			}
+
+			return stream.Send(converted)
+		},
+	)
+}
+
+func (srv *ReflectionServer) serve(receive func() (*reflectionv1.ServerReflectionRequest, error), send func(*reflectionv1.ServerReflectionResponse) error) error {
+	// sent holds the paths of all files that have already been sent on
+	// this stream so dependencies are only sent once.
+	sent := make(map[string]struct{})
+
+	for {
+		req, err := receive()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if err := send(srv.handle(req, sent)); err != nil {
+			return err
+		}
+	}
+}
+
+func (srv *ReflectionServer) handle(req *reflectionv1.ServerReflectionRequest, sent map[string]struct{}) *reflectionv1.ServerReflectionResponse {
+	res := &reflectionv1.ServerReflectionResponse{
+		ValidHost:       req.Host,
+		OriginalRequest: req,
+	}
+
+	var (
+		desc protoreflect.FileDescriptor
+		err  error
+	)
+
+	switch v := req.MessageRequest.(type) {
+	case *reflectionv1.ServerReflectionRequest_FileByFilename:
+		desc, err = srv.registry.FileByFilename(v.FileByFilename)
+
+	case *reflectionv1.ServerReflectionRequest_FileContainingSymbol:
+		desc, err = srv.registry.FileContainingSymbol(protoreflect.FullName(v.FileContainingSymbol))
+
+	case *reflectionv1.ServerReflectionRequest_FileContainingExtension:
+		desc, err = srv.registry.FileContainingExtension(
+			protoreflect.FullName(v.FileContainingExtension.ContainingType),
+			protoreflect.FieldNumber(v.FileContainingExtension.ExtensionNumber),
+		)
+
+	case *reflectionv1.ServerReflectionRequest_AllExtensionNumbersOfType:
+		var numbers []protoreflect.FieldNumber
+
+		numbers, err = srv.registry.ExtensionNumbersOfType(protoreflect.FullName(v.AllExtensionNumbersOfType))
+		if err == nil {
+			extensions := &reflectionv1.ExtensionNumberResponse{
+				BaseTypeName: v.AllExtensionNumbersOfType,
+			}
+
+			for _, number := range numbers {
+				extensions.ExtensionNumber = append(extensions.ExtensionNumber, int32(number))
+			}
+
+			res.MessageResponse = &reflectionv1.ServerReflectionResponse_AllExtensionNumbersResponse{
+				AllExtensionNumbersResponse: extensions,
+			}
+
+			return res
+		}
+
+	case *reflectionv1.ServerReflectionRequest_ListServices:
+		services := &reflectionv1.ListServiceResponse{}
+
+		for _, name := range srv.registry.ListServices() {
+			services.Service = append(services.Service, &reflectionv1.ServiceResponse{
+				Name: string(name),
+			})
+		}
+
+		res.MessageResponse = &reflectionv1.ServerReflectionResponse_ListServicesResponse{
+			ListServicesResponse: services,
+		}
+
+		return res
+
+	default:
+		return reflectionError(res, codes.InvalidArgument, "invalid message request")
+	}
+
+	if err != nil {
+		if errors.Is(err, protoregistry.NotFound) {
+			return reflectionError(res, codes.NotFound, err.Error())
+		}
+
+		return reflectionError(res, codes.Internal, err.Error())
+	}
+
+	files, err := fileWithDependencies(desc, sent)
+	if err != nil {
+		slog.Error("failed to marshal file descriptor", "file", desc.Path(), "error", err)
+
+		return reflectionError(res, codes.Internal, err.Error())
+	}
+
+	res.MessageResponse = &reflectionv1.ServerReflectionResponse_FileDescriptorResponse{
+		FileDescriptorResponse: &reflectionv1.FileDescriptorResponse{
+			FileDescriptorProto: files,
+		},
+	}
+
+	return res
+}
+
+// fileWithDependencies returns the serialized file descriptor of desc
+// followed by all transitive dependencies that have not yet been sent. The
+// requested file is always included.
+func fileWithDependencies(desc protoreflect.FileDescriptor, sent map[string]struct{}) ([][]byte, error) {
+	var (
+		result [][]byte
+		queue  = []protoreflect.FileDescriptor{desc}
+	)
+
+	sent[desc.Path()] = struct{}{}
+
+	for len(queue) > 0 {
+		file := queue[0]
+		queue = queue[1:]
+
+		blob, err := proto.Marshal(protodesc.ToFileDescriptorProto(file))
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, blob)
+
+		imports := file.Imports()
+		for i := 0; i < imports.Len(); i++ {
+			imp := imports.Get(i).FileDescriptor
+
+			if _, ok := sent[imp.Path()]; ok {
+				continue
+			}
+
+			sent[imp.Path()] = struct{}{}
+			queue = append(queue, imp)
+		}
+	}
+
+	return result, nil
+}
+
+func reflectionError(res *reflectionv1.ServerReflectionResponse, code codes.Code, msg string) *reflectionv1.ServerReflectionResponse {
+	res.MessageResponse = &reflectionv1.ServerReflectionResponse_ErrorResponse{
+		ErrorResponse: &reflectionv1.ErrorResponse{
+			ErrorCode:    int32(code),
+			ErrorMessage: msg,
+		},
+	}
+
+	return res
+}
+
+// convertMessage converts between wire compatible messages.
+func convertMessage(from, to proto.Message) error {
+	blob, err := proto.Marshal(from)
+	if err != nil {
+		return err
+	}
+
+	return proto.Unmarshal(blob, to)
+}
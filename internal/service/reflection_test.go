@@ -0,0 +1,146 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/bufbuild/connect-go"
+	"google.golang.org/grpc/codes"
+	reflectionv1 "google.golang.org/grpc/reflection/grpc_reflection_v1"
+	reflectionv1alpha "google.golang.org/grpc/reflection/grpc_reflection_v1alpha"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// reflectionFiles returns the paths of the file descriptors in blobs.
+func reflectionFiles(t *testing.T, blobs [][]byte) []string {
+	t.Helper()
+
+	var paths []string
+	for _, blob := range blobs {
+		var file descriptorpb.FileDescriptorProto
+		if err := proto.Unmarshal(blob, &file); err != nil {
+			t.Fatal(err)
+		}
+
+		paths = append(paths, file.GetName())
+	}
+
+	return paths
+}
+
+func TestReflection(t *testing.T) {
+	reg := startRegistry(t, map[string]string{
+		"acme/v1/a.proto": `syntax = "proto3"; package acme.v1; import "acme/v1/b.proto"; message A { B b = 1; }`,
+		"acme/v1/b.proto": `syntax = "proto3"; package acme.v1; message B {}`,
+	})
+
+	var (
+		srv = NewReflectionServer(reg)
+		mux = http.NewServeMux()
+	)
+
+	mux.Handle(srv.Handler())
+	mux.Handle(srv.HandlerV1Alpha())
+
+	// bidirectional streams require HTTP/2
+	server := httptest.NewUnstartedServer(mux)
+	server.EnableHTTP2 = true
+	server.StartTLS()
+	t.Cleanup(server.Close)
+
+	t.Run("v1", func(t *testing.T) {
+		client := connect.NewClient[reflectionv1.ServerReflectionRequest, reflectionv1.ServerReflectionResponse](
+			server.Client(),
+			server.URL+reflectionv1.ServerReflection_ServerReflectionInfo_FullMethodName,
+			connect.WithGRPC(),
+		)
+
+		stream := client.CallBidiStream(context.Background())
+		defer stream.CloseResponse()
+
+		roundTrip := func(req *reflectionv1.ServerReflectionRequest) *reflectionv1.ServerReflectionResponse {
+			t.Helper()
+
+			if err := stream.Send(req); err != nil {
+				t.Fatal(err)
+			}
+
+			res, err := stream.Receive()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			return res
+		}
+
+		res := roundTrip(&reflectionv1.ServerReflectionRequest{
+			MessageRequest: &reflectionv1.ServerReflectionRequest_FileContainingSymbol{FileContainingSymbol: "acme.v1.A"},
+		})
+
+		// the file is followed by its dependencies
+		if got := reflectionFiles(t, res.GetFileDescriptorResponse().GetFileDescriptorProto()); len(got) != 2 || got[0] != "acme/v1/a.proto" || got[1] != "acme/v1/b.proto" {
+			t.Errorf("unexpected files %v", got)
+		}
+
+		// files already sent on the stream are only sent if requested
+		res = roundTrip(&reflectionv1.ServerReflectionRequest{
+			MessageRequest: &reflectionv1.ServerReflectionRequest_FileContainingSymbol{FileContainingSymbol: "acme.v1.B"},
+		})
+
+		if got := reflectionFiles(t, res.GetFileDescriptorResponse().GetFileDescriptorProto()); len(got) != 1 || got[0] != "acme/v1/b.proto" {
+			t.Errorf("unexpected files %v", got)
+		}
+
+		res = roundTrip(&reflectionv1.ServerReflectionRequest{
+			MessageRequest: &reflectionv1.ServerReflectionRequest_FileContainingSymbol{FileContainingSymbol: "acme.v1.Unknown"},
+		})
+
+		if code := res.GetErrorResponse().GetErrorCode(); code != int32(codes.NotFound) {
+			t.Errorf("expected NOT_FOUND, got %d", code)
+		}
+
+		if err := stream.CloseRequest(); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	t.Run("v1alpha", func(t *testing.T) {
+		client := connect.NewClient[reflectionv1alpha.ServerReflectionRequest, reflectionv1alpha.ServerReflectionResponse](
+			server.Client(),
+			server.URL+reflectionv1alpha.ServerReflection_ServerReflectionInfo_FullMethodName,
+			connect.WithGRPC(),
+		)
+
+		stream := client.CallBidiStream(context.Background())
+		defer stream.CloseResponse()
+
+		req := &reflectionv1alpha.ServerReflectionRequest{
+			Host:           "localhost",
+			MessageRequest: &reflectionv1alpha.ServerReflectionRequest_FileContainingSymbol{FileContainingSymbol: "acme.v1.A"},
+		}
+
+		if err := stream.Send(req); err != nil {
+			t.Fatal(err)
+		}
+
+		res, err := stream.Receive()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if res.GetValidHost() != "localhost" || !proto.Equal(res.GetOriginalRequest(), req) {
+			t.Errorf("unexpected response header %v", res)
+		}
+
+		if got := reflectionFiles(t, res.GetFileDescriptorResponse().GetFileDescriptorProto()); len(got) != 2 || got[0] != "acme/v1/a.proto" {
+			t.Errorf("unexpected files %v", got)
+		}
+
+		if err := stream.CloseRequest(); err != nil {
+			t.Fatal(err)
+		}
+	})
+}
@@ -5,6 +5,8 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"net/http"
+	"time"
 
 	"github.com/bufbuild/connect-go"
 	typeserverv1 "github.com/tierklinik-dobersberg/apis/gen/go/tkd/typeserver/v1"
@@ -29,23 +31,26 @@ func New(registry *registry.Registry) *TypeServer {
 }
 
 func (srv *TypeServer) ResolveType(ctx context.Context, req *connect.Request[typeserverv1.ResolveRequest]) (*connect.Response[typeserverv1.ResolveResponse], error) {
-	var (
-		desc protoreflect.FileDescriptor
-		err  error
-	)
+	// the file and its provenance are looked up in the same snapshot
+	files, snap, err := snapshot(ctx, srv.registry, "")
+	if err != nil {
+		return nil, err
+	}
+
+	var desc protoreflect.FileDescriptor
 
 	switch v := req.Msg.Kind.(type) {
 	case *typeserverv1.ResolveRequest_FileByFilename:
 		slog.Info("resolving proto type", "filename", v.FileByFilename)
-		desc, err = srv.registry.FileByFilename(v.FileByFilename)
+		desc, err = files.FileByFilename(v.FileByFilename)
 
 	case *typeserverv1.ResolveRequest_FileContainingSymbol:
 		slog.Info("resolving proto type", "symbol", v.FileContainingSymbol)
-		desc, err = srv.registry.FileContainingSymbol(protoreflect.FullName(v.FileContainingSymbol))
+		desc, err = files.FileContainingSymbol(protoreflect.FullName(v.FileContainingSymbol))
 
 	case *typeserverv1.ResolveRequest_FileContainingUrl:
 		slog.Info("resolving proto type", "url", v.FileContainingUrl)
-		desc, err = srv.registry.FileContaingURL(v.FileContainingUrl)
+		desc, err = files.FileContaingURL(v.FileContainingUrl)
 
 	default:
 		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("no message kind specified"))
@@ -53,7 +58,7 @@ func (srv *TypeServer) ResolveType(ctx context.Context, req *connect.Request[typ
 
 	if err != nil {
 		if errors.Is(err, protoregistry.NotFound) {
-			return nil, connect.NewError(connect.CodeNotFound, err)
+			return nil, notFoundError(srv.registry, srv.registry, err, req.Msg.GetFileByFilename(), req.Msg.GetFileContainingSymbol(), req.Msg.GetFileContainingUrl())
 		}
 
 		return nil, err
@@ -66,12 +71,45 @@ func (srv *TypeServer) ResolveType(ctx context.Context, req *connect.Request[typ
 		return nil, err
 	}
 
-	return connect.NewResponse(&typeserverv1.ResolveResponse{
+	res := connect.NewResponse(&typeserverv1.ResolveResponse{
 		OriginalRequest: req.Msg,
 		MessageResponse: &typeserverv1.ResolveResponse_FileDescriptor{
 			FileDescriptor: &typeserverv1.FileDescriptorResponse{
 				FileDescriptorProto: blob,
 			},
 		},
-	}), nil
+	})
+
+	// the response message is defined elsewhere, so the provenance is
+	// sent as response metadata.
+	if snap != nil {
+		if prov, ok := snap.FileProvenance(desc.Path()); ok {
+			setProvenanceHeaders(res.Header(), prov)
+		}
+	}
+
+	return res, nil
+}
+
+// Response headers holding the provenance of the resolved file.
+const (
+	SourceHeader       = "X-Pbtype-Source"
+	URLHeader          = "X-Pbtype-Url"
+	RevisionHeader     = "X-Pbtype-Revision"
+	OriginalPathHeader = "X-Pbtype-Original-Path"
+	FetchedHeader      = "X-Pbtype-Fetched"
+)
+
+func setProvenanceHeaders(header http.Header, prov registry.Provenance) {
+	header.Set(SourceHeader, prov.Source)
+	header.Set(URLHeader, prov.URL)
+	header.Set(OriginalPathHeader, prov.Path)
+
+	if prov.Revision != "" {
+		header.Set(RevisionHeader, prov.Revision)
+	}
+
+	if !prov.Fetched.IsZero() {
+		header.Set(FetchedHeader, prov.Fetched.UTC().Format(time.RFC3339))
+	}
 }
@@ -0,0 +1,92 @@
+package service
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/bufbuild/connect-go"
+	typeserverv1 "github.com/tierklinik-dobersberg/apis/gen/go/tkd/typeserver/v1"
+	"github.com/tierklinik-dobersberg/pbtype-server/internal/config"
+	"github.com/tierklinik-dobersberg/pbtype-server/internal/registry"
+)
+
+// startRegistry returns a registry serving files from a local source
+// named "local", followed by sources.
+func startRegistry(t *testing.T, files map[string]string, sources ...config.Source) *registry.Registry {
+	t.Helper()
+
+	dir := t.TempDir()
+	for path, content := range files {
+		path = filepath.Join(dir, filepath.FromSlash(path))
+
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	cfg := config.Config{
+		RefreshInterval: config.Duration(time.Hour),
+		Sources:         append([]config.Source{{Name: "local", URL: dir}}, sources...),
+	}
+
+	if err := cfg.Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	reg := registry.New(cfg)
+	if err := reg.StartPolling(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := reg.Refresh(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	return reg
+}
+
+func TestResolveTypeProvenance(t *testing.T) {
+	reg := startRegistry(t, map[string]string{
+		"acme/v1/a.proto": `syntax = "proto3"; package acme.v1; message A {}`,
+	})
+
+	res, err := New(reg).ResolveType(context.Background(), connect.NewRequest(&typeserverv1.ResolveRequest{
+		Kind: &typeserverv1.ResolveRequest_FileContainingSymbol{FileContainingSymbol: "acme.v1.A"},
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	header := res.Header()
+
+	if got := header.Get(SourceHeader); got != "local" {
+		t.Errorf("unexpected source %q", got)
+	}
+
+	if got := header.Get(URLHeader); got == "" || got != reg.Sources()[0].URL {
+		t.Errorf("unexpected URL %q", got)
+	}
+
+	if got := header.Get(OriginalPathHeader); got != "acme/v1/a.proto" {
+		t.Errorf("unexpected original path %q", got)
+	}
+
+	// local sources have no revision
+	if got := header.Get(RevisionHeader); got != "" {
+		t.Errorf("unexpected revision %q", got)
+	}
+
+	if _, err := time.Parse(time.RFC3339, header.Get(FetchedHeader)); err != nil {
+		t.Errorf("unexpected fetch time: %s", err)
+	}
+}
@@ -0,0 +1,314 @@
+package webhook
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/tierklinik-dobersberg/pbtype-server/internal/config"
+	"github.com/tierklinik-dobersberg/pbtype-server/internal/registry"
+)
+
+// maxPayloadSize limits the size of accepted webhook payloads.
+const maxPayloadSize = 5 << 20
+
+// refreshTimeout limits how long a refresh triggered by a webhook may take.
+const refreshTimeout = 5 * time.Minute
+
+// Handler accepts push webhooks from GitHub, Gitea and GitLab and refreshes
+// all sources that match the pushed repository and ref.
+type Handler struct {
+	registry *registry.Registry
+	secret   []byte
+}
+
+func New(registry *registry.Registry, secret string) *Handler {
+	return &Handler{
+		registry: registry,
+		secret:   []byte(secret),
+	}
+}
+
+// pushEvent holds the pushed ref and the repository URLs of a push event.
+// The fields cover the payload formats of GitHub and Gitea (repository) as
+// well as GitLab (project).
+type pushEvent struct {
+	Ref string `json:"ref"`
+
+	Repository struct {
+		CloneURL      string `json:"clone_url"`
+		HTMLURL       string `json:"html_url"`
+		SSHURL        string `json:"ssh_url"`
+		DefaultBranch string `json:"default_branch"`
+	} `json:"repository"`
+
+	Project struct {
+		GitHTTPURL    string `json:"git_http_url"`
+		GitSSHURL     string `json:"git_ssh_url"`
+		WebURL        string `json:"web_url"`
+		DefaultBranch string `json:"default_branch"`
+	} `json:"project"`
+}
+
+func (e *pushEvent) urls() []string {
+	var result []string
+
+	for _, u := range []string{
+		e.Repository.CloneURL,
+		e.Repository.HTMLURL,
+		e.Repository.SSHURL,
+		e.Project.GitHTTPURL,
+		e.Project.GitSSHURL,
+		e.Project.WebURL,
+	} {
+		if u != "" {
+			result = append(result, u)
+		}
+	}
+
+	return result
+}
+
+// updates reports whether the push updates ref, the branch, tag or commit
+// checked out by a source. An empty ref refers to the default branch.
+// Pushes are assumed to update ref if the payload lacks the information
+// to decide.
+func (e *pushEvent) updates(ref string) bool {
+	if e.Ref == "" {
+		return true
+	}
+
+	if ref == "" {
+		ref = e.Repository.DefaultBranch
+		if ref == "" {
+			ref = e.Project.DefaultBranch
+		}
+
+		if ref == "" {
+			return true
+		}
+	}
+
+	return e.Ref == ref || e.Ref == "refs/heads/"+ref || e.Ref == "refs/tags/"+ref
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxPayloadSize))
+	if err != nil {
+		http.Error(w, "failed to read payload", http.StatusBadRequest)
+
+		return
+	}
+
+	event, err := h.verify(r.Header, body)
+	if err != nil {
+		slog.Warn("rejected webhook", "remote", r.RemoteAddr, "error", err)
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+
+		return
+	}
+
+	switch event {
+	case "ping":
+		w.WriteHeader(http.StatusNoContent)
+
+		return
+
+	case "push", "Push Hook", "Tag Push Hook":
+
+	default:
+		http.Error(w, fmt.Sprintf("unsupported event %q", event), http.StatusBadRequest)
+
+		return
+	}
+
+	var payload pushEvent
+	if err := json.Unmarshal(body, &payload); err != nil {
+		http.Error(w, "invalid payload", http.StatusBadRequest)
+
+		return
+	}
+
+	names, known := h.matchSources(&payload)
+	if !known {
+		http.Error(w, "no source matches the repository", http.StatusNotFound)
+
+		return
+	}
+
+	if len(names) == 0 {
+		slog.Info("ignoring webhook for a ref that is not checked out", "ref", payload.Ref)
+		w.WriteHeader(http.StatusNoContent)
+
+		return
+	}
+
+	slog.Info("refreshing sources triggered by webhook", "sources", names)
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), refreshTimeout)
+		defer cancel()
+
+		if err := h.registry.Refresh(ctx, names...); err != nil {
+			slog.Error("failed to refresh sources triggered by webhook", "sources", names, "error", err)
+		}
+	}()
+
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// verify checks the signature or token of a webhook request and returns the
+// event type.
+func (h *Handler) verify(header http.Header, body []byte) (string, error) {
+	if len(h.secret) == 0 {
+		return "", fmt.Errorf("no webhook secret configured")
+	}
+
+	switch {
+	// Gitea also sends X-GitHub-Event so it must be checked first.
+	case header.Get("X-Gitea-Event") != "":
+		if !h.validHMAC(header.Get("X-Gitea-Signature"), body) {
+			return "", fmt.Errorf("invalid signature")
+		}
+
+		return header.Get("X-Gitea-Event"), nil
+
+	case header.Get("X-GitHub-Event") != "":
+		signature, ok := strings.CutPrefix(header.Get("X-Hub-Signature-256"), "sha256=")
+		if !ok || !h.validHMAC(signature, body) {
+			return "", fmt.Errorf("invalid signature")
+		}
+
+		return header.Get("X-GitHub-Event"), nil
+
+	case header.Get("X-Gitlab-Event") != "":
+		// GitLab does not sign payloads but sends the shared secret as
+		// a token.
+		if subtle.ConstantTimeCompare([]byte(header.Get("X-Gitlab-Token")), h.secret) != 1 {
+			return "", fmt.Errorf("invalid token")
+		}
+
+		return header.Get("X-Gitlab-Event"), nil
+
+	default:
+		return "", fmt.Errorf("unsupported webhook format")
+	}
+}
+
+func (h *Handler) validHMAC(signature string, body []byte) bool {
+	expected, err := hex.DecodeString(signature)
+	if err != nil {
+		return false
+	}
+
+	mac := hmac.New(sha256.New, h.secret)
+	mac.Write(body)
+
+	return hmac.Equal(mac.Sum(nil), expected)
+}
+
+// matchSources returns the names of all sources whose repository matches
+// one of the URLs of event and whose ref is updated by it. known reports
+// whether any source matches the repository.
+func (h *Handler) matchSources(event *pushEvent) (names []string, known bool) {
+	repos := make(map[string]struct{})
+	for _, u := range event.urls() {
+		repos[normalizeRepository(u)] = struct{}{}
+	}
+
+	for _, src := range h.registry.Sources() {
+		repo := src.Repository
+		if repo == "" {
+			repo = src.URL
+		}
+
+		if _, ok := repos[normalizeRepository(repo)]; !ok {
+			continue
+		}
+
+		known = true
+
+		if event.updates(sourceRef(src.Source)) {
+			names = append(names, src.Name)
+		}
+	}
+
+	return names, known
+}
+
+// sourceRef returns the ref checked out for src, which is either its Ref
+// or the ref parameter of its URL. It is empty if the default branch is
+// checked out.
+func sourceRef(src config.Source) string {
+	if src.Ref != "" {
+		return src.Ref
+	}
+
+	_, query, ok := strings.Cut(src.URL, "?")
+	if !ok {
+		return ""
+	}
+
+	values, err := url.ParseQuery(query)
+	if err != nil {
+		return ""
+	}
+
+	return values.Get("ref")
+}
+
+// normalizeRepository normalizes a repository or go-getter URL to
+// host/path so different notations of the same repository can be compared.
+// For example, all of the following are normalized to
+// github.com/tierklinik-dobersberg/apis:
+//
+//	https://github.com/tierklinik-dobersberg/apis.git
+//	git@github.com:tierklinik-dobersberg/apis.git
+//	git::https://github.com/tierklinik-dobersberg/apis.git//proto?ref=main
+//	github.com/tierklinik-dobersberg/apis.git//proto
+func normalizeRepository(u string) string {
+	// strip forced go-getter getters like git::
+	if idx := strings.Index(u, "::"); idx >= 0 {
+		u = u[idx+2:]
+	}
+
+	// strip query parameters
+	u, _, _ = strings.Cut(u, "?")
+
+	// strip the scheme
+	if idx := strings.Index(u, "://"); idx >= 0 {
+		u = u[idx+3:]
+	} else if user, rest, ok := strings.Cut(u, "@"); ok && !strings.Contains(user, "/") {
+		// scp-like syntax: git@github.com:owner/repo.git
+		u = strings.Replace(rest, ":", "/", 1)
+	}
+
+	// strip user information
+	if user, rest, ok := strings.Cut(u, "@"); ok && !strings.Contains(user, "/") {
+		u = rest
+	}
+
+	// strip go-getter sub-directories
+	u, _, _ = strings.Cut(u, "//")
+
+	u = strings.TrimSuffix(u, "/")
+	u = strings.TrimSuffix(u, ".git")
+
+	return strings.ToLower(u)
+}
@@ -2,14 +2,18 @@ package resolver
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"strings"
+	"sync"
 
 	"github.com/bufbuild/connect-go"
 	typeserverv1 "github.com/tierklinik-dobersberg/apis/gen/go/tkd/typeserver/v1"
 	"github.com/tierklinik-dobersberg/apis/gen/go/tkd/typeserver/v1/typeserverv1connect"
 	"github.com/tierklinik-dobersberg/apis/pkg/cli"
+	pbtypev1 "github.com/tierklinik-dobersberg/pbtype-server/gen/go/tkd/pbtype/v1"
+	"github.com/tierklinik-dobersberg/pbtype-server/gen/go/tkd/pbtype/v1/pbtypev1connect"
 	"github.com/tierklinik-dobersberg/pbtype-server/pkg/protoresolve"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/reflect/protodesc"
@@ -36,18 +40,37 @@ type ClientFactory interface {
 	Create() (typeserverv1connect.TypeResolverServiceClient, error)
 }
 
+// DescriptorClientFactory may be implemented by a ClientFactory to also
+// create clients for the DescriptorService. If available, the Resolver
+// fetches a file and all of its dependencies using a single request.
+type DescriptorClientFactory interface {
+	CreateDescriptorClient() (pbtypev1connect.DescriptorServiceClient, error)
+}
+
 type staticClientFactory struct {
-	cli typeserverv1connect.TypeResolverServiceClient
+	cli        typeserverv1connect.TypeResolverServiceClient
+	descriptor pbtypev1connect.DescriptorServiceClient
 }
 
 func (s staticClientFactory) Create() (typeserverv1connect.TypeResolverServiceClient, error) {
 	return s.cli, nil
 }
 
+func (s staticClientFactory) CreateDescriptorClient() (pbtypev1connect.DescriptorServiceClient, error) {
+	return s.descriptor, nil
+}
+
 type Resolver struct {
 	factory ClientFactory
-	reg     *protoregistry.Files
-	types   *protoregistry.Types
+
+	// l guards reg and types, which are replaced when cached files are
+	// invalidated, and hashes, which holds the server-side hash of each
+	// file reported by Watch. Registries other than the global ones are
+	// not safe for concurrent use, so l is held for all accesses to them.
+	l      sync.RWMutex
+	reg    *protoregistry.Files
+	types  *protoregistry.Types
+	hashes map[string]string
 }
 
 func New(url string) *Resolver {
@@ -67,12 +90,12 @@ func WrapFactory(factory ClientFactory, files *protoregistry.Files, types *proto
 }
 
 func Wrap(url string, files *protoregistry.Files, types *protoregistry.Types) *Resolver {
+	httpClient := cli.NewInsecureHttp2Client()
+
 	return &Resolver{
 		factory: staticClientFactory{
-			cli: typeserverv1connect.NewTypeResolverServiceClient(
-				cli.NewInsecureHttp2Client(),
-				url,
-			),
+			cli:        typeserverv1connect.NewTypeResolverServiceClient(httpClient, url),
+			descriptor: pbtypev1connect.NewDescriptorServiceClient(httpClient, url),
 		},
 		reg:   files,
 		types: types,
@@ -117,10 +140,18 @@ func (h *Resolver) UnpackAny(m *anypb.Any) (proto.Message, error) {
 }
 
 func (h *Resolver) FindFileByPath(path string) (protoreflect.FileDescriptor, error) {
-	if res, err := h.reg.FindFileByPath(path); err == nil {
+	if res, err := h.findFileByPath(path); err == nil {
 		return res, nil
 	}
 
+	if desc, ok, err := h.resolveWithDependencies(&pbtypev1.ResolveRequest{
+		Kind: &pbtypev1.ResolveRequest_FileByFilename{
+			FileByFilename: path,
+		},
+	}); ok {
+		return desc, err
+	}
+
 	cli, err := h.factory.Create()
 	if err != nil {
 		return nil, err
@@ -138,7 +169,7 @@ func (h *Resolver) FindFileByPath(path string) (protoreflect.FileDescriptor, err
 }
 
 func (h *Resolver) FindDescriptorByName(name protoreflect.FullName) (protoreflect.Descriptor, error) {
-	if res, err := h.reg.FindDescriptorByName(name); err == nil {
+	if res, err := h.findDescriptorByName(name); err == nil {
 		slog.Debug("found type in local registry", "name", name)
 
 		return res, nil
@@ -146,6 +177,18 @@ func (h *Resolver) FindDescriptorByName(name protoreflect.FullName) (protoreflec
 
 	slog.Info("trying to resolve type", "name", name)
 
+	if _, ok, err := h.resolveWithDependencies(&pbtypev1.ResolveRequest{
+		Kind: &pbtypev1.ResolveRequest_FileContainingSymbol{
+			FileContainingSymbol: string(name),
+		},
+	}); ok {
+		if err != nil {
+			return nil, err
+		}
+
+		return h.findDescriptorByName(name)
+	}
+
 	cli, err := h.factory.Create()
 	if err != nil {
 		return nil, err
@@ -163,7 +206,166 @@ func (h *Resolver) FindDescriptorByName(name protoreflect.FullName) (protoreflec
 		return nil, err
 	}
 
-	return h.reg.FindDescriptorByName(name)
+	return h.findDescriptorByName(name)
+}
+
+// resolveWithDependencies resolves a file and all of its dependencies using
+// a single request to the DescriptorService. It returns false if the client
+// factory or the type server do not support the DescriptorService.
+func (h *Resolver) resolveWithDependencies(req *pbtypev1.ResolveRequest) (protoreflect.FileDescriptor, bool, error) {
+	factory, ok := h.factory.(DescriptorClientFactory)
+	if !ok {
+		return nil, false, nil
+	}
+
+	cli, err := factory.CreateDescriptorClient()
+	if err != nil {
+		return nil, true, err
+	}
+
+	// only one of the names is set
+	name := req.GetFileByFilename() + req.GetFileContainingSymbol() + req.GetFileContainingUrl()
+
+	req.IncludeDependencies = true
+	req.KnownFiles = h.knownFiles(name)
+
+	res, err := cli.Resolve(context.Background(), connect.NewRequest(req))
+	if err != nil {
+		if connect.CodeOf(err) == connect.CodeUnimplemented {
+			return nil, false, nil
+		}
+
+		return nil, true, err
+	}
+
+	var desc protoreflect.FileDescriptor
+
+	// files are ordered by dependency so each import is already registered
+	// when a file is parsed.
+	for _, blob := range res.Msg.FileDescriptorProtos {
+		desc, err = h.parseFileDescriptorProto(blob)
+		if err != nil {
+			return nil, true, err
+		}
+	}
+
+	if desc == nil {
+		return nil, true, fmt.Errorf("empty response from type server")
+	}
+
+	return desc, true, nil
+}
+
+// maxPrefetchItems is the maximum number of items the type server accepts
+// in a single BatchResolve request.
+const maxPrefetchItems = 500
+
+// Prefetch resolves multiple names using a single request to the type server
+// so later lookups can be answered from the local registry. Each name may
+// either be a file name (ending in .proto), a type URL or a fully-qualified
+// symbol name. Names that cannot be resolved are reported in the returned
+// error but do not prevent other names from being registered. More than
+// maxPrefetchItems names are split into multiple requests.
+func (h *Resolver) Prefetch(ctx context.Context, names ...string) error {
+	var errs []error
+
+	for len(names) > 0 {
+		n := min(len(names), maxPrefetchItems)
+
+		if err := h.prefetch(ctx, names[:n]); err != nil {
+			errs = append(errs, err)
+		}
+
+		names = names[n:]
+	}
+
+	return errors.Join(errs...)
+}
+
+// prefetch resolves names using a single BatchResolve request.
+func (h *Resolver) prefetch(ctx context.Context, names []string) error {
+	req := &pbtypev1.BatchResolveRequest{
+		IncludeDependencies: true,
+	}
+
+	for _, name := range names {
+		item := &pbtypev1.ResolveItem{}
+
+		switch {
+		case strings.HasSuffix(name, ".proto"):
+			item.Kind = &pbtypev1.ResolveItem_FileByFilename{FileByFilename: name}
+		case strings.Contains(name, "/"):
+			item.Kind = &pbtypev1.ResolveItem_FileContainingUrl{FileContainingUrl: name}
+		default:
+			item.Kind = &pbtypev1.ResolveItem_FileContainingSymbol{FileContainingSymbol: name}
+		}
+
+		req.Items = append(req.Items, item)
+	}
+
+	factory, ok := h.factory.(DescriptorClientFactory)
+	if !ok {
+		return h.prefetchEach(req.Items)
+	}
+
+	cli, err := factory.CreateDescriptorClient()
+	if err != nil {
+		return err
+	}
+
+	req.KnownFiles = h.knownFiles(names...)
+
+	res, err := cli.BatchResolve(ctx, connect.NewRequest(req))
+	if err != nil {
+		if connect.CodeOf(err) == connect.CodeUnimplemented {
+			return h.prefetchEach(req.Items)
+		}
+
+		return err
+	}
+
+	var errs []error
+
+	// the server lists the dependencies of all items before the items
+	// themselves, so a failed file only fails the files importing it.
+	for _, blob := range res.Msg.FileDescriptorProtos {
+		if _, err := h.parseFileDescriptorProto(blob); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	for idx, status := range res.Msg.Items {
+		if status.Error != "" {
+			errs = append(errs, fmt.Errorf("%s: %s", names[idx], status.Error))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// prefetchEach resolves each item using a dedicated request. It is used if
+// the type server does not support batch requests.
+func (h *Resolver) prefetchEach(items []*pbtypev1.ResolveItem) error {
+	var errs []error
+
+	for _, item := range items {
+		var err error
+
+		switch v := item.Kind.(type) {
+		case *pbtypev1.ResolveItem_FileByFilename:
+			_, err = h.FindFileByPath(v.FileByFilename)
+		case *pbtypev1.ResolveItem_FileContainingUrl:
+			_, err = h.FindMessageByURL(v.FileContainingUrl)
+		case *pbtypev1.ResolveItem_FileContainingSymbol:
+			_, err = h.FindDescriptorByName(protoreflect.FullName(v.FileContainingSymbol))
+		}
+
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
 }
 
 func (h *Resolver) parseFileDescriptorProto(blob []byte) (protoreflect.FileDescriptor, error) {
@@ -173,27 +375,44 @@ func (h *Resolver) parseFileDescriptorProto(blob []byte) (protoreflect.FileDescr
 		return nil, fmt.Errorf("failed to unmarshal file descriptor proto: %w", err)
 	}
 
+	// the file might already be known if it has been resolved as a
+	// dependency of another file.
+	if desc, err := h.findFileByPath(parsed.GetName()); err == nil {
+		return desc, nil
+	}
+
+	// NewFile resolves imports using h, so h.l must not be held here.
 	desc, err := protodesc.NewFile(parsed, h)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create file descriptor: %w", err)
 	}
 
+	h.l.Lock()
+	defer h.l.Unlock()
+
+	// the registries may have been replaced by Watch or the file may have
+	// been registered concurrently in the meantime.
+	files, types := h.reg, h.types
+	if known, err := files.FindFileByPath(desc.Path()); err == nil {
+		return known, nil
+	}
+
 	// register the file at the registry
-	h.reg.RegisterFile(desc)
+	files.RegisterFile(desc)
 
 	// also, register all message, enum and extension types
 	for idx := 0; idx < desc.Extensions().Len(); idx++ {
-		h.types.RegisterExtension(
+		types.RegisterExtension(
 			dynamicpb.NewExtensionType(desc.Extensions().Get(idx)),
 		)
 	}
 	for idx := 0; idx < desc.Messages().Len(); idx++ {
-		h.types.RegisterMessage(
+		types.RegisterMessage(
 			dynamicpb.NewMessageType(desc.Messages().Get(idx)),
 		)
 	}
 	for idx := 0; idx < desc.Enums().Len(); idx++ {
-		h.types.RegisterEnum(
+		types.RegisterEnum(
 			dynamicpb.NewEnumType(desc.Enums().Get(idx)),
 		)
 	}
@@ -202,10 +421,16 @@ func (h *Resolver) parseFileDescriptorProto(blob []byte) (protoreflect.FileDescr
 }
 
 func (h *Resolver) FindExtensionByName(name protoreflect.FullName) (protoreflect.ExtensionType, error) {
+	h.l.RLock()
+	defer h.l.RUnlock()
+
 	return h.types.FindExtensionByName(name)
 }
 
 func (h *Resolver) FindExtensionByNumber(message protoreflect.FullName, field protoreflect.FieldNumber) (protoreflect.ExtensionType, error) {
+	h.l.RLock()
+	defer h.l.RUnlock()
+
 	return h.types.FindExtensionByNumber(message, field)
 }
 
@@ -215,6 +440,9 @@ func (h *Resolver) FindMessageByName(name protoreflect.FullName) (protoreflect.M
 		return nil, err
 	}
 
+	h.l.RLock()
+	defer h.l.RUnlock()
+
 	return h.types.FindMessageByName(name)
 }
 
@@ -226,7 +454,83 @@ func (h *Resolver) FindMessageByURL(url string) (protoreflect.MessageType, error
 		return nil, err
 	}
 
+	h.l.RLock()
+	defer h.l.RUnlock()
+
 	return h.types.FindMessageByURL(url)
 }
 
+// findFileByPath looks up path in the local registry only.
+func (h *Resolver) findFileByPath(path string) (protoreflect.FileDescriptor, error) {
+	h.l.RLock()
+	defer h.l.RUnlock()
+
+	return h.reg.FindFileByPath(path)
+}
+
+// findDescriptorByName looks up name in the local registry only.
+func (h *Resolver) findDescriptorByName(name protoreflect.FullName) (protoreflect.Descriptor, error) {
+	h.l.RLock()
+	defer h.l.RUnlock()
+
+	return h.reg.FindDescriptorByName(name)
+}
+
+// maxKnownFiles is the maximum number of known files sent to the type
+// server with a single request.
+const maxKnownFiles = 250
+
+// knownFiles returns the paths of files in the local registry that likely
+// belong to the dependencies of names, which are file names, type URLs or
+// symbol names as accepted by Prefetch. A file is considered related if its
+// path or package starts with the same element as one of names, like acme
+// for acme/v1/a.proto and acme.v1.A. At most maxKnownFiles paths are
+// returned. Other files are sent by the type server again and ignored by
+// parseFileDescriptorProto.
+func (h *Resolver) knownFiles(names ...string) []string {
+	roots := make(map[string]struct{}, len(names))
+	for _, name := range names {
+		roots[rootOf(name)] = struct{}{}
+	}
+
+	related := func(fd protoreflect.FileDescriptor) bool {
+		pkg, _, _ := strings.Cut(string(fd.Package()), ".")
+		dir, _, _ := strings.Cut(fd.Path(), "/")
+
+		_, pkgOk := roots[pkg]
+		_, dirOk := roots[dir]
+
+		return pkgOk || dirOk
+	}
+
+	h.l.RLock()
+	defer h.l.RUnlock()
+
+	var result []string
+	h.reg.RangeFiles(func(fd protoreflect.FileDescriptor) bool {
+		if related(fd) {
+			result = append(result, fd.Path())
+		}
+
+		return len(result) < maxKnownFiles
+	})
+
+	return result
+}
+
+// rootOf returns the first element of the path of a file name or of the
+// package of a symbol name or type URL.
+func rootOf(name string) string {
+	if strings.HasSuffix(name, ".proto") {
+		root, _, _ := strings.Cut(name, "/")
+
+		return root
+	}
+
+	name = name[strings.LastIndex(name, "/")+1:]
+	root, _, _ := strings.Cut(name, ".")
+
+	return root
+}
+
 var _ protoresolve.Resolver = (*Resolver)(nil)
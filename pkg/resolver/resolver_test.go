@@ -0,0 +1,174 @@
+package resolver
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/tierklinik-dobersberg/apis/gen/go/tkd/typeserver/v1/typeserverv1connect"
+	"github.com/tierklinik-dobersberg/pbtype-server/gen/go/tkd/pbtype/v1/pbtypev1connect"
+	"github.com/tierklinik-dobersberg/pbtype-server/internal/config"
+	"github.com/tierklinik-dobersberg/pbtype-server/internal/registry"
+	"github.com/tierklinik-dobersberg/pbtype-server/internal/service"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+// testServer serves the files of a local source in dir and counts
+// requests by procedure.
+type testServer struct {
+	dir string
+	reg *registry.Registry
+
+	l        sync.Mutex
+	requests map[string]int
+}
+
+func (srv *testServer) count(procedure string) int {
+	srv.l.Lock()
+	defer srv.l.Unlock()
+
+	return srv.requests[procedure]
+}
+
+// startServer serves files from a local source and returns a Resolver
+// using it.
+func startServer(t *testing.T, files map[string]string) (*Resolver, *testServer) {
+	t.Helper()
+
+	dir := t.TempDir()
+	for path, content := range files {
+		path = filepath.Join(dir, filepath.FromSlash(path))
+
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	cfg := config.Config{
+		RefreshInterval: config.Duration(time.Hour),
+		Sources:         []config.Source{{Name: "local", URL: dir}},
+	}
+
+	if err := cfg.Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	reg := registry.New(cfg)
+	if err := reg.StartPolling(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := reg.Refresh(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle(typeserverv1connect.NewTypeResolverServiceHandler(service.New(reg)))
+	mux.Handle(pbtypev1connect.NewDescriptorServiceHandler(service.NewDescriptorServer(reg)))
+
+	srv := &testServer{dir: dir, reg: reg, requests: make(map[string]int)}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		srv.l.Lock()
+		srv.requests[r.URL.Path]++
+		srv.l.Unlock()
+
+		mux.ServeHTTP(w, r)
+	}))
+	t.Cleanup(server.Close)
+
+	factory := staticClientFactory{
+		cli:        typeserverv1connect.NewTypeResolverServiceClient(server.Client(), server.URL),
+		descriptor: pbtypev1connect.NewDescriptorServiceClient(server.Client(), server.URL),
+	}
+
+	return WrapFactory(factory, &protoregistry.Files{}, &protoregistry.Types{}), srv
+}
+
+func TestPrefetch(t *testing.T) {
+	h, _ := startServer(t, map[string]string{
+		"acme/v1/a.proto": `syntax = "proto3"; package acme.v1; import "acme/v1/b.proto"; message A { B b = 1; }`,
+		"acme/v1/b.proto": `syntax = "proto3"; package acme.v1; message B {}`,
+		"acme/v1/c.proto": `syntax = "proto3"; package acme.v1; message C {}`,
+		"acme/v1/d.proto": `syntax = "proto3"; package acme.v1; message D {}`,
+	})
+
+	err := h.Prefetch(context.Background(), "acme/v1/a.proto", "type.googleapis.com/acme.v1.C", "acme.v1.D", "acme.v1.Missing")
+	if err == nil || !strings.Contains(err.Error(), "acme.v1.Missing") {
+		t.Errorf("expected the missing symbol to be reported, got %v", err)
+	}
+
+	// all other names, including dependencies, are registered locally
+	for _, path := range []string{"acme/v1/a.proto", "acme/v1/b.proto", "acme/v1/c.proto", "acme/v1/d.proto"} {
+		if _, err := h.findFileByPath(path); err != nil {
+			t.Errorf("expected %s to be registered: %s", path, err)
+		}
+	}
+
+	// more names than fit into a single request are split
+	names := make([]string, maxPrefetchItems+1)
+	for idx := range names {
+		names[idx] = "acme.v1.A"
+	}
+
+	if err := h.Prefetch(context.Background(), names...); err != nil {
+		t.Errorf("expected all names to be resolved, got %s", err)
+	}
+}
+
+func TestResolveWithDependencies(t *testing.T) {
+	h, srv := startServer(t, map[string]string{
+		"acme/v1/a.proto":  `syntax = "proto3"; package acme.v1; import "acme/v1/b.proto"; message A { B b = 1; }`,
+		"acme/v1/b.proto":  `syntax = "proto3"; package acme.v1; message B {}`,
+		"other/v1/o.proto": `syntax = "proto3"; package other.v1; message O {}`,
+	})
+
+	if err := h.Prefetch(context.Background(), "acme.v1.B", "other.v1.O"); err != nil {
+		t.Fatal(err)
+	}
+
+	// only files related to the requested name are sent as known files
+	if got := h.knownFiles("acme.v1.A"); !slices.Equal(got, []string{"acme/v1/b.proto"}) {
+		t.Errorf("unexpected known files %v", got)
+	}
+
+	if _, err := h.FindDescriptorByName("acme.v1.A"); err != nil {
+		t.Fatal(err)
+	}
+
+	// the file and its dependencies are fetched using a single request
+	if n := srv.count(pbtypev1connect.DescriptorServiceResolveProcedure); n != 1 {
+		t.Errorf("expected a single Resolve request, got %d", n)
+	}
+
+	if n := srv.count(typeserverv1connect.TypeResolverServiceResolveTypeProcedure); n != 0 {
+		t.Errorf("expected no ResolveType requests, got %d", n)
+	}
+}
+
+func TestRootOf(t *testing.T) {
+	for name, want := range map[string]string{
+		"acme/v1/a.proto":               "acme",
+		"a.proto":                       "a.proto",
+		"acme.v1.A":                     "acme",
+		"type.googleapis.com/acme.v1.A": "acme",
+	} {
+		if got := rootOf(name); got != want {
+			t.Errorf("rootOf(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
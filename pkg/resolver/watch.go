@@ -0,0 +1,221 @@
+package resolver
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/bufbuild/connect-go"
+	pbtypev1 "github.com/tierklinik-dobersberg/pbtype-server/gen/go/tkd/pbtype/v1"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+// Watch subscribes to schema changes of the type server. Each time a file
+// that has been resolved before is modified or removed on the server, it is
+// dropped from the local registry together with all files that depend on
+// it. Dropped files that still exist on the server are fetched again right
+// away so later lookups see the new version.
+//
+// Since files cannot be removed from a protoregistry.Files, the registries
+// passed to Wrap or WrapFactory are replaced by copies once a file is
+// dropped.
+//
+// Watch blocks until ctx is cancelled or the stream fails. Callers usually
+// run Watch in a dedicated goroutine and call it again if it returns with
+// an error. It returns errors.ErrUnsupported if the client factory does not
+// support the DescriptorService.
+func (h *Resolver) Watch(ctx context.Context) error {
+	factory, ok := h.factory.(DescriptorClientFactory)
+	if !ok {
+		return errors.ErrUnsupported
+	}
+
+	cli, err := factory.CreateDescriptorClient()
+	if err != nil {
+		return err
+	}
+
+	stream, err := cli.Watch(ctx, connect.NewRequest(&pbtypev1.WatchRequest{}))
+	if err != nil {
+		return err
+	}
+	defer stream.Close()
+
+	for stream.Receive() {
+		refetch := h.applyChanges(stream.Msg())
+
+		if len(refetch) > 0 {
+			slog.Info("fetching changed proto files", "files", refetch)
+
+			if err := h.Prefetch(ctx, refetch...); err != nil {
+				slog.Error("failed to fetch changed proto files", "error", err)
+			}
+		}
+	}
+
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	return stream.Err()
+}
+
+// applyChanges records the file hashes of msg and drops all files that are
+// stale. It returns the paths of dropped files that still exist on the
+// server.
+func (h *Resolver) applyChanges(msg *pbtypev1.WatchResponse) []string {
+	h.l.Lock()
+	defer h.l.Unlock()
+
+	if h.hashes == nil {
+		h.hashes = make(map[string]string)
+	}
+
+	var (
+		stale   = make(map[string]struct{})
+		removed = make(map[string]struct{})
+		listed  = make(map[string]struct{}, len(msg.Changes))
+	)
+
+	for _, change := range msg.Changes {
+		prev, known := h.hashes[change.FileName]
+		listed[change.FileName] = struct{}{}
+
+		switch {
+		case change.Type == pbtypev1.FileChangeType_FILE_CHANGE_TYPE_REMOVED:
+			delete(h.hashes, change.FileName)
+			stale[change.FileName] = struct{}{}
+			removed[change.FileName] = struct{}{}
+
+			continue
+
+		case change.Type == pbtypev1.FileChangeType_FILE_CHANGE_TYPE_MODIFIED:
+			stale[change.FileName] = struct{}{}
+
+		case msg.Initial && known && prev != change.Hash:
+			// the file changed while we were not connected
+			stale[change.FileName] = struct{}{}
+		}
+
+		h.hashes[change.FileName] = change.Hash
+	}
+
+	if msg.Initial {
+		// files that have been removed while we were not connected
+		for path := range h.hashes {
+			if _, ok := listed[path]; !ok {
+				delete(h.hashes, path)
+				stale[path] = struct{}{}
+				removed[path] = struct{}{}
+			}
+		}
+	}
+
+	if len(stale) == 0 {
+		return nil
+	}
+
+	var refetch []string
+	for _, path := range h.invalidate(stale) {
+		if _, ok := removed[path]; !ok {
+			refetch = append(refetch, path)
+		}
+	}
+
+	return refetch
+}
+
+// invalidate replaces the local registries with copies that do not contain
+// the stale files and all files that depend on them. It returns the paths
+// of all dropped files. Callers must hold h.l.
+func (h *Resolver) invalidate(stale map[string]struct{}) []string {
+	var (
+		dropped = make(map[string]bool)
+		visit   func(fd protoreflect.FileDescriptor) bool
+	)
+
+	visit = func(fd protoreflect.FileDescriptor) bool {
+		if result, ok := dropped[fd.Path()]; ok {
+			return result
+		}
+
+		_, result := stale[fd.Path()]
+
+		// guard against import cycles while the file is being visited
+		dropped[fd.Path()] = result
+
+		imports := fd.Imports()
+		for i := 0; i < imports.Len() && !result; i++ {
+			result = visit(imports.Get(i).FileDescriptor)
+		}
+
+		dropped[fd.Path()] = result
+
+		return result
+	}
+
+	h.reg.RangeFiles(func(fd protoreflect.FileDescriptor) bool {
+		visit(fd)
+
+		return true
+	})
+
+	var paths []string
+	for path, drop := range dropped {
+		if drop {
+			paths = append(paths, path)
+		}
+	}
+
+	if len(paths) == 0 {
+		return nil
+	}
+
+	var (
+		files = new(protoregistry.Files)
+		types = new(protoregistry.Types)
+		keep  = func(desc protoreflect.Descriptor) bool {
+			return !dropped[desc.ParentFile().Path()]
+		}
+	)
+
+	h.reg.RangeFiles(func(fd protoreflect.FileDescriptor) bool {
+		if !dropped[fd.Path()] {
+			files.RegisterFile(fd)
+		}
+
+		return true
+	})
+
+	h.types.RangeMessages(func(mt protoreflect.MessageType) bool {
+		if keep(mt.Descriptor()) {
+			types.RegisterMessage(mt)
+		}
+
+		return true
+	})
+
+	h.types.RangeEnums(func(et protoreflect.EnumType) bool {
+		if keep(et.Descriptor()) {
+			types.RegisterEnum(et)
+		}
+
+		return true
+	})
+
+	h.types.RangeExtensions(func(xt protoreflect.ExtensionType) bool {
+		if keep(xt.TypeDescriptor()) {
+			types.RegisterExtension(xt)
+		}
+
+		return true
+	})
+
+	h.reg = files
+	h.types = types
+
+	slog.Info("dropped stale proto files", "files", paths)
+
+	return paths
+}
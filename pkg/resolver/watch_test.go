@@ -0,0 +1,150 @@
+package resolver
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	pbtypev1 "github.com/tierklinik-dobersberg/pbtype-server/gen/go/tkd/pbtype/v1"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// waitFor fails the test if cond does not become true within a few
+// seconds.
+func waitFor(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+
+	for deadline := time.Now().Add(5 * time.Second); !cond(); {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestWatch(t *testing.T) {
+	h, srv := startServer(t, map[string]string{
+		"acme/v1/a.proto": `syntax = "proto3"; package acme.v1; import "acme/v1/b.proto"; message A { B b = 1; }`,
+		"acme/v1/b.proto": `syntax = "proto3"; package acme.v1; message B {}`,
+		"acme/v1/c.proto": `syntax = "proto3"; package acme.v1; message C {}`,
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	if err := h.Prefetch(ctx, "acme.v1.A", "acme.v1.C"); err != nil {
+		t.Fatal(err)
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- h.Watch(ctx)
+	}()
+
+	waitFor(t, "the initial hashes", func() bool {
+		h.l.RLock()
+		defer h.l.RUnlock()
+
+		return len(h.hashes) == 3
+	})
+
+	// modify b and remove c
+	if err := os.WriteFile(filepath.Join(srv.dir, "acme/v1/b.proto"), []byte(`syntax = "proto3"; package acme.v1; message B { string name = 1; }`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.Remove(filepath.Join(srv.dir, "acme/v1/c.proto")); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := srv.reg.Refresh(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	// the modified file and the file importing it are fetched again
+	waitFor(t, "the modified file", func() bool {
+		desc, err := h.findDescriptorByName("acme.v1.B")
+
+		return err == nil && desc.(protoreflect.MessageDescriptor).Fields().Len() == 1
+	})
+
+	waitFor(t, "the importing file", func() bool {
+		_, err := h.findDescriptorByName("acme.v1.A")
+
+		return err == nil
+	})
+
+	// the removed file is evicted
+	if _, err := h.findFileByPath("acme/v1/c.proto"); err == nil {
+		t.Errorf("expected the removed file to be evicted")
+	}
+
+	if _, err := h.FindMessageByURL("type.googleapis.com/acme.v1.C"); err == nil {
+		t.Errorf("expected the removed type to be gone")
+	}
+
+	cancel()
+
+	if err := <-done; err != context.Canceled {
+		t.Errorf("expected Watch to return once cancelled, got %v", err)
+	}
+}
+
+func TestWatchReconnect(t *testing.T) {
+	h, _ := startServer(t, map[string]string{
+		"acme/v1/a.proto": `syntax = "proto3"; package acme.v1; message A {}`,
+		"acme/v1/b.proto": `syntax = "proto3"; package acme.v1; message B {}`,
+	})
+
+	if err := h.Prefetch(context.Background(), "acme.v1.A", "acme.v1.B"); err != nil {
+		t.Fatal(err)
+	}
+
+	initial := func(hashes map[string]string) *pbtypev1.WatchResponse {
+		msg := &pbtypev1.WatchResponse{Initial: true}
+		for path, hash := range hashes {
+			msg.Changes = append(msg.Changes, &pbtypev1.FileChange{
+				FileName: path,
+				Type:     pbtypev1.FileChangeType_FILE_CHANGE_TYPE_ADDED,
+				Hash:     hash,
+			})
+		}
+
+		return msg
+	}
+
+	hashes := map[string]string{"acme/v1/a.proto": "a1", "acme/v1/b.proto": "b1"}
+
+	// the first connection only records the hashes
+	if refetch := h.applyChanges(initial(hashes)); len(refetch) != 0 {
+		t.Errorf("unexpected refetch %v", refetch)
+	}
+
+	files := h.reg
+
+	// reconnecting without changes keeps all files
+	if refetch := h.applyChanges(initial(hashes)); len(refetch) != 0 || h.reg != files {
+		t.Errorf("expected no invalidations after reconnecting, got %v", refetch)
+	}
+
+	// files changed while disconnected are fetched again
+	hashes["acme/v1/b.proto"] = "b2"
+
+	if refetch := h.applyChanges(initial(hashes)); len(refetch) != 1 || refetch[0] != "acme/v1/b.proto" {
+		t.Errorf("expected b to be fetched again, got %v", refetch)
+	}
+
+	// files removed while disconnected are evicted
+	delete(hashes, "acme/v1/a.proto")
+
+	if refetch := h.applyChanges(initial(hashes)); len(refetch) != 0 {
+		t.Errorf("unexpected refetch %v", refetch)
+	}
+
+	if _, err := h.findFileByPath("acme/v1/a.proto"); err == nil {
+		t.Errorf("expected a to be evicted")
+	}
+}